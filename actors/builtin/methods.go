@@ -123,7 +123,25 @@ var MethodsStake = struct {
 	ChangeInflationFactor       abi.MethodNum
 	ChangeRootKey               abi.MethodNum
 	OnEpochTickEnd              abi.MethodNum
-}{MethodConstructor, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}
+	DepositFor                  abi.MethodNum
+	WithdrawDelegatedPrincipal  abi.MethodNum
+	RestakeReward               abi.MethodNum
+	SetAutoCompound             abi.MethodNum
+	AddSlasher                  abi.MethodNum
+	RemoveSlasher               abi.MethodNum
+	Slash                       abi.MethodNum
+	GetStakerInfo               abi.MethodNum
+	GetStakeParams              abi.MethodNum
+	GetTotalStakePower          abi.MethodNum
+	ClaimUnbonded               abi.MethodNum
+	ChangeUnbondingPeriod       abi.MethodNum
+	ChangeProposalDelay         abi.MethodNum
+	CancelProposal              abi.MethodNum
+	GetProposals                abi.MethodNum
+	ChangeRewardVestingSpec     abi.MethodNum
+	GetStakePower               abi.MethodNum
+	ChangeRewardCurve           abi.MethodNum
+}{MethodConstructor, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30}
 
 
 var MethodsToken = struct {
@@ -138,4 +156,27 @@ var MethodsToken = struct {
 	SafeBatchTransferFrom       abi.MethodNum
 	SetApproveForAll            abi.MethodNum
 	IsApproveForAll             abi.MethodNum
-}{MethodConstructor, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11}
+	TotalSupply                 abi.MethodNum
+	TotalSupplyBatch            abi.MethodNum
+	Burn                        abi.MethodNum
+	BurnBatch                   abi.MethodNum
+	Approve                     abi.MethodNum
+	Allowance                   abi.MethodNum
+	GetEvents                   abi.MethodNum
+	GetMetadata                 abi.MethodNum
+	TransferCreator             abi.MethodNum
+	AddMinter                   abi.MethodNum
+	RemoveMinter                abi.MethodNum
+	GetCreator                  abi.MethodNum
+	GetMinters                  abi.MethodNum
+	TokensOf                    abi.MethodNum
+	CreateWithMetadata          abi.MethodNum
+}{MethodConstructor, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26}
+
+// Methods the token actor invokes on a non-account actor receiving tokens.
+// Numbered above any builtin actor's exports so that actors which do not
+// implement them reject the transfer rather than run an unrelated method.
+var MethodsTokenReceiver = struct {
+	OnTokenReceived      abi.MethodNum
+	OnBatchTokenReceived abi.MethodNum
+}{1000, 1001}
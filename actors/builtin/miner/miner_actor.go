@@ -93,6 +93,13 @@ var _ runtime.VMActor = Actor{}
 // between the two, the construction parameters are defined in the power actor.
 type ConstructorParams = power.MinerConstructorParams
 
+// The stake power of a miner's owner, returned by the stake actor. In order to break a circular dependency
+// between the two, the return value is defined in the miner actor.
+type StakePowerReturn struct {
+	StakePower      abi.StakePower
+	TotalStakePower abi.StakePower
+}
+
 const GasOnMinerCreate = 666_666_666
 
 func (a Actor) Constructor(rt Runtime, params *ConstructorParams) *abi.EmptyValue {
@@ -557,6 +564,14 @@ func (a Actor) PreCommitSector(rt Runtime, params *PreCommitSectorParams) *abi.E
 
 	store := adt.AsStore(rt)
 	var st State
+	// The deposit is discounted by the owner's stake at pre-commit. It is returned on activation,
+	// when the initial pledge is discounted by the owner's stake at that time instead.
+	var ownerStake *StakePowerReturn
+	if StakePledgeDiscountEnabled() {
+		rt.StateReadonly(&st)
+		ownerStake = requestOwnerStakePower(rt, getMinerInfo(rt, &st).Owner)
+	}
+
 	var err error
 	newlyVested := big.Zero()
 	feeToBurn := abi.NewTokenAmount(0)
@@ -609,6 +624,9 @@ func (a Actor) PreCommitSector(rt Runtime, params *PreCommitSectorParams) *abi.E
 		duration := params.Expiration - rt.CurrEpoch()
 		sectorWeight := QAPowerForWeight(info.SectorSize, duration, dealWeight.DealWeight, dealWeight.VerifiedDealWeight)
 		depositReq := PreCommitDepositForPower(rewardStats.ThisEpochRewardSmoothed, pwrTotal.QualityAdjPowerSmoothed, sectorWeight)
+		if ownerStake != nil {
+			depositReq = PledgeDiscountedByStake(depositReq, ownerStake.StakePower, ownerStake.TotalStakePower)
+		}
 		if availableBalance.LessThan(depositReq) {
 			rt.Abortf(exitcode.ErrInsufficientFunds, "insufficient funds for pre-commit deposit: %v", depositReq)
 		}
@@ -746,6 +764,11 @@ func (a Actor) ConfirmSectorProofsValid(rt Runtime, params *builtin.ConfirmSecto
 	rt.StateReadonly(&st)
 	store := adt.AsStore(rt)
 	info := getMinerInfo(rt, &st)
+	// The initial pledge is discounted by the owner's stake at activation, whatever it was at pre-commit.
+	var ownerStake *StakePowerReturn
+	if StakePledgeDiscountEnabled() {
+		ownerStake = requestOwnerStakePower(rt, info.Owner)
+	}
 
 	//
 	// Activate storage deals.
@@ -829,6 +852,9 @@ func (a Actor) ConfirmSectorProofsValid(rt Runtime, params *builtin.ConfirmSecto
 			storagePledge := ExpectedRewardForPower(rewardStats.ThisEpochRewardSmoothed, pwrTotal.QualityAdjPowerSmoothed, pwr, InitialPledgeProjectionPeriod)
 			initialPledge := InitialPledgeForPower(pwr, rewardStats.ThisEpochBaselinePower, rewardStats.ThisEpochRewardSmoothed,
 				pwrTotal.QualityAdjPowerSmoothed, circulatingSupply)
+			if ownerStake != nil {
+				initialPledge = PledgeDiscountedByStake(initialPledge, ownerStake.StakePower, ownerStake.TotalStakePower)
+			}
 
 			// Lower-bound the pledge by that of the sector being replaced.
 			// Record the replaced age and reward rate for termination fee calculations.
@@ -2200,6 +2226,14 @@ func requestCurrentTotalPower(rt Runtime) *power.CurrentTotalPowerReturn {
 	return &pwr
 }
 
+// Requests the stake power of the miner's owner from the stake actor.
+func requestOwnerStakePower(rt Runtime, owner addr.Address) *StakePowerReturn {
+	var ret StakePowerReturn
+	code := rt.Send(builtin.StakeActorAddr, builtin.MethodsStake.GetStakePower, &owner, big.Zero(), &ret)
+	builtin.RequireSuccess(rt, code, "failed to check owner stake power")
+	return &ret
+}
+
 // Resolves an address to an ID address and verifies that it is address of an account or multisig actor.
 func resolveControlAddress(rt Runtime, raw addr.Address) addr.Address {
 	resolved, ok := rt.ResolveAddress(raw)
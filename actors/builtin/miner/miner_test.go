@@ -507,6 +507,44 @@ func TestCommitments(t *testing.T) {
 		assert.Equal(t, miner.NewPowerPairZero(), entry.FaultyPower)
 	})
 
+	t.Run("owner stake discounts pledge", func(t *testing.T) {
+		defer func(factor builtin.BigFrac) { miner.StakePledgeDiscountFactor = factor }(miner.StakePledgeDiscountFactor)
+		miner.StakePledgeDiscountFactor = builtin.BigFrac{Numerator: big.NewInt(1), Denominator: big.NewInt(1)}
+
+		actor := newHarness(t, periodOffset)
+		actor.ownerStake = miner.StakePowerReturn{StakePower: big.NewInt(100), TotalStakePower: big.NewInt(400)}
+		rt := builderForHarness(actor).
+			WithBalance(bigBalance, big.Zero()).
+			Build(t)
+		precommitEpoch := periodOffset + 1
+		rt.SetEpoch(precommitEpoch)
+		actor.constructAndVerify(rt)
+		dlInfo := actor.deadline(rt)
+
+		sectorNo := abi.SectorNumber(100)
+		expiration := dlInfo.PeriodEnd() + defaultSectorExpiration*miner.WPoStProvingPeriod
+		precommit := actor.preCommitSector(rt, actor.makePreCommit(sectorNo, precommitEpoch-1, expiration, nil), preCommitConf{})
+
+		// the owner holds a quarter of stake power
+		pwrEstimate := miner.QAPowerForWeight(actor.sectorSize, expiration-precommitEpoch, big.Zero(), big.Zero())
+		deposit := miner.PreCommitDepositForPower(actor.epochRewardSmooth, actor.epochQAPowerSmooth, pwrEstimate)
+		expectedDeposit := big.Sub(deposit, big.Div(deposit, big.NewInt(4)))
+		assert.Equal(t, expectedDeposit, precommit.PreCommitDeposit)
+
+		// by activation the owner holds half of stake power, which discounts the pledge instead
+		actor.ownerStake = miner.StakePowerReturn{StakePower: big.NewInt(200), TotalStakePower: big.NewInt(400)}
+		rt.SetEpoch(precommitEpoch + miner.PreCommitChallengeDelay + 1)
+		actor.proveCommitSectorAndConfirm(rt, precommit, makeProveCommit(sectorNo), proveCommitConf{})
+
+		qaPower := miner.QAPowerForWeight(actor.sectorSize, expiration-rt.Epoch(), big.Zero(), big.Zero())
+		pledge := miner.InitialPledgeForPower(qaPower, actor.baselinePower, actor.epochRewardSmooth,
+			actor.epochQAPowerSmooth, rt.TotalFilCircSupply())
+		expectedPledge := big.Sub(pledge, big.Div(pledge, big.NewInt(2)))
+		assert.Equal(t, expectedPledge, actor.getSector(rt, sectorNo).InitialPledge)
+		assert.Equal(t, expectedPledge, getState(rt).InitialPledge)
+		actor.checkState(rt)
+	})
+
 	t.Run("deal space exceeds sector space", func(t *testing.T) {
 		actor := newHarness(t, periodOffset)
 		rt := builderForHarness(actor).
@@ -4230,6 +4268,8 @@ type actorHarness struct {
 
 	epochRewardSmooth  smoothing.FilterEstimate
 	epochQAPowerSmooth smoothing.FilterEstimate
+
+	ownerStake miner.StakePowerReturn
 }
 
 func newHarness(t testing.TB, provingPeriodOffset abi.ChainEpoch) *actorHarness {
@@ -4262,6 +4302,8 @@ func newHarness(t testing.TB, provingPeriodOffset abi.ChainEpoch) *actorHarness
 
 		epochRewardSmooth:  smoothing.TestingConstantEstimate(rwd),
 		epochQAPowerSmooth: smoothing.TestingConstantEstimate(pwr),
+
+		ownerStake: miner.StakePowerReturn{StakePower: big.Zero(), TotalStakePower: big.Zero()},
 	}
 	h.setProofType(abi.RegisteredSealProof_StackedDrg32GiBV1)
 	return h
@@ -4560,6 +4602,7 @@ func (h *actorHarness) preCommitSector(rt *mock.Runtime, params *miner.PreCommit
 		}
 		rt.ExpectSend(builtin.StorageMarketActorAddr, builtin.MethodsMarket.VerifyDealsForActivation, &vdParams, big.Zero(), &vdReturn, exitcode.Ok)
 	}
+	expectQueryOwnerStake(rt, h)
 	st := getState(rt)
 
 	if conf.pledgeDelta != nil {
@@ -4633,6 +4676,7 @@ func (h *actorHarness) proveCommitSector(rt *mock.Runtime, precommit *miner.Sect
 func (h *actorHarness) confirmSectorProofsValid(rt *mock.Runtime, conf proveCommitConf, precommits ...*miner.SectorPreCommitOnChainInfo) {
 	// expect calls to get network stats
 	expectQueryNetworkInfo(rt, h)
+	expectQueryOwnerStake(rt, h)
 
 	// Prepare for and receive call to ConfirmSectorProofsValid.
 	var validPrecommits []*miner.SectorPreCommitOnChainInfo
@@ -4671,6 +4715,7 @@ func (h *actorHarness) confirmSectorProofsValid(rt *mock.Runtime, conf proveComm
 				expectRawPower = big.Add(expectRawPower, big.NewIntUnsigned(uint64(h.sectorSize)))
 				pledge := miner.InitialPledgeForPower(qaPowerDelta, h.baselinePower, h.epochRewardSmooth,
 					h.epochQAPowerSmooth, rt.TotalFilCircSupply())
+				pledge = miner.PledgeDiscountedByStake(pledge, h.ownerStake.StakePower, h.ownerStake.TotalStakePower)
 
 				// if cc upgrade, pledge is max of new and replaced pledges
 				if precommitOnChain.Info.ReplaceCapacity {
@@ -5592,6 +5637,20 @@ func fixedHasher(target uint64) func([]byte) [32]byte {
 	}
 }
 
+func expectQueryOwnerStake(rt *mock.Runtime, h *actorHarness) {
+	if !miner.StakePledgeDiscountEnabled() {
+		return
+	}
+	rt.ExpectSend(
+		builtin.StakeActorAddr,
+		builtin.MethodsStake.GetStakePower,
+		&h.owner,
+		big.Zero(),
+		&h.ownerStake,
+		exitcode.Ok,
+	)
+}
+
 func expectQueryNetworkInfo(rt *mock.Runtime, h *actorHarness) {
 	currentPower := power.CurrentTotalPowerReturn{
 		RawBytePower:            h.networkRawPower,
@@ -31,6 +31,20 @@ var InitialPledgeLockTarget = builtin.BigFrac{
 	Denominator: big.NewInt(10),
 }
 
+// Multiplier of the owner's share of total stake power giving the fraction by which stake discounts pledge.
+// A zero numerator disables the discount, and the stake actor is not consulted.
+var StakePledgeDiscountFactor = builtin.BigFrac{ // PARAM_SPEC
+	Numerator:   big.Zero(),
+	Denominator: big.NewInt(1),
+}
+
+// Maximum fraction by which stake discounts pledge.
+// Pledge never falls below the floor of (1 - StakePledgeDiscountMax) times its undiscounted value.
+var StakePledgeDiscountMax = builtin.BigFrac{ // PARAM_SPEC
+	Numerator:   big.NewInt(1),
+	Denominator: big.NewInt(2),
+}
+
 // Projection period of expected daily sector block reward penalised when a fault is continued after initial detection.
 // This guarantees that a miner pays back at least the expected block reward earned since the last successful PoSt.
 // The network conservatively assumes the sector was faulty since the last time it was proven.
@@ -138,6 +152,25 @@ func InitialPledgeForPower(qaPower, baselinePower abi.StoragePower, rewardEstima
 	return abi.NewTokenAmount(0)
 }
 
+// Whether pledge is discounted by the stake of the miner's owner.
+func StakePledgeDiscountEnabled() bool {
+	return StakePledgeDiscountFactor.Numerator.GreaterThan(big.Zero()) && StakePledgeDiscountMax.Numerator.GreaterThan(big.Zero())
+}
+
+// Discounts a pledge requirement by the owner's share of total stake power.
+// Discount = Pledge * StakePledgeDiscountFactor * stakePower / totalStakePower
+// The discount is capped at Pledge * StakePledgeDiscountMax.
+func PledgeDiscountedByStake(pledge abi.TokenAmount, stakePower, totalStakePower abi.StakePower) abi.TokenAmount {
+	if !StakePledgeDiscountEnabled() || stakePower.LessThanEqual(big.Zero()) || totalStakePower.LessThanEqual(big.Zero()) {
+		return pledge
+	}
+	discount := big.Div(
+		big.Mul(big.Mul(pledge, stakePower), StakePledgeDiscountFactor.Numerator),
+		big.Mul(totalStakePower, StakePledgeDiscountFactor.Denominator))
+	maxDiscount := big.Div(big.Mul(pledge, StakePledgeDiscountMax.Numerator), StakePledgeDiscountMax.Denominator)
+	return big.Sub(pledge, big.Min(discount, maxDiscount))
+}
+
 // Repays all fee debt and then verifies that the miner has amount needed to cover
 // the pledge requirement after burning all fee debt.  If not aborts.
 // Returns an amount that must be burnt by the actor.
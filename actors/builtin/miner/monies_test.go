@@ -154,3 +154,38 @@ func TestNegativeBRClamp(t *testing.T) {
 	fourBR := miner.ExpectedRewardForPower(rewardEstimate, powerEstimate, qaSectorPower, abi.ChainEpoch(4))
 	assert.Equal(t, big.Zero(), fourBR)
 }
+
+func TestPledgeDiscountedByStake(t *testing.T) {
+	pledge := abi.NewTokenAmount(1 << 40)
+	totalStake := abi.NewStoragePower(1000)
+
+	defer func(factor, max builtin.BigFrac) {
+		miner.StakePledgeDiscountFactor = factor
+		miner.StakePledgeDiscountMax = max
+	}(miner.StakePledgeDiscountFactor, miner.StakePledgeDiscountMax)
+
+	t.Run("disabled by default", func(t *testing.T) {
+		assert.False(t, miner.StakePledgeDiscountEnabled())
+		assert.Equal(t, pledge, miner.PledgeDiscountedByStake(pledge, big.NewInt(1000), totalStake))
+	})
+
+	miner.StakePledgeDiscountFactor = builtin.BigFrac{Numerator: big.NewInt(2), Denominator: big.NewInt(1)}
+	miner.StakePledgeDiscountMax = builtin.BigFrac{Numerator: big.NewInt(1), Denominator: big.NewInt(2)}
+
+	t.Run("no stake", func(t *testing.T) {
+		assert.Equal(t, pledge, miner.PledgeDiscountedByStake(pledge, big.Zero(), totalStake))
+		assert.Equal(t, pledge, miner.PledgeDiscountedByStake(pledge, big.Zero(), big.Zero()))
+	})
+
+	t.Run("discount in proportion to stake share", func(t *testing.T) {
+		// a 10% share of stake discounts 20%
+		expected := big.Sub(pledge, big.Div(pledge, big.NewInt(5)))
+		assert.Equal(t, expected, miner.PledgeDiscountedByStake(pledge, big.NewInt(100), totalStake))
+	})
+
+	t.Run("discount is capped at the floor", func(t *testing.T) {
+		floor := big.Div(pledge, big.NewInt(2))
+		assert.Equal(t, floor, miner.PledgeDiscountedByStake(pledge, big.NewInt(250), totalStake))
+		assert.Equal(t, floor, miner.PledgeDiscountedByStake(pledge, totalStake, totalStake))
+	})
+}
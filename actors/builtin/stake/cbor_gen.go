@@ -7,14 +7,13 @@ import (
 	"io"
 
 	abi "github.com/filecoin-project/go-state-types/abi"
-	stake "github.com/filecoin-project/specs-actors/v2/actors/builtin/stake"
 	cbg "github.com/whyrusleeping/cbor-gen"
 	xerrors "golang.org/x/xerrors"
 )
 
 var _ = xerrors.Errorf
 
-var lengthBufState = []byte{144}
+var lengthBufState = []byte{152, 29}
 
 func (t *State) MarshalCBOR(w io.Writer) error {
 	if t == nil {
@@ -85,6 +84,11 @@ func (t *State) MarshalCBOR(w io.Writer) error {
 		return err
 	}
 
+	// t.RewardCurve (stake.RewardCurveParams) (struct)
+	if err := t.RewardCurve.MarshalCBOR(w); err != nil {
+		return err
+	}
+
 	// t.LastRoundReward (big.Int) (struct)
 	if err := t.LastRoundReward.MarshalCBOR(w); err != nil {
 		return err
@@ -142,6 +146,98 @@ func (t *State) MarshalCBOR(w io.Writer) error {
 		return xerrors.Errorf("failed to write cid field t.AvailableRewardMap: %w", err)
 	}
 
+	// t.StakerEventQueue (cid.Cid) (struct)
+
+	if err := cbg.WriteCidBuf(scratch, w, t.StakerEventQueue); err != nil {
+		return xerrors.Errorf("failed to write cid field t.StakerEventQueue: %w", err)
+	}
+
+	// t.FirstEventEpoch (abi.ChainEpoch) (int64)
+	if t.FirstEventEpoch >= 0 {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.FirstEventEpoch)); err != nil {
+			return err
+		}
+	} else {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajNegativeInt, uint64(-t.FirstEventEpoch-1)); err != nil {
+			return err
+		}
+	}
+
+	// t.Delegations (cid.Cid) (struct)
+
+	if err := cbg.WriteCidBuf(scratch, w, t.Delegations); err != nil {
+		return xerrors.Errorf("failed to write cid field t.Delegations: %w", err)
+	}
+
+	// t.AutoCompound (cid.Cid) (struct)
+
+	if err := cbg.WriteCidBuf(scratch, w, t.AutoCompound); err != nil {
+		return xerrors.Errorf("failed to write cid field t.AutoCompound: %w", err)
+	}
+
+	// t.Slashers (cid.Cid) (struct)
+
+	if err := cbg.WriteCidBuf(scratch, w, t.Slashers); err != nil {
+		return xerrors.Errorf("failed to write cid field t.Slashers: %w", err)
+	}
+
+	// t.UnbondingPeriod (abi.ChainEpoch) (int64)
+	if t.UnbondingPeriod >= 0 {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.UnbondingPeriod)); err != nil {
+			return err
+		}
+	} else {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajNegativeInt, uint64(-t.UnbondingPeriod-1)); err != nil {
+			return err
+		}
+	}
+
+	// t.UnbondingMap (cid.Cid) (struct)
+
+	if err := cbg.WriteCidBuf(scratch, w, t.UnbondingMap); err != nil {
+		return xerrors.Errorf("failed to write cid field t.UnbondingMap: %w", err)
+	}
+
+	// t.ProposalDelay (abi.ChainEpoch) (int64)
+	if t.ProposalDelay >= 0 {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.ProposalDelay)); err != nil {
+			return err
+		}
+	} else {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajNegativeInt, uint64(-t.ProposalDelay-1)); err != nil {
+			return err
+		}
+	}
+
+	// t.Proposals (cid.Cid) (struct)
+
+	if err := cbg.WriteCidBuf(scratch, w, t.Proposals); err != nil {
+		return xerrors.Errorf("failed to write cid field t.Proposals: %w", err)
+	}
+
+	// t.NextProposalID (stake.ProposalID) (int64)
+	if t.NextProposalID >= 0 {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.NextProposalID)); err != nil {
+			return err
+		}
+	} else {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajNegativeInt, uint64(-t.NextProposalID-1)); err != nil {
+			return err
+		}
+	}
+
+	// t.RewardVestingSpecs (cid.Cid) (struct)
+
+	if err := cbg.WriteCidBuf(scratch, w, t.RewardVestingSpecs); err != nil {
+		return xerrors.Errorf("failed to write cid field t.RewardVestingSpecs: %w", err)
+	}
+
+	// t.CurrentRewardVestingSpec (uint64) (uint64)
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.CurrentRewardVestingSpec)); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -159,7 +255,7 @@ func (t *State) UnmarshalCBOR(r io.Reader) error {
 		return fmt.Errorf("cbor input should be of type array")
 	}
 
-	if extra != 16 {
+	if extra != 29 {
 		return fmt.Errorf("cbor input had wrong number of fields")
 	}
 
@@ -282,6 +378,15 @@ func (t *State) UnmarshalCBOR(r io.Reader) error {
 			return xerrors.Errorf("unmarshaling t.InflationFactor: %w", err)
 		}
 
+	}
+	// t.RewardCurve (stake.RewardCurveParams) (struct)
+
+	{
+
+		if err := t.RewardCurve.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.RewardCurve: %w", err)
+		}
+
 	}
 	// t.LastRoundReward (big.Int) (struct)
 
@@ -401,6 +506,204 @@ func (t *State) UnmarshalCBOR(r io.Reader) error {
 
 		t.AvailableRewardMap = c
 
+	}
+	// t.StakerEventQueue (cid.Cid) (struct)
+
+	{
+
+		c, err := cbg.ReadCid(br)
+		if err != nil {
+			return xerrors.Errorf("failed to read cid field t.StakerEventQueue: %w", err)
+		}
+
+		t.StakerEventQueue = c
+
+	}
+	// t.FirstEventEpoch (abi.ChainEpoch) (int64)
+	{
+		maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+		var extraI int64
+		if err != nil {
+			return err
+		}
+		switch maj {
+		case cbg.MajUnsignedInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 positive overflow")
+			}
+		case cbg.MajNegativeInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 negative oveflow")
+			}
+			extraI = -1 - extraI
+		default:
+			return fmt.Errorf("wrong type for int64 field: %d", maj)
+		}
+
+		t.FirstEventEpoch = abi.ChainEpoch(extraI)
+	}
+	// t.Delegations (cid.Cid) (struct)
+
+	{
+
+		c, err := cbg.ReadCid(br)
+		if err != nil {
+			return xerrors.Errorf("failed to read cid field t.Delegations: %w", err)
+		}
+
+		t.Delegations = c
+
+	}
+	// t.AutoCompound (cid.Cid) (struct)
+
+	{
+
+		c, err := cbg.ReadCid(br)
+		if err != nil {
+			return xerrors.Errorf("failed to read cid field t.AutoCompound: %w", err)
+		}
+
+		t.AutoCompound = c
+
+	}
+	// t.Slashers (cid.Cid) (struct)
+
+	{
+
+		c, err := cbg.ReadCid(br)
+		if err != nil {
+			return xerrors.Errorf("failed to read cid field t.Slashers: %w", err)
+		}
+
+		t.Slashers = c
+
+	}
+	// t.UnbondingPeriod (abi.ChainEpoch) (int64)
+	{
+		maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+		var extraI int64
+		if err != nil {
+			return err
+		}
+		switch maj {
+		case cbg.MajUnsignedInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 positive overflow")
+			}
+		case cbg.MajNegativeInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 negative oveflow")
+			}
+			extraI = -1 - extraI
+		default:
+			return fmt.Errorf("wrong type for int64 field: %d", maj)
+		}
+
+		t.UnbondingPeriod = abi.ChainEpoch(extraI)
+	}
+	// t.UnbondingMap (cid.Cid) (struct)
+
+	{
+
+		c, err := cbg.ReadCid(br)
+		if err != nil {
+			return xerrors.Errorf("failed to read cid field t.UnbondingMap: %w", err)
+		}
+
+		t.UnbondingMap = c
+
+	}
+	// t.ProposalDelay (abi.ChainEpoch) (int64)
+	{
+		maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+		var extraI int64
+		if err != nil {
+			return err
+		}
+		switch maj {
+		case cbg.MajUnsignedInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 positive overflow")
+			}
+		case cbg.MajNegativeInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 negative oveflow")
+			}
+			extraI = -1 - extraI
+		default:
+			return fmt.Errorf("wrong type for int64 field: %d", maj)
+		}
+
+		t.ProposalDelay = abi.ChainEpoch(extraI)
+	}
+	// t.Proposals (cid.Cid) (struct)
+
+	{
+
+		c, err := cbg.ReadCid(br)
+		if err != nil {
+			return xerrors.Errorf("failed to read cid field t.Proposals: %w", err)
+		}
+
+		t.Proposals = c
+
+	}
+	// t.NextProposalID (stake.ProposalID) (int64)
+	{
+		maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+		var extraI int64
+		if err != nil {
+			return err
+		}
+		switch maj {
+		case cbg.MajUnsignedInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 positive overflow")
+			}
+		case cbg.MajNegativeInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 negative oveflow")
+			}
+			extraI = -1 - extraI
+		default:
+			return fmt.Errorf("wrong type for int64 field: %d", maj)
+		}
+
+		t.NextProposalID = ProposalID(extraI)
+	}
+	// t.RewardVestingSpecs (cid.Cid) (struct)
+
+	{
+
+		c, err := cbg.ReadCid(br)
+		if err != nil {
+			return xerrors.Errorf("failed to read cid field t.RewardVestingSpecs: %w", err)
+		}
+
+		t.RewardVestingSpecs = c
+
+	}
+	// t.CurrentRewardVestingSpec (uint64) (uint64)
+
+	{
+
+		maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+		if err != nil {
+			return err
+		}
+		if maj != cbg.MajUnsignedInt {
+			return fmt.Errorf("wrong type for uint64 field")
+		}
+		t.CurrentRewardVestingSpec = uint64(extra)
+
 	}
 	return nil
 }
@@ -633,12 +936,12 @@ func (t *VestingFunds) UnmarshalCBOR(r io.Reader) error {
 	}
 
 	if extra > 0 {
-		t.Funds = make([]stake.VestingFund, extra)
+		t.Funds = make([]VestingFund, extra)
 	}
 
 	for i := 0; i < int(extra); i++ {
 
-		var v stake.VestingFund
+		var v VestingFund
 		if err := v.UnmarshalCBOR(br); err != nil {
 			return err
 		}
@@ -648,3 +951,2132 @@ func (t *VestingFunds) UnmarshalCBOR(r io.Reader) error {
 
 	return nil
 }
+
+var lengthBufVestingFund = []byte{131}
+
+func (t *VestingFund) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufVestingFund); err != nil {
+		return err
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.Epoch (abi.ChainEpoch) (int64)
+	if t.Epoch >= 0 {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.Epoch)); err != nil {
+			return err
+		}
+	} else {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajNegativeInt, uint64(-t.Epoch-1)); err != nil {
+			return err
+		}
+	}
+
+	// t.Amount (big.Int) (struct)
+	if err := t.Amount.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.Spec (uint64) (uint64)
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.Spec)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (t *VestingFund) UnmarshalCBOR(r io.Reader) error {
+	*t = VestingFund{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 3 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.Epoch (abi.ChainEpoch) (int64)
+	{
+		maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+		var extraI int64
+		if err != nil {
+			return err
+		}
+		switch maj {
+		case cbg.MajUnsignedInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 positive overflow")
+			}
+		case cbg.MajNegativeInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 negative oveflow")
+			}
+			extraI = -1 - extraI
+		default:
+			return fmt.Errorf("wrong type for int64 field: %d", maj)
+		}
+
+		t.Epoch = abi.ChainEpoch(extraI)
+	}
+	// t.Amount (big.Int) (struct)
+
+	{
+
+		if err := t.Amount.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.Amount: %w", err)
+		}
+
+	}
+	// t.Spec (uint64) (uint64)
+
+	{
+
+		maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+		if err != nil {
+			return err
+		}
+		if maj != cbg.MajUnsignedInt {
+			return fmt.Errorf("wrong type for uint64 field")
+		}
+		t.Spec = uint64(extra)
+
+	}
+	return nil
+}
+
+var lengthBufDelegation = []byte{133}
+
+func (t *Delegation) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufDelegation); err != nil {
+		return err
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.Depositor (address.Address) (struct)
+	if err := t.Depositor.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.RewardReceiver (address.Address) (struct)
+	if err := t.RewardReceiver.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.WithdrawPolicy (stake.WithdrawPolicy) (uint64)
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.WithdrawPolicy)); err != nil {
+		return err
+	}
+
+	// t.PendingWithdrawal (big.Int) (struct)
+	if err := t.PendingWithdrawal.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.PendingFromDepositor (bool) (bool)
+	if err := cbg.WriteBool(w, t.PendingFromDepositor); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (t *Delegation) UnmarshalCBOR(r io.Reader) error {
+	*t = Delegation{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 5 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.Depositor (address.Address) (struct)
+
+	{
+
+		if err := t.Depositor.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.Depositor: %w", err)
+		}
+
+	}
+	// t.RewardReceiver (address.Address) (struct)
+
+	{
+
+		if err := t.RewardReceiver.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.RewardReceiver: %w", err)
+		}
+
+	}
+	// t.WithdrawPolicy (stake.WithdrawPolicy) (uint64)
+
+	{
+
+		maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+		if err != nil {
+			return err
+		}
+		if maj != cbg.MajUnsignedInt {
+			return fmt.Errorf("wrong type for uint64 field")
+		}
+		t.WithdrawPolicy = WithdrawPolicy(extra)
+
+	}
+	// t.PendingWithdrawal (big.Int) (struct)
+
+	{
+
+		if err := t.PendingWithdrawal.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.PendingWithdrawal: %w", err)
+		}
+
+	}
+	// t.PendingFromDepositor (bool) (bool)
+
+	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajOther {
+		return fmt.Errorf("booleans must be major type 7")
+	}
+	switch extra {
+	case 20:
+		t.PendingFromDepositor = false
+	case 21:
+		t.PendingFromDepositor = true
+	default:
+		return fmt.Errorf("booleans are either major type 7, value 20 or 21 (got %d)", extra)
+	}
+	return nil
+}
+
+var lengthBufUnbondings = []byte{129}
+
+func (t *Unbondings) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufUnbondings); err != nil {
+		return err
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.Data ([]stake.Unbonding) (slice)
+	if len(t.Data) > cbg.MaxLength {
+		return xerrors.Errorf("Slice value in field t.Data was too long")
+	}
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajArray, uint64(len(t.Data))); err != nil {
+		return err
+	}
+	for _, v := range t.Data {
+		if err := v.MarshalCBOR(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *Unbondings) UnmarshalCBOR(r io.Reader) error {
+	*t = Unbondings{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 1 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.Data ([]stake.Unbonding) (slice)
+
+	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+
+	if extra > cbg.MaxLength {
+		return fmt.Errorf("t.Data: array too large (%d)", extra)
+	}
+
+	if maj != cbg.MajArray {
+		return fmt.Errorf("expected cbor array")
+	}
+
+	if extra > 0 {
+		t.Data = make([]Unbonding, extra)
+	}
+
+	for i := 0; i < int(extra); i++ {
+
+		var v Unbonding
+		if err := v.UnmarshalCBOR(br); err != nil {
+			return err
+		}
+
+		t.Data[i] = v
+	}
+
+	return nil
+}
+
+var lengthBufUnbonding = []byte{131}
+
+func (t *Unbonding) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufUnbonding); err != nil {
+		return err
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.Amount (big.Int) (struct)
+	if err := t.Amount.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.ReleaseEpoch (abi.ChainEpoch) (int64)
+	if t.ReleaseEpoch >= 0 {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.ReleaseEpoch)); err != nil {
+			return err
+		}
+	} else {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajNegativeInt, uint64(-t.ReleaseEpoch-1)); err != nil {
+			return err
+		}
+	}
+
+	// t.Recipient (address.Address) (struct)
+	if err := t.Recipient.MarshalCBOR(w); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (t *Unbonding) UnmarshalCBOR(r io.Reader) error {
+	*t = Unbonding{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 3 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.Amount (big.Int) (struct)
+
+	{
+
+		if err := t.Amount.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.Amount: %w", err)
+		}
+
+	}
+	// t.ReleaseEpoch (abi.ChainEpoch) (int64)
+	{
+		maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+		var extraI int64
+		if err != nil {
+			return err
+		}
+		switch maj {
+		case cbg.MajUnsignedInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 positive overflow")
+			}
+		case cbg.MajNegativeInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 negative oveflow")
+			}
+			extraI = -1 - extraI
+		default:
+			return fmt.Errorf("wrong type for int64 field: %d", maj)
+		}
+
+		t.ReleaseEpoch = abi.ChainEpoch(extraI)
+	}
+	// t.Recipient (address.Address) (struct)
+
+	{
+
+		if err := t.Recipient.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.Recipient: %w", err)
+		}
+
+	}
+	return nil
+}
+
+var lengthBufProposal = []byte{131}
+
+func (t *Proposal) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufProposal); err != nil {
+		return err
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.Method (abi.MethodNum) (uint64)
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.Method)); err != nil {
+		return err
+	}
+
+	// t.Params ([]uint8) (slice)
+	if len(t.Params) > cbg.ByteArrayMaxLen {
+		return xerrors.Errorf("Byte array in field t.Params was too long")
+	}
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajByteString, uint64(len(t.Params))); err != nil {
+		return err
+	}
+
+	if _, err := w.Write(t.Params[:]); err != nil {
+		return err
+	}
+
+	// t.ActivationEpoch (abi.ChainEpoch) (int64)
+	if t.ActivationEpoch >= 0 {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.ActivationEpoch)); err != nil {
+			return err
+		}
+	} else {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajNegativeInt, uint64(-t.ActivationEpoch-1)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *Proposal) UnmarshalCBOR(r io.Reader) error {
+	*t = Proposal{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 3 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.Method (abi.MethodNum) (uint64)
+
+	{
+
+		maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+		if err != nil {
+			return err
+		}
+		if maj != cbg.MajUnsignedInt {
+			return fmt.Errorf("wrong type for uint64 field")
+		}
+		t.Method = abi.MethodNum(extra)
+
+	}
+	// t.Params ([]uint8) (slice)
+
+	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+
+	if extra > cbg.ByteArrayMaxLen {
+		return fmt.Errorf("t.Params: byte array too large (%d)", extra)
+	}
+	if maj != cbg.MajByteString {
+		return fmt.Errorf("expected byte array")
+	}
+
+	if extra > 0 {
+		t.Params = make([]uint8, extra)
+	}
+
+	if _, err := io.ReadFull(br, t.Params[:]); err != nil {
+		return err
+	}
+	// t.ActivationEpoch (abi.ChainEpoch) (int64)
+	{
+		maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+		var extraI int64
+		if err != nil {
+			return err
+		}
+		switch maj {
+		case cbg.MajUnsignedInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 positive overflow")
+			}
+		case cbg.MajNegativeInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 negative oveflow")
+			}
+			extraI = -1 - extraI
+		default:
+			return fmt.Errorf("wrong type for int64 field: %d", maj)
+		}
+
+		t.ActivationEpoch = abi.ChainEpoch(extraI)
+	}
+	return nil
+}
+
+var lengthBufPendingProposal = []byte{130}
+
+func (t *PendingProposal) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufPendingProposal); err != nil {
+		return err
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.ID (stake.ProposalID) (int64)
+	if t.ID >= 0 {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.ID)); err != nil {
+			return err
+		}
+	} else {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajNegativeInt, uint64(-t.ID-1)); err != nil {
+			return err
+		}
+	}
+
+	// t.Proposal (stake.Proposal) (struct)
+	if err := t.Proposal.MarshalCBOR(w); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (t *PendingProposal) UnmarshalCBOR(r io.Reader) error {
+	*t = PendingProposal{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 2 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.ID (stake.ProposalID) (int64)
+	{
+		maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+		var extraI int64
+		if err != nil {
+			return err
+		}
+		switch maj {
+		case cbg.MajUnsignedInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 positive overflow")
+			}
+		case cbg.MajNegativeInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 negative oveflow")
+			}
+			extraI = -1 - extraI
+		default:
+			return fmt.Errorf("wrong type for int64 field: %d", maj)
+		}
+
+		t.ID = ProposalID(extraI)
+	}
+	// t.Proposal (stake.Proposal) (struct)
+
+	{
+
+		if err := t.Proposal.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.Proposal: %w", err)
+		}
+
+	}
+	return nil
+}
+
+var lengthBufRewardCurveParams = []byte{133}
+
+func (t *RewardCurveParams) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufRewardCurveParams); err != nil {
+		return err
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.Curve (stake.RewardCurve) (uint64)
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.Curve)); err != nil {
+		return err
+	}
+
+	// t.InitialRewardPerRound (big.Int) (struct)
+	if err := t.InitialRewardPerRound.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.HalfLife (abi.ChainEpoch) (int64)
+	if t.HalfLife >= 0 {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.HalfLife)); err != nil {
+			return err
+		}
+	} else {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajNegativeInt, uint64(-t.HalfLife-1)); err != nil {
+			return err
+		}
+	}
+
+	// t.TargetStakeRatio (big.Int) (struct)
+	if err := t.TargetStakeRatio.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.BudgetPerRound (big.Int) (struct)
+	if err := t.BudgetPerRound.MarshalCBOR(w); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (t *RewardCurveParams) UnmarshalCBOR(r io.Reader) error {
+	*t = RewardCurveParams{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 5 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.Curve (stake.RewardCurve) (uint64)
+
+	{
+
+		maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+		if err != nil {
+			return err
+		}
+		if maj != cbg.MajUnsignedInt {
+			return fmt.Errorf("wrong type for uint64 field")
+		}
+		t.Curve = RewardCurve(extra)
+
+	}
+	// t.InitialRewardPerRound (big.Int) (struct)
+
+	{
+
+		if err := t.InitialRewardPerRound.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.InitialRewardPerRound: %w", err)
+		}
+
+	}
+	// t.HalfLife (abi.ChainEpoch) (int64)
+	{
+		maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+		var extraI int64
+		if err != nil {
+			return err
+		}
+		switch maj {
+		case cbg.MajUnsignedInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 positive overflow")
+			}
+		case cbg.MajNegativeInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 negative oveflow")
+			}
+			extraI = -1 - extraI
+		default:
+			return fmt.Errorf("wrong type for int64 field: %d", maj)
+		}
+
+		t.HalfLife = abi.ChainEpoch(extraI)
+	}
+	// t.TargetStakeRatio (big.Int) (struct)
+
+	{
+
+		if err := t.TargetStakeRatio.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.TargetStakeRatio: %w", err)
+		}
+
+	}
+	// t.BudgetPerRound (big.Int) (struct)
+
+	{
+
+		if err := t.BudgetPerRound.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.BudgetPerRound: %w", err)
+		}
+
+	}
+	return nil
+}
+
+var lengthBufDepositForParams = []byte{131}
+
+func (t *DepositForParams) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufDepositForParams); err != nil {
+		return err
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.Beneficiary (address.Address) (struct)
+	if err := t.Beneficiary.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.RewardReceiver (address.Address) (struct)
+	if err := t.RewardReceiver.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.WithdrawPolicy (stake.WithdrawPolicy) (uint64)
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.WithdrawPolicy)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (t *DepositForParams) UnmarshalCBOR(r io.Reader) error {
+	*t = DepositForParams{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 3 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.Beneficiary (address.Address) (struct)
+
+	{
+
+		if err := t.Beneficiary.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.Beneficiary: %w", err)
+		}
+
+	}
+	// t.RewardReceiver (address.Address) (struct)
+
+	{
+
+		if err := t.RewardReceiver.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.RewardReceiver: %w", err)
+		}
+
+	}
+	// t.WithdrawPolicy (stake.WithdrawPolicy) (uint64)
+
+	{
+
+		maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+		if err != nil {
+			return err
+		}
+		if maj != cbg.MajUnsignedInt {
+			return fmt.Errorf("wrong type for uint64 field")
+		}
+		t.WithdrawPolicy = WithdrawPolicy(extra)
+
+	}
+	return nil
+}
+
+var lengthBufWithdrawDelegatedPrincipalParams = []byte{130}
+
+func (t *WithdrawDelegatedPrincipalParams) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufWithdrawDelegatedPrincipalParams); err != nil {
+		return err
+	}
+
+	// t.Staker (address.Address) (struct)
+	if err := t.Staker.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.AmountRequested (big.Int) (struct)
+	if err := t.AmountRequested.MarshalCBOR(w); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (t *WithdrawDelegatedPrincipalParams) UnmarshalCBOR(r io.Reader) error {
+	*t = WithdrawDelegatedPrincipalParams{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 2 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.Staker (address.Address) (struct)
+
+	{
+
+		if err := t.Staker.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.Staker: %w", err)
+		}
+
+	}
+	// t.AmountRequested (big.Int) (struct)
+
+	{
+
+		if err := t.AmountRequested.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.AmountRequested: %w", err)
+		}
+
+	}
+	return nil
+}
+
+var lengthBufRestakeRewardParams = []byte{129}
+
+func (t *RestakeRewardParams) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufRestakeRewardParams); err != nil {
+		return err
+	}
+
+	// t.Amount (big.Int) (struct)
+	if err := t.Amount.MarshalCBOR(w); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (t *RestakeRewardParams) UnmarshalCBOR(r io.Reader) error {
+	*t = RestakeRewardParams{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 1 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.Amount (big.Int) (struct)
+
+	{
+
+		if err := t.Amount.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.Amount: %w", err)
+		}
+
+	}
+	return nil
+}
+
+var lengthBufSetAutoCompoundParams = []byte{129}
+
+func (t *SetAutoCompoundParams) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufSetAutoCompoundParams); err != nil {
+		return err
+	}
+
+	// t.Enabled (bool) (bool)
+	if err := cbg.WriteBool(w, t.Enabled); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (t *SetAutoCompoundParams) UnmarshalCBOR(r io.Reader) error {
+	*t = SetAutoCompoundParams{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 1 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.Enabled (bool) (bool)
+
+	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajOther {
+		return fmt.Errorf("booleans must be major type 7")
+	}
+	switch extra {
+	case 20:
+		t.Enabled = false
+	case 21:
+		t.Enabled = true
+	default:
+		return fmt.Errorf("booleans are either major type 7, value 20 or 21 (got %d)", extra)
+	}
+	return nil
+}
+
+var lengthBufSlashParams = []byte{131}
+
+func (t *SlashParams) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufSlashParams); err != nil {
+		return err
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.Staker (address.Address) (struct)
+	if err := t.Staker.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.Amount (big.Int) (struct)
+	if err := t.Amount.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.Reason (string) (string)
+	if len(t.Reason) > cbg.MaxLength {
+		return xerrors.Errorf("Value in field t.Reason was too long")
+	}
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajTextString, uint64(len(t.Reason))); err != nil {
+		return err
+	}
+	if _, err := io.WriteString(w, string(t.Reason)); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (t *SlashParams) UnmarshalCBOR(r io.Reader) error {
+	*t = SlashParams{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 3 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.Staker (address.Address) (struct)
+
+	{
+
+		if err := t.Staker.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.Staker: %w", err)
+		}
+
+	}
+	// t.Amount (big.Int) (struct)
+
+	{
+
+		if err := t.Amount.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.Amount: %w", err)
+		}
+
+	}
+	// t.Reason (string) (string)
+
+	{
+		sval, err := cbg.ReadStringBuf(br, scratch)
+		if err != nil {
+			return err
+		}
+
+		t.Reason = string(sval)
+	}
+	return nil
+}
+
+var lengthBufGetStakerInfoReturn = []byte{134}
+
+func (t *GetStakerInfoReturn) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufGetStakerInfoReturn); err != nil {
+		return err
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.LockedPrincipals ([]stake.LockedPrincipal) (slice)
+	if len(t.LockedPrincipals) > cbg.MaxLength {
+		return xerrors.Errorf("Slice value in field t.LockedPrincipals was too long")
+	}
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajArray, uint64(len(t.LockedPrincipals))); err != nil {
+		return err
+	}
+	for _, v := range t.LockedPrincipals {
+		if err := v.MarshalCBOR(w); err != nil {
+			return err
+		}
+	}
+
+	// t.AvailablePrincipal (big.Int) (struct)
+	if err := t.AvailablePrincipal.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.StakePower (big.Int) (struct)
+	if err := t.StakePower.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.VestingFunds ([]stake.VestingFund) (slice)
+	if len(t.VestingFunds) > cbg.MaxLength {
+		return xerrors.Errorf("Slice value in field t.VestingFunds was too long")
+	}
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajArray, uint64(len(t.VestingFunds))); err != nil {
+		return err
+	}
+	for _, v := range t.VestingFunds {
+		if err := v.MarshalCBOR(w); err != nil {
+			return err
+		}
+	}
+
+	// t.AvailableReward (big.Int) (struct)
+	if err := t.AvailableReward.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.Unbondings ([]stake.Unbonding) (slice)
+	if len(t.Unbondings) > cbg.MaxLength {
+		return xerrors.Errorf("Slice value in field t.Unbondings was too long")
+	}
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajArray, uint64(len(t.Unbondings))); err != nil {
+		return err
+	}
+	for _, v := range t.Unbondings {
+		if err := v.MarshalCBOR(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *GetStakerInfoReturn) UnmarshalCBOR(r io.Reader) error {
+	*t = GetStakerInfoReturn{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 6 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.LockedPrincipals ([]stake.LockedPrincipal) (slice)
+
+	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+
+	if extra > cbg.MaxLength {
+		return fmt.Errorf("t.LockedPrincipals: array too large (%d)", extra)
+	}
+
+	if maj != cbg.MajArray {
+		return fmt.Errorf("expected cbor array")
+	}
+
+	if extra > 0 {
+		t.LockedPrincipals = make([]LockedPrincipal, extra)
+	}
+
+	for i := 0; i < int(extra); i++ {
+
+		var v LockedPrincipal
+		if err := v.UnmarshalCBOR(br); err != nil {
+			return err
+		}
+
+		t.LockedPrincipals[i] = v
+	}
+
+	// t.AvailablePrincipal (big.Int) (struct)
+
+	{
+
+		if err := t.AvailablePrincipal.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.AvailablePrincipal: %w", err)
+		}
+
+	}
+	// t.StakePower (big.Int) (struct)
+
+	{
+
+		if err := t.StakePower.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.StakePower: %w", err)
+		}
+
+	}
+	// t.VestingFunds ([]stake.VestingFund) (slice)
+
+	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+
+	if extra > cbg.MaxLength {
+		return fmt.Errorf("t.VestingFunds: array too large (%d)", extra)
+	}
+
+	if maj != cbg.MajArray {
+		return fmt.Errorf("expected cbor array")
+	}
+
+	if extra > 0 {
+		t.VestingFunds = make([]VestingFund, extra)
+	}
+
+	for i := 0; i < int(extra); i++ {
+
+		var v VestingFund
+		if err := v.UnmarshalCBOR(br); err != nil {
+			return err
+		}
+
+		t.VestingFunds[i] = v
+	}
+
+	// t.AvailableReward (big.Int) (struct)
+
+	{
+
+		if err := t.AvailableReward.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.AvailableReward: %w", err)
+		}
+
+	}
+	// t.Unbondings ([]stake.Unbonding) (slice)
+
+	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+
+	if extra > cbg.MaxLength {
+		return fmt.Errorf("t.Unbondings: array too large (%d)", extra)
+	}
+
+	if maj != cbg.MajArray {
+		return fmt.Errorf("expected cbor array")
+	}
+
+	if extra > 0 {
+		t.Unbondings = make([]Unbonding, extra)
+	}
+
+	for i := 0; i < int(extra); i++ {
+
+		var v Unbonding
+		if err := v.UnmarshalCBOR(br); err != nil {
+			return err
+		}
+
+		t.Unbondings[i] = v
+	}
+
+	return nil
+}
+
+var lengthBufGetStakeParamsReturn = []byte{138}
+
+func (t *GetStakeParamsReturn) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufGetStakeParamsReturn); err != nil {
+		return err
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.MaturePeriod (abi.ChainEpoch) (int64)
+	if t.MaturePeriod >= 0 {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.MaturePeriod)); err != nil {
+			return err
+		}
+	} else {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajNegativeInt, uint64(-t.MaturePeriod-1)); err != nil {
+			return err
+		}
+	}
+
+	// t.RoundPeriod (abi.ChainEpoch) (int64)
+	if t.RoundPeriod >= 0 {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.RoundPeriod)); err != nil {
+			return err
+		}
+	} else {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajNegativeInt, uint64(-t.RoundPeriod-1)); err != nil {
+			return err
+		}
+	}
+
+	// t.PrincipalLockDuration (abi.ChainEpoch) (int64)
+	if t.PrincipalLockDuration >= 0 {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.PrincipalLockDuration)); err != nil {
+			return err
+		}
+	} else {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajNegativeInt, uint64(-t.PrincipalLockDuration-1)); err != nil {
+			return err
+		}
+	}
+
+	// t.MinDepositAmount (big.Int) (struct)
+	if err := t.MinDepositAmount.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.MaxRewardPerRound (big.Int) (struct)
+	if err := t.MaxRewardPerRound.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.InflationFactor (big.Int) (struct)
+	if err := t.InflationFactor.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.UnbondingPeriod (abi.ChainEpoch) (int64)
+	if t.UnbondingPeriod >= 0 {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.UnbondingPeriod)); err != nil {
+			return err
+		}
+	} else {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajNegativeInt, uint64(-t.UnbondingPeriod-1)); err != nil {
+			return err
+		}
+	}
+
+	// t.ProposalDelay (abi.ChainEpoch) (int64)
+	if t.ProposalDelay >= 0 {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.ProposalDelay)); err != nil {
+			return err
+		}
+	} else {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajNegativeInt, uint64(-t.ProposalDelay-1)); err != nil {
+			return err
+		}
+	}
+
+	// t.RewardCurve (stake.RewardCurveParams) (struct)
+	if err := t.RewardCurve.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.RewardVestingSpec (miner.VestSpec) (struct)
+	if err := t.RewardVestingSpec.MarshalCBOR(w); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (t *GetStakeParamsReturn) UnmarshalCBOR(r io.Reader) error {
+	*t = GetStakeParamsReturn{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 10 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.MaturePeriod (abi.ChainEpoch) (int64)
+	{
+		maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+		var extraI int64
+		if err != nil {
+			return err
+		}
+		switch maj {
+		case cbg.MajUnsignedInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 positive overflow")
+			}
+		case cbg.MajNegativeInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 negative oveflow")
+			}
+			extraI = -1 - extraI
+		default:
+			return fmt.Errorf("wrong type for int64 field: %d", maj)
+		}
+
+		t.MaturePeriod = abi.ChainEpoch(extraI)
+	}
+	// t.RoundPeriod (abi.ChainEpoch) (int64)
+	{
+		maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+		var extraI int64
+		if err != nil {
+			return err
+		}
+		switch maj {
+		case cbg.MajUnsignedInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 positive overflow")
+			}
+		case cbg.MajNegativeInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 negative oveflow")
+			}
+			extraI = -1 - extraI
+		default:
+			return fmt.Errorf("wrong type for int64 field: %d", maj)
+		}
+
+		t.RoundPeriod = abi.ChainEpoch(extraI)
+	}
+	// t.PrincipalLockDuration (abi.ChainEpoch) (int64)
+	{
+		maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+		var extraI int64
+		if err != nil {
+			return err
+		}
+		switch maj {
+		case cbg.MajUnsignedInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 positive overflow")
+			}
+		case cbg.MajNegativeInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 negative oveflow")
+			}
+			extraI = -1 - extraI
+		default:
+			return fmt.Errorf("wrong type for int64 field: %d", maj)
+		}
+
+		t.PrincipalLockDuration = abi.ChainEpoch(extraI)
+	}
+	// t.MinDepositAmount (big.Int) (struct)
+
+	{
+
+		if err := t.MinDepositAmount.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.MinDepositAmount: %w", err)
+		}
+
+	}
+	// t.MaxRewardPerRound (big.Int) (struct)
+
+	{
+
+		if err := t.MaxRewardPerRound.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.MaxRewardPerRound: %w", err)
+		}
+
+	}
+	// t.InflationFactor (big.Int) (struct)
+
+	{
+
+		if err := t.InflationFactor.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.InflationFactor: %w", err)
+		}
+
+	}
+	// t.UnbondingPeriod (abi.ChainEpoch) (int64)
+	{
+		maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+		var extraI int64
+		if err != nil {
+			return err
+		}
+		switch maj {
+		case cbg.MajUnsignedInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 positive overflow")
+			}
+		case cbg.MajNegativeInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 negative oveflow")
+			}
+			extraI = -1 - extraI
+		default:
+			return fmt.Errorf("wrong type for int64 field: %d", maj)
+		}
+
+		t.UnbondingPeriod = abi.ChainEpoch(extraI)
+	}
+	// t.ProposalDelay (abi.ChainEpoch) (int64)
+	{
+		maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+		var extraI int64
+		if err != nil {
+			return err
+		}
+		switch maj {
+		case cbg.MajUnsignedInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 positive overflow")
+			}
+		case cbg.MajNegativeInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 negative oveflow")
+			}
+			extraI = -1 - extraI
+		default:
+			return fmt.Errorf("wrong type for int64 field: %d", maj)
+		}
+
+		t.ProposalDelay = abi.ChainEpoch(extraI)
+	}
+	// t.RewardCurve (stake.RewardCurveParams) (struct)
+
+	{
+
+		if err := t.RewardCurve.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.RewardCurve: %w", err)
+		}
+
+	}
+	// t.RewardVestingSpec (miner.VestSpec) (struct)
+
+	{
+
+		if err := t.RewardVestingSpec.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.RewardVestingSpec: %w", err)
+		}
+
+	}
+	return nil
+}
+
+var lengthBufGetTotalStakePowerReturn = []byte{129}
+
+func (t *GetTotalStakePowerReturn) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufGetTotalStakePowerReturn); err != nil {
+		return err
+	}
+
+	// t.TotalStakePower (big.Int) (struct)
+	if err := t.TotalStakePower.MarshalCBOR(w); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (t *GetTotalStakePowerReturn) UnmarshalCBOR(r io.Reader) error {
+	*t = GetTotalStakePowerReturn{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 1 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.TotalStakePower (big.Int) (struct)
+
+	{
+
+		if err := t.TotalStakePower.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.TotalStakePower: %w", err)
+		}
+
+	}
+	return nil
+}
+
+var lengthBufChangeUnbondingPeriodParams = []byte{129}
+
+func (t *ChangeUnbondingPeriodParams) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufChangeUnbondingPeriodParams); err != nil {
+		return err
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.UnbondingPeriod (abi.ChainEpoch) (int64)
+	if t.UnbondingPeriod >= 0 {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.UnbondingPeriod)); err != nil {
+			return err
+		}
+	} else {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajNegativeInt, uint64(-t.UnbondingPeriod-1)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *ChangeUnbondingPeriodParams) UnmarshalCBOR(r io.Reader) error {
+	*t = ChangeUnbondingPeriodParams{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 1 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.UnbondingPeriod (abi.ChainEpoch) (int64)
+	{
+		maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+		var extraI int64
+		if err != nil {
+			return err
+		}
+		switch maj {
+		case cbg.MajUnsignedInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 positive overflow")
+			}
+		case cbg.MajNegativeInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 negative oveflow")
+			}
+			extraI = -1 - extraI
+		default:
+			return fmt.Errorf("wrong type for int64 field: %d", maj)
+		}
+
+		t.UnbondingPeriod = abi.ChainEpoch(extraI)
+	}
+	return nil
+}
+
+var lengthBufChangeProposalDelayParams = []byte{129}
+
+func (t *ChangeProposalDelayParams) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufChangeProposalDelayParams); err != nil {
+		return err
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.ProposalDelay (abi.ChainEpoch) (int64)
+	if t.ProposalDelay >= 0 {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.ProposalDelay)); err != nil {
+			return err
+		}
+	} else {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajNegativeInt, uint64(-t.ProposalDelay-1)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *ChangeProposalDelayParams) UnmarshalCBOR(r io.Reader) error {
+	*t = ChangeProposalDelayParams{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 1 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.ProposalDelay (abi.ChainEpoch) (int64)
+	{
+		maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+		var extraI int64
+		if err != nil {
+			return err
+		}
+		switch maj {
+		case cbg.MajUnsignedInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 positive overflow")
+			}
+		case cbg.MajNegativeInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 negative oveflow")
+			}
+			extraI = -1 - extraI
+		default:
+			return fmt.Errorf("wrong type for int64 field: %d", maj)
+		}
+
+		t.ProposalDelay = abi.ChainEpoch(extraI)
+	}
+	return nil
+}
+
+var lengthBufCancelProposalParams = []byte{129}
+
+func (t *CancelProposalParams) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufCancelProposalParams); err != nil {
+		return err
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.ID (stake.ProposalID) (int64)
+	if t.ID >= 0 {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.ID)); err != nil {
+			return err
+		}
+	} else {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajNegativeInt, uint64(-t.ID-1)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *CancelProposalParams) UnmarshalCBOR(r io.Reader) error {
+	*t = CancelProposalParams{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 1 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.ID (stake.ProposalID) (int64)
+	{
+		maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+		var extraI int64
+		if err != nil {
+			return err
+		}
+		switch maj {
+		case cbg.MajUnsignedInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 positive overflow")
+			}
+		case cbg.MajNegativeInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 negative oveflow")
+			}
+			extraI = -1 - extraI
+		default:
+			return fmt.Errorf("wrong type for int64 field: %d", maj)
+		}
+
+		t.ID = ProposalID(extraI)
+	}
+	return nil
+}
+
+var lengthBufGetProposalsReturn = []byte{129}
+
+func (t *GetProposalsReturn) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufGetProposalsReturn); err != nil {
+		return err
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.Proposals ([]stake.PendingProposal) (slice)
+	if len(t.Proposals) > cbg.MaxLength {
+		return xerrors.Errorf("Slice value in field t.Proposals was too long")
+	}
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajArray, uint64(len(t.Proposals))); err != nil {
+		return err
+	}
+	for _, v := range t.Proposals {
+		if err := v.MarshalCBOR(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *GetProposalsReturn) UnmarshalCBOR(r io.Reader) error {
+	*t = GetProposalsReturn{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 1 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.Proposals ([]stake.PendingProposal) (slice)
+
+	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+
+	if extra > cbg.MaxLength {
+		return fmt.Errorf("t.Proposals: array too large (%d)", extra)
+	}
+
+	if maj != cbg.MajArray {
+		return fmt.Errorf("expected cbor array")
+	}
+
+	if extra > 0 {
+		t.Proposals = make([]PendingProposal, extra)
+	}
+
+	for i := 0; i < int(extra); i++ {
+
+		var v PendingProposal
+		if err := v.UnmarshalCBOR(br); err != nil {
+			return err
+		}
+
+		t.Proposals[i] = v
+	}
+
+	return nil
+}
+
+var lengthBufProposeReturn = []byte{130}
+
+func (t *ProposeReturn) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufProposeReturn); err != nil {
+		return err
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.ID (stake.ProposalID) (int64)
+	if t.ID >= 0 {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.ID)); err != nil {
+			return err
+		}
+	} else {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajNegativeInt, uint64(-t.ID-1)); err != nil {
+			return err
+		}
+	}
+
+	// t.ActivationEpoch (abi.ChainEpoch) (int64)
+	if t.ActivationEpoch >= 0 {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.ActivationEpoch)); err != nil {
+			return err
+		}
+	} else {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajNegativeInt, uint64(-t.ActivationEpoch-1)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *ProposeReturn) UnmarshalCBOR(r io.Reader) error {
+	*t = ProposeReturn{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 2 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.ID (stake.ProposalID) (int64)
+	{
+		maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+		var extraI int64
+		if err != nil {
+			return err
+		}
+		switch maj {
+		case cbg.MajUnsignedInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 positive overflow")
+			}
+		case cbg.MajNegativeInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 negative oveflow")
+			}
+			extraI = -1 - extraI
+		default:
+			return fmt.Errorf("wrong type for int64 field: %d", maj)
+		}
+
+		t.ID = ProposalID(extraI)
+	}
+	// t.ActivationEpoch (abi.ChainEpoch) (int64)
+	{
+		maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+		var extraI int64
+		if err != nil {
+			return err
+		}
+		switch maj {
+		case cbg.MajUnsignedInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 positive overflow")
+			}
+		case cbg.MajNegativeInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 negative oveflow")
+			}
+			extraI = -1 - extraI
+		default:
+			return fmt.Errorf("wrong type for int64 field: %d", maj)
+		}
+
+		t.ActivationEpoch = abi.ChainEpoch(extraI)
+	}
+	return nil
+}
+
+var lengthBufChangeRewardVestingSpecParams = []byte{129}
+
+func (t *ChangeRewardVestingSpecParams) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufChangeRewardVestingSpecParams); err != nil {
+		return err
+	}
+
+	// t.Spec (miner.VestSpec) (struct)
+	if err := t.Spec.MarshalCBOR(w); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (t *ChangeRewardVestingSpecParams) UnmarshalCBOR(r io.Reader) error {
+	*t = ChangeRewardVestingSpecParams{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 1 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.Spec (miner.VestSpec) (struct)
+
+	{
+
+		if err := t.Spec.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.Spec: %w", err)
+		}
+
+	}
+	return nil
+}
+
+var lengthBufChangeRewardCurveParams = []byte{129}
+
+func (t *ChangeRewardCurveParams) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufChangeRewardCurveParams); err != nil {
+		return err
+	}
+
+	// t.RewardCurve (stake.RewardCurveParams) (struct)
+	if err := t.RewardCurve.MarshalCBOR(w); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (t *ChangeRewardCurveParams) UnmarshalCBOR(r io.Reader) error {
+	*t = ChangeRewardCurveParams{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 1 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.RewardCurve (stake.RewardCurveParams) (struct)
+
+	{
+
+		if err := t.RewardCurve.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.RewardCurve: %w", err)
+		}
+
+	}
+	return nil
+}
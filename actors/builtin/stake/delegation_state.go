@@ -0,0 +1,111 @@
+package stake
+
+import (
+	addr "github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/specs-actors/v3/actors/builtin"
+	"github.com/filecoin-project/specs-actors/v3/actors/util/adt"
+)
+
+// WithdrawPolicy determines who may withdraw principal deposited on behalf of a staker.
+type WithdrawPolicy uint64
+
+const (
+	// Only the depositor may withdraw the principal, which is returned to the depositor.
+	WithdrawPolicyDepositor WithdrawPolicy = iota
+	// Only the staker may withdraw the principal, which is paid to the staker.
+	WithdrawPolicyStaker
+	// The depositor and the staker must both request the same amount, which is returned to the depositor.
+	WithdrawPolicyJoint
+)
+
+func (p WithdrawPolicy) IsValid() bool {
+	return p <= WithdrawPolicyJoint
+}
+
+// Delegation records that a staker's principal was deposited by another address.
+// A staker with a delegation may only receive principal from its depositor.
+type Delegation struct {
+	// Address that deposited principal on behalf of the staker.
+	Depositor addr.Address
+	// Address credited with the rewards earned by the staker's power.
+	RewardReceiver addr.Address
+	// Who may withdraw the staker's principal.
+	WithdrawPolicy WithdrawPolicy
+	// Principal amount requested by one party under WithdrawPolicyJoint and awaiting the other, or zero.
+	PendingWithdrawal abi.TokenAmount
+	// Whether the pending withdrawal was requested by the depositor rather than the staker.
+	PendingFromDepositor bool
+}
+
+func (st *State) LoadDelegation(delegations *adt.Map, staker addr.Address) (*Delegation, bool, error) {
+	var delegation Delegation
+	found, err := delegations.Get(abi.AddrKey(staker), &delegation)
+	if err != nil {
+		return nil, false, xerrors.Errorf("failed to get delegation for %v: %w", staker, err)
+	}
+	if !found {
+		return nil, false, nil
+	}
+	return &delegation, true, nil
+}
+
+func (st *State) putDelegation(delegations *adt.Map, staker addr.Address, delegation *Delegation) error {
+	if err := delegations.Put(abi.AddrKey(staker), delegation); err != nil {
+		return xerrors.Errorf("failed to put delegation for %v: %w", staker, err)
+	}
+	return nil
+}
+
+// Removes the delegation of a staker once no principal deposited for it remains locked, available or unbonding,
+// so that the staker may stake on its own again. Returns whether a delegation was removed.
+func (st *State) releaseDelegation(store adt.Store, staker addr.Address) (bool, error) {
+	principal, err := st.totalPrincipal(store, staker)
+	if err != nil {
+		return false, err
+	}
+	if !principal.IsZero() {
+		return false, nil
+	}
+	unbondingMap, err := adt.AsMap(store, st.UnbondingMap, builtin.DefaultHamtBitwidth)
+	if err != nil {
+		return false, xerrors.Errorf("failed to load unbondings: %w", err)
+	}
+	unbondings, found, err := st.LoadUnbondings(store, unbondingMap, staker)
+	if err != nil {
+		return false, err
+	}
+	if found && len(unbondings.Data) > 0 {
+		return false, nil
+	}
+	return st.deleteDelegation(store, staker)
+}
+
+func (st *State) deleteDelegation(store adt.Store, staker addr.Address) (bool, error) {
+	delegations, err := adt.AsMap(store, st.Delegations, builtin.DefaultHamtBitwidth)
+	if err != nil {
+		return false, xerrors.Errorf("failed to load delegations: %w", err)
+	}
+	deleted, err := delegations.TryDelete(abi.AddrKey(staker))
+	if err != nil {
+		return false, xerrors.Errorf("failed to delete delegation for %v: %w", staker, err)
+	}
+	if st.Delegations, err = delegations.Root(); err != nil {
+		return false, xerrors.Errorf("failed to flush delegations: %w", err)
+	}
+	return deleted, nil
+}
+
+// Returns the address credited with rewards for a staker's power.
+func (st *State) rewardReceiver(delegations *adt.Map, staker addr.Address) (addr.Address, error) {
+	delegation, found, err := st.LoadDelegation(delegations, staker)
+	if err != nil {
+		return addr.Undef, err
+	}
+	if !found {
+		return staker, nil
+	}
+	return delegation.RewardReceiver, nil
+}
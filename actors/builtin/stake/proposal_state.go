@@ -0,0 +1,75 @@
+package stake
+
+import (
+	"sort"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/specs-actors/v3/actors/builtin"
+	"github.com/filecoin-project/specs-actors/v3/actors/util/adt"
+)
+
+// Number of epochs between a parameter change being proposed and taking effect, until a proposal changes it.
+var DefaultProposalDelay = abi.ChainEpoch(builtin.EpochsInDay) // PARAM_SPEC
+
+// Smallest proposal delay the root key may set, so that a change is always visible before it takes effect.
+var MinProposalDelay = abi.ChainEpoch(builtin.EpochsInHour) // PARAM_SPEC
+
+type ProposalID int64
+
+func (id ProposalID) Key() string {
+	return abi.IntKey(int64(id)).Key()
+}
+
+// Proposal is a parameter change proposed by the root key, applied by cron at its activation epoch.
+type Proposal struct {
+	// The Change* method whose effect is proposed.
+	Method abi.MethodNum
+	// Serialized parameters of the method.
+	Params []byte
+	// First epoch at which the change is applied.
+	ActivationEpoch abi.ChainEpoch
+}
+
+// PendingProposal is a proposal together with the ID by which it may be cancelled.
+type PendingProposal struct {
+	ID       ProposalID
+	Proposal Proposal
+}
+
+// Records a proposal to take effect after the proposal delay, returning its ID.
+func (st *State) addProposal(proposals *adt.Map, method abi.MethodNum, params []byte, currEpoch abi.ChainEpoch) (ProposalID, abi.ChainEpoch, error) {
+	id := st.NextProposalID
+	activationEpoch := currEpoch + st.ProposalDelay
+	if err := proposals.Put(id, &Proposal{
+		Method:          method,
+		Params:          params,
+		ActivationEpoch: activationEpoch,
+	}); err != nil {
+		return 0, 0, xerrors.Errorf("failed to put proposal %d: %w", id, err)
+	}
+	st.NextProposalID++
+	return id, activationEpoch, nil
+}
+
+// Returns the pending proposals in the order they were made.
+func (st *State) loadProposals(proposals *adt.Map) ([]PendingProposal, error) {
+	var pending []PendingProposal
+	var proposal Proposal
+	err := proposals.ForEach(&proposal, func(key string) error {
+		id, err := abi.ParseIntKey(key)
+		if err != nil {
+			return err
+		}
+		pending = append(pending, PendingProposal{ID: ProposalID(id), Proposal: proposal})
+		return nil
+	})
+	if err != nil {
+		return nil, xerrors.Errorf("failed to iterate proposals: %w", err)
+	}
+	sort.Slice(pending, func(i, j int) bool {
+		return pending[i].ID < pending[j].ID
+	})
+	return pending, nil
+}
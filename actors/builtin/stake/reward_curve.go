@@ -0,0 +1,108 @@
+package stake
+
+import (
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/specs-actors/v3/actors/util/math"
+)
+
+// RewardCurve selects the model computing the total reward distributed in each round.
+type RewardCurve uint64
+
+const (
+	// Pays InflationFactor / InflationDenominator of the total stake power each round.
+	RewardCurveLinear RewardCurve = iota
+	// Pays an initial reward that halves every HalfLife epochs after the stake period start.
+	RewardCurveExponentialDecay
+	// Pays InflationFactor on the stake that TargetStakeRatio of the circulating supply would hold,
+	// so each unit of power earns more while total stake is below the target and less above it.
+	RewardCurveTargetRatio
+	// Pays the same budget every round, whatever the total stake power.
+	RewardCurveFixedBudget
+)
+
+// RewardCurveParams holds the selected reward curve and its parameters.
+// Only the parameters of the selected curve are used.
+type RewardCurveParams struct {
+	Curve RewardCurve
+
+	// Reward of a round at the stake period start, for RewardCurveExponentialDecay.
+	InitialRewardPerRound abi.TokenAmount
+	// Number of epochs over which the round reward halves, for RewardCurveExponentialDecay.
+	HalfLife abi.ChainEpoch
+
+	// Share of the circulating supply expected to be staked, over InflationDenominator, for RewardCurveTargetRatio.
+	TargetStakeRatio big.Int
+
+	// Reward of every round, for RewardCurveFixedBudget.
+	BudgetPerRound abi.TokenAmount
+}
+
+// LinearRewardCurve returns the parameters of the linear curve, under which the reward follows InflationFactor alone.
+func LinearRewardCurve() RewardCurveParams {
+	return RewardCurveParams{
+		Curve:                 RewardCurveLinear,
+		InitialRewardPerRound: big.Zero(),
+		HalfLife:              0,
+		TargetStakeRatio:      big.Zero(),
+		BudgetPerRound:        big.Zero(),
+	}
+}
+
+func (p *RewardCurveParams) Validate() error {
+	switch p.Curve {
+	case RewardCurveLinear:
+	case RewardCurveExponentialDecay:
+		if p.InitialRewardPerRound.Nil() || p.InitialRewardPerRound.LessThan(big.Zero()) {
+			return xerrors.Errorf("invalid initial reward per round: %v", p.InitialRewardPerRound)
+		}
+		if p.HalfLife <= 0 {
+			return xerrors.Errorf("invalid half life: %d", p.HalfLife)
+		}
+	case RewardCurveTargetRatio:
+		if p.TargetStakeRatio.Nil() || p.TargetStakeRatio.LessThanEqual(big.Zero()) || p.TargetStakeRatio.GreaterThan(InflationDenominator) {
+			return xerrors.Errorf("invalid target stake ratio: %v", p.TargetStakeRatio)
+		}
+	case RewardCurveFixedBudget:
+		if p.BudgetPerRound.Nil() || p.BudgetPerRound.LessThan(big.Zero()) {
+			return xerrors.Errorf("invalid budget per round: %v", p.BudgetPerRound)
+		}
+	default:
+		return xerrors.Errorf("unknown reward curve %d", p.Curve)
+	}
+	return nil
+}
+
+// Computes the total reward of the round at currEpoch, before it is capped by MaxRewardPerRound.
+func (st *State) roundReward(currEpoch abi.ChainEpoch, circSupply abi.TokenAmount) abi.TokenAmount {
+	curve := &st.RewardCurve
+	switch curve.Curve {
+	case RewardCurveExponentialDecay:
+		return decayedReward(curve.InitialRewardPerRound, curve.HalfLife, currEpoch-st.StakePeriodStart)
+	case RewardCurveTargetRatio:
+		targetStake := big.Div(big.Mul(circSupply, curve.TargetStakeRatio), InflationDenominator)
+		return big.Div(big.Mul(targetStake, st.InflationFactor), InflationDenominator)
+	case RewardCurveFixedBudget:
+		return curve.BudgetPerRound
+	default:
+		return big.Div(big.Mul(st.TotalStakePower, st.InflationFactor), InflationDenominator)
+	}
+}
+
+// Returns initial * e^(-ln(2) * elapsed / halfLife).
+// Whole half lives are applied as shifts, keeping the exponent within the range where ExpNeg is most precise.
+func decayedReward(initial abi.TokenAmount, halfLife, elapsed abi.ChainEpoch) abi.TokenAmount {
+	if elapsed <= 0 {
+		return initial
+	}
+	halvings := uint(elapsed / halfLife)
+	remainder := big.NewInt(int64(elapsed % halfLife))
+
+	two := big.Lsh(big.NewInt(2), math.Precision128)                            // Q.0 => Q.128
+	x := big.Div(big.Mul(math.Ln(two), remainder), big.NewInt(int64(halfLife))) // Q.128 * Q.0 / Q.0 => Q.128
+
+	reward := big.Mul(initial, big.NewFromGo(math.ExpNeg(x.Int))) // Q.0 * Q.128 => Q.128
+	return big.Rsh(reward, math.Precision128+halvings)            // Q.128 >> 128 => Q.0, halved per half life
+}
@@ -1,16 +1,21 @@
 package stake
 
 import (
+	"bytes"
+
 	addr "github.com/filecoin-project/go-address"
 	"github.com/filecoin-project/go-state-types/abi"
 	"github.com/filecoin-project/go-state-types/big"
 	"github.com/filecoin-project/go-state-types/cbor"
 	"github.com/filecoin-project/go-state-types/exitcode"
+	rtt "github.com/filecoin-project/go-state-types/rt"
 	stake2 "github.com/filecoin-project/specs-actors/v2/actors/builtin/stake"
 	"github.com/filecoin-project/specs-actors/v3/actors/builtin"
+	"github.com/filecoin-project/specs-actors/v3/actors/builtin/miner"
 	"github.com/filecoin-project/specs-actors/v3/actors/runtime"
 	"github.com/filecoin-project/specs-actors/v3/actors/util/adt"
 	"github.com/ipfs/go-cid"
+	"golang.org/x/xerrors"
 )
 
 type Runtime = runtime.Runtime
@@ -31,6 +36,24 @@ func (a Actor) Exports() []interface{} {
 		10:                        a.ChangeInflationFactor,
 		11:                        a.ChangeRootKey,
 		12:                        a.OnEpochTickEnd,
+		13:                        a.DepositFor,
+		14:                        a.WithdrawDelegatedPrincipal,
+		15:                        a.RestakeReward,
+		16:                        a.SetAutoCompound,
+		17:                        a.AddSlasher,
+		18:                        a.RemoveSlasher,
+		19:                        a.Slash,
+		20:                        a.GetStakerInfo,
+		21:                        a.GetStakeParams,
+		22:                        a.GetTotalStakePower,
+		23:                        a.ClaimUnbonded,
+		24:                        a.ChangeUnbondingPeriod,
+		25:                        a.ChangeProposalDelay,
+		26:                        a.CancelProposal,
+		27:                        a.GetProposals,
+		28:                        a.ChangeRewardVestingSpec,
+		29:                        a.GetStakePower,
+		30:                        a.ChangeRewardCurve,
 	}
 }
 
@@ -50,9 +73,11 @@ var _ runtime.VMActor = Actor{}
 
 type ConstructorParams = stake2.ConstructorParams
 
+// Constructs the actor with the linear reward curve. Genesis may select another curve through ConstructState,
+// and the root key may propose one later through ChangeRewardCurve.
 func (a Actor) Constructor(rt Runtime, params *ConstructorParams) *abi.EmptyValue {
 	rt.ValidateImmediateCallerIs(builtin.SystemActorAddr)
-	st, err := ConstructState(adt.AsStore(rt), params)
+	st, err := ConstructState(adt.AsStore(rt), params, nil)
 	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to construct state")
 	rt.StateCreate(st)
 	return nil
@@ -66,39 +91,91 @@ func (a Actor) Deposit(rt Runtime, _ *abi.EmptyValue) *abi.EmptyValue {
 
 	depositAmount := rt.ValueReceived()
 	staker := rt.Caller()
-	currEpoch := rt.CurrEpoch()
 
-	store := adt.AsStore(rt)
 	var st State
 	rt.StateReadonly(&st)
 	builtin.RequireParam(rt, depositAmount.GreaterThanEqual(st.MinDepositAmount), "amount to deposit must be greater than or equal to %s", st.MinDepositAmount)
 
 	rt.StateTransaction(&st, func() {
-		lockedPrincipalMap, err := adt.AsMap(store, st.LockedPrincipalMap, builtin.DefaultHamtBitwidth)
-		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load locked principalsMap")
-		lockedPrincipals, found, err := st.LoadLockedPrincipals(store, lockedPrincipalMap, staker)
-		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load locked principals for %v", staker)
-		if !found {
-			lockedPrincipals = ConstructLockedPrincipals()
-		}
-		newlyUnlocked := lockedPrincipals.unlockLockedPrincipals(st.PrincipalLockDuration, currEpoch)
+		requireNotDelegated(rt, &st, staker)
+		depositPrincipal(rt, &st, staker, depositAmount)
+		rt.ChargeGas("OnStakeDeposit", GasOnStakeDeposit, 0)
+	})
+	return nil
+}
 
-		availablePrincipalMap, err := adt.AsMap(store, st.AvailablePrincipalMap, builtin.DefaultHamtBitwidth)
-		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load available principals")
-		_, err = st.updateAvailablePrincipal(availablePrincipalMap, staker, newlyUnlocked)
-		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to update available principals")
-		ap, err := availablePrincipalMap.Root()
-		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to flush available principals")
-		st.AvailablePrincipalMap = ap
+type DepositForParams struct {
+	Beneficiary    addr.Address
+	RewardReceiver addr.Address
+	WithdrawPolicy WithdrawPolicy
+}
 
-		lockedPrincipals.addLockedPrincipal(depositAmount, currEpoch)
-		err = st.putLockedPrincipals(store, lockedPrincipalMap, staker, lockedPrincipals)
-		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to put locked principals")
-		lpm, err := lockedPrincipalMap.Root()
-		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to flush locked principalMap")
+// Deposits the value received as principal of the beneficiary, recording the caller as its depositor.
+// Rewards for the beneficiary's power are credited to RewardReceiver, and the principal may be withdrawn
+// through WithdrawDelegatedPrincipal as WithdrawPolicy allows. Later deposits for the same beneficiary
+// must come from the same depositor with the same terms. A beneficiary with auto compound enabled is rejected.
+func (a Actor) DepositFor(rt Runtime, params *DepositForParams) *abi.EmptyValue {
+	rt.ValidateImmediateCallerAcceptAny()
+
+	depositAmount := rt.ValueReceived()
+	depositor := rt.Caller()
+
+	staker, ok := rt.ResolveAddress(params.Beneficiary)
+	if !ok {
+		rt.Abortf(exitcode.ErrIllegalArgument, "unable to resolve address %v", params.Beneficiary)
+	}
+	rewardReceiver, ok := rt.ResolveAddress(params.RewardReceiver)
+	if !ok {
+		rt.Abortf(exitcode.ErrIllegalArgument, "unable to resolve address %v", params.RewardReceiver)
+	}
+	builtin.RequireParam(rt, staker != depositor, "depositor %v cannot deposit for itself, use Deposit", depositor)
+	builtin.RequireParam(rt, params.WithdrawPolicy.IsValid(), "invalid withdraw policy %d", params.WithdrawPolicy)
 
+	store := adt.AsStore(rt)
+	var st State
+	rt.StateReadonly(&st)
+	builtin.RequireParam(rt, depositAmount.GreaterThanEqual(st.MinDepositAmount), "amount to deposit must be greater than or equal to %s", st.MinDepositAmount)
+
+	rt.StateTransaction(&st, func() {
+		delegations, err := adt.AsMap(store, st.Delegations, builtin.DefaultHamtBitwidth)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load delegations")
+		delegation, found, err := st.LoadDelegation(delegations, staker)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load delegation for %v", staker)
+		if found {
+			if delegation.Depositor != depositor {
+				rt.Abortf(exitcode.ErrForbidden, "principal of %v is deposited by %v, not %v", staker, delegation.Depositor, depositor)
+			}
+			builtin.RequireParam(rt, delegation.RewardReceiver == rewardReceiver && delegation.WithdrawPolicy == params.WithdrawPolicy,
+				"delegation for %v already exists with reward receiver %v and withdraw policy %d", staker, delegation.RewardReceiver, delegation.WithdrawPolicy)
+		} else {
+			principal, err := st.totalPrincipal(store, staker)
+			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load principal of %v", staker)
+			if !principal.IsZero() {
+				rt.Abortf(exitcode.ErrForbidden, "staker %v already holds principal %v of its own", staker, principal)
+			}
+			// Compounded rewards would become principal the depositor could withdraw.
+			autoCompound, err := adt.AsSet(store, st.AutoCompound, builtin.DefaultHamtBitwidth)
+			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load auto compound stakers")
+			compound, err := autoCompound.Has(abi.AddrKey(staker))
+			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to check auto compound for %v", staker)
+			if compound {
+				rt.Abortf(exitcode.ErrForbidden, "staker %v auto compounds its rewards and cannot take delegated principal", staker)
+			}
+
+			err = st.putDelegation(delegations, staker, &Delegation{
+				Depositor:            depositor,
+				RewardReceiver:       rewardReceiver,
+				WithdrawPolicy:       params.WithdrawPolicy,
+				PendingWithdrawal:    big.Zero(),
+				PendingFromDepositor: false,
+			})
+			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to put delegation")
+			st.Delegations, err = delegations.Root()
+			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to flush delegations")
+		}
+
+		depositPrincipal(rt, &st, staker, depositAmount)
 		rt.ChargeGas("OnStakeDeposit", GasOnStakeDeposit, 0)
-		st.LockedPrincipalMap = lpm
 	})
 	return nil
 }
@@ -115,25 +192,141 @@ func (a Actor) WithdrawPrincipal(rt Runtime, params *WithdrawParams) *abi.EmptyV
 	store := adt.AsStore(rt)
 	var st State
 	rt.StateTransaction(&st, func() {
-		availablePrincipalMap, err := adt.AsMap(store, st.AvailablePrincipalMap, builtin.DefaultHamtBitwidth)
-		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load available principals")
-		_, err = st.updateAvailablePrincipal(availablePrincipalMap, stakerAddr, params.AmountRequested.Neg())
-		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to update available principals")
-		ap, err := availablePrincipalMap.Root()
-		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to flush available principals")
-		st.AvailablePrincipalMap = ap
+		delegations, err := adt.AsMap(store, st.Delegations, builtin.DefaultHamtBitwidth)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load delegations")
+		delegation, found, err := st.LoadDelegation(delegations, stakerAddr)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load delegation for %v", stakerAddr)
+		if found {
+			rt.Abortf(exitcode.ErrForbidden, "principal of %v is deposited by %v and may only be withdrawn through WithdrawDelegatedPrincipal", stakerAddr, delegation.Depositor)
+		}
 
-		stakePowerMap, err := adt.AsMap(store, st.StakePowerMap, builtin.DefaultHamtBitwidth)
-		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load stake powers")
-		err = st.updateStakePower(stakePowerMap, stakerAddr, params.AmountRequested.Neg())
-		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to update stake power")
-		sp, err := stakePowerMap.Root()
-		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to flush stake power")
-		st.StakePowerMap = sp
+		withdrawAvailablePrincipal(rt, &st, stakerAddr, params.AmountRequested)
+		err = st.addUnbonding(store, stakerAddr, params.AmountRequested, stakerAddr, rt.CurrEpoch())
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to add unbonding for %v", stakerAddr)
 	})
+	return nil
+}
 
-	code := rt.Send(stakerAddr, builtin.MethodSend, nil, params.AmountRequested, &builtin.Discard{})
-	builtin.RequireSuccess(rt, code, "failed to withdraw principal")
+type WithdrawDelegatedPrincipalParams struct {
+	Staker          addr.Address
+	AmountRequested abi.TokenAmount
+}
+
+// Withdraws available principal deposited on behalf of a staker, as allowed by its delegation's withdraw policy.
+// Under WithdrawPolicyJoint the first party's request is recorded and the withdrawal happens once the other
+// party requests the same amount.
+func (a Actor) WithdrawDelegatedPrincipal(rt Runtime, params *WithdrawDelegatedPrincipalParams) *abi.EmptyValue {
+	rt.ValidateImmediateCallerAcceptAny()
+	if params.AmountRequested.LessThanEqual(abi.NewTokenAmount(0)) {
+		rt.Abortf(exitcode.ErrIllegalArgument, "negative or zero fund requested for withdrawal: %s", params.AmountRequested)
+	}
+	staker, ok := rt.ResolveAddress(params.Staker)
+	if !ok {
+		rt.Abortf(exitcode.ErrIllegalArgument, "unable to resolve address %v", params.Staker)
+	}
+	caller := rt.Caller()
+
+	var recipient addr.Address
+	execute := false
+	store := adt.AsStore(rt)
+	var st State
+	rt.StateTransaction(&st, func() {
+		delegations, err := adt.AsMap(store, st.Delegations, builtin.DefaultHamtBitwidth)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load delegations")
+		delegation, found, err := st.LoadDelegation(delegations, staker)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load delegation for %v", staker)
+		if !found {
+			rt.Abortf(exitcode.ErrNotFound, "no delegated principal for %v", staker)
+		}
+
+		switch delegation.WithdrawPolicy {
+		case WithdrawPolicyDepositor:
+			if caller != delegation.Depositor {
+				rt.Abortf(exitcode.ErrForbidden, "only depositor %v may withdraw principal of %v", delegation.Depositor, staker)
+			}
+			execute, recipient = true, delegation.Depositor
+		case WithdrawPolicyStaker:
+			if caller != staker {
+				rt.Abortf(exitcode.ErrForbidden, "only staker %v may withdraw its principal", staker)
+			}
+			execute, recipient = true, staker
+		case WithdrawPolicyJoint:
+			if caller != delegation.Depositor && caller != staker {
+				rt.Abortf(exitcode.ErrForbidden, "only depositor %v or staker %v may withdraw principal of %v", delegation.Depositor, staker, staker)
+			}
+			fromDepositor := caller == delegation.Depositor
+			if delegation.PendingWithdrawal.Equals(params.AmountRequested) && delegation.PendingFromDepositor != fromDepositor {
+				execute, recipient = true, delegation.Depositor
+				delegation.PendingWithdrawal = big.Zero()
+				delegation.PendingFromDepositor = false
+			} else {
+				delegation.PendingWithdrawal = params.AmountRequested
+				delegation.PendingFromDepositor = fromDepositor
+			}
+			err = st.putDelegation(delegations, staker, delegation)
+			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to put delegation")
+			st.Delegations, err = delegations.Root()
+			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to flush delegations")
+		default:
+			rt.Abortf(exitcode.ErrIllegalState, "invalid withdraw policy %d for %v", delegation.WithdrawPolicy, staker)
+		}
+
+		if execute {
+			withdrawAvailablePrincipal(rt, &st, staker, params.AmountRequested)
+			err = st.addUnbonding(store, staker, params.AmountRequested, recipient, rt.CurrEpoch())
+			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to add unbonding for %v", staker)
+		}
+	})
+	return nil
+}
+
+// Pays out a staker's withdrawn principal whose unbonding period has passed, each amount to the recipient
+// recorded when it was withdrawn.
+func (a Actor) ClaimUnbonded(rt Runtime, staker *addr.Address) *abi.EmptyValue {
+	rt.ValidateImmediateCallerAcceptAny()
+	resolved, ok := rt.ResolveAddress(*staker)
+	if !ok {
+		rt.Abortf(exitcode.ErrIllegalArgument, "unable to resolve address %v", *staker)
+	}
+
+	var recipients []addr.Address
+	payouts := make(map[addr.Address]abi.TokenAmount)
+	store := adt.AsStore(rt)
+	var st State
+	rt.StateTransaction(&st, func() {
+		unbondingMap, err := adt.AsMap(store, st.UnbondingMap, builtin.DefaultHamtBitwidth)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load unbondings")
+		unbondings, found, err := st.LoadUnbondings(store, unbondingMap, resolved)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load unbondings for %v", resolved)
+		if !found {
+			rt.Abortf(exitcode.ErrIllegalArgument, "no unbonding principal for %v", resolved)
+		}
+
+		released := unbondings.releaseUnbonded(rt.CurrEpoch())
+		if len(released) == 0 {
+			rt.Abortf(exitcode.ErrIllegalArgument, "no unbonding principal of %v is released at epoch %d", resolved, rt.CurrEpoch())
+		}
+		for _, ub := range released {
+			if _, ok := payouts[ub.Recipient]; !ok {
+				recipients = append(recipients, ub.Recipient)
+				payouts[ub.Recipient] = big.Zero()
+			}
+			payouts[ub.Recipient] = big.Add(payouts[ub.Recipient], ub.Amount)
+		}
+
+		err = st.putUnbondings(store, unbondingMap, resolved, unbondings)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to put unbondings for %v", resolved)
+		st.UnbondingMap, err = unbondingMap.Root()
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to flush unbondings")
+
+		_, err = st.releaseDelegation(store, resolved)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to release delegation for %v", resolved)
+	})
+
+	for _, recipient := range recipients {
+		code := rt.Send(recipient, builtin.MethodSend, nil, payouts[recipient], &builtin.Discard{})
+		builtin.RequireSuccess(rt, code, "failed to pay unbonded principal to %v", recipient)
+	}
 	return nil
 }
 
@@ -164,9 +357,70 @@ func (a Actor) WithdrawReward(rt Runtime, params *WithdrawParams) *abi.EmptyValu
 	return nil
 }
 
+type RestakeRewardParams struct {
+	Amount abi.TokenAmount
+}
+
+// Moves available reward of the caller into a new locked principal entry, as a Deposit of the same amount would,
+// without the reward leaving the actor.
+func (a Actor) RestakeReward(rt Runtime, params *RestakeRewardParams) *abi.EmptyValue {
+	rt.ValidateImmediateCallerAcceptAny()
+	staker := rt.Caller()
+
+	store := adt.AsStore(rt)
+	var st State
+	rt.StateReadonly(&st)
+	builtin.RequireParam(rt, params.Amount.GreaterThanEqual(st.MinDepositAmount), "amount to restake must be greater than or equal to %s", st.MinDepositAmount)
+
+	rt.StateTransaction(&st, func() {
+		requireNotDelegated(rt, &st, staker)
+
+		availableRewardMap, err := adt.AsMap(store, st.AvailableRewardMap, builtin.DefaultHamtBitwidth)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load available rewards")
+		err = st.updateAvailableReward(availableRewardMap, staker, params.Amount.Neg())
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to update available rewards")
+		st.AvailableRewardMap, err = availableRewardMap.Root()
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to flush available rewards")
+
+		depositPrincipal(rt, &st, staker, params.Amount)
+	})
+	return nil
+}
+
+type SetAutoCompoundParams struct {
+	Enabled bool
+}
+
+// Sets whether the caller's rewards are restaked as locked principal when they vest,
+// instead of becoming available for withdrawal.
+func (a Actor) SetAutoCompound(rt Runtime, params *SetAutoCompoundParams) *abi.EmptyValue {
+	rt.ValidateImmediateCallerAcceptAny()
+	staker := rt.Caller()
+
+	store := adt.AsStore(rt)
+	var st State
+	rt.StateTransaction(&st, func() {
+		if params.Enabled {
+			requireNotDelegated(rt, &st, staker)
+		}
+
+		autoCompound, err := adt.AsSet(store, st.AutoCompound, builtin.DefaultHamtBitwidth)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load auto compound stakers")
+		if params.Enabled {
+			err = autoCompound.Put(abi.AddrKey(staker))
+		} else {
+			_, err = autoCompound.TryDelete(abi.AddrKey(staker))
+		}
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to update auto compound for %v", staker)
+		st.AutoCompound, err = autoCompound.Root()
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to flush auto compound stakers")
+	})
+	return nil
+}
+
 type ChangeMaturePeriodParams = stake2.ChangeMaturePeriodParams
 
-func (a Actor) ChangeMaturePeriod(rt Runtime, params *ChangeMaturePeriodParams) *abi.EmptyValue {
+func (a Actor) ChangeMaturePeriod(rt Runtime, params *ChangeMaturePeriodParams) *ProposeReturn {
 	if params.MaturePeriod <= 0 {
 		rt.Abortf(exitcode.ErrIllegalArgument, "invalid mature period: %d", params.MaturePeriod)
 	}
@@ -179,15 +433,12 @@ func (a Actor) ChangeMaturePeriod(rt Runtime, params *ChangeMaturePeriodParams)
 		rt.Abortf(exitcode.ErrIllegalArgument, "mature period %d cannot be greater than principal lock duration %d", params.MaturePeriod, st.PrincipalLockDuration)
 	}
 
-	rt.StateTransaction(&st, func() {
-		st.MaturePeriod = params.MaturePeriod
-	})
-	return nil
+	return propose(rt, builtin.MethodsStake.ChangeMaturePeriod, params)
 }
 
 type ChangeRoundPeriodParams = stake2.ChangeRoundPeriodParams
 
-func (a Actor) ChangeRoundPeriod(rt Runtime, params *ChangeRoundPeriodParams) *abi.EmptyValue {
+func (a Actor) ChangeRoundPeriod(rt Runtime, params *ChangeRoundPeriodParams) *ProposeReturn {
 	if params.RoundPeriod <= 0 {
 		rt.Abortf(exitcode.ErrIllegalArgument, "invalid round period: %d", params.RoundPeriod)
 	}
@@ -196,15 +447,12 @@ func (a Actor) ChangeRoundPeriod(rt Runtime, params *ChangeRoundPeriodParams) *a
 	rt.StateReadonly(&st)
 	rt.ValidateImmediateCallerIs(st.RootKey)
 
-	rt.StateTransaction(&st, func() {
-		st.RoundPeriod = params.RoundPeriod
-	})
-	return nil
+	return propose(rt, builtin.MethodsStake.ChangeRoundPeriod, params)
 }
 
 type ChangePrincipalLockDurationParams = stake2.ChangePrincipalLockDurationParams
 
-func (a Actor) ChangePrincipalLockDuration(rt Runtime, params *ChangePrincipalLockDurationParams) *abi.EmptyValue {
+func (a Actor) ChangePrincipalLockDuration(rt Runtime, params *ChangePrincipalLockDurationParams) *ProposeReturn {
 	if params.PrincipalLockDuration <= 0 {
 		rt.Abortf(exitcode.ErrIllegalArgument, "invalid principal lock duration: %d", params.PrincipalLockDuration)
 	}
@@ -217,15 +465,29 @@ func (a Actor) ChangePrincipalLockDuration(rt Runtime, params *ChangePrincipalLo
 		rt.Abortf(exitcode.ErrIllegalArgument, "principal lock duration %d cannot be less than mature period %d", params.PrincipalLockDuration, st.MaturePeriod)
 	}
 
-	rt.StateTransaction(&st, func() {
-		st.PrincipalLockDuration = params.PrincipalLockDuration
-	})
-	return nil
+	return propose(rt, builtin.MethodsStake.ChangePrincipalLockDuration, params)
+}
+
+type ChangeUnbondingPeriodParams struct {
+	UnbondingPeriod abi.ChainEpoch
+}
+
+// Proposes a new unbonding period for later withdrawals. Principal already unbonding keeps its release epoch.
+func (a Actor) ChangeUnbondingPeriod(rt Runtime, params *ChangeUnbondingPeriodParams) *ProposeReturn {
+	if params.UnbondingPeriod < 0 {
+		rt.Abortf(exitcode.ErrIllegalArgument, "invalid unbonding period: %d", params.UnbondingPeriod)
+	}
+
+	var st State
+	rt.StateReadonly(&st)
+	rt.ValidateImmediateCallerIs(st.RootKey)
+
+	return propose(rt, builtin.MethodsStake.ChangeUnbondingPeriod, params)
 }
 
 type ChangeMinDepositAmountParams = stake2.ChangeMinDepositAmountParams
 
-func (a Actor) ChangeMinDepositAmount(rt Runtime, params *ChangeMinDepositAmountParams) *abi.EmptyValue {
+func (a Actor) ChangeMinDepositAmount(rt Runtime, params *ChangeMinDepositAmountParams) *ProposeReturn {
 	if params.MinDepositAmount.LessThanEqual(abi.NewTokenAmount(0)) {
 		rt.Abortf(exitcode.ErrIllegalArgument, "invalid min deposit amount: %s", params.MinDepositAmount)
 	}
@@ -234,15 +496,12 @@ func (a Actor) ChangeMinDepositAmount(rt Runtime, params *ChangeMinDepositAmount
 	rt.StateReadonly(&st)
 	rt.ValidateImmediateCallerIs(st.RootKey)
 
-	rt.StateTransaction(&st, func() {
-		st.MinDepositAmount = params.MinDepositAmount
-	})
-	return nil
+	return propose(rt, builtin.MethodsStake.ChangeMinDepositAmount, params)
 }
 
 type ChangeMaxRewardsPerRoundParams = stake2.ChangeMaxRewardsPerRoundParams
 
-func (a Actor) ChangeMaxRewardsPerRound(rt Runtime, params *ChangeMaxRewardsPerRoundParams) *abi.EmptyValue {
+func (a Actor) ChangeMaxRewardsPerRound(rt Runtime, params *ChangeMaxRewardsPerRoundParams) *ProposeReturn {
 	if params.MaxRewardPerRound.LessThan(abi.NewTokenAmount(0)) {
 		rt.Abortf(exitcode.ErrIllegalArgument, "invalid max reward per round: %s", params.MaxRewardPerRound)
 	}
@@ -251,15 +510,12 @@ func (a Actor) ChangeMaxRewardsPerRound(rt Runtime, params *ChangeMaxRewardsPerR
 	rt.StateReadonly(&st)
 	rt.ValidateImmediateCallerIs(st.RootKey)
 
-	rt.StateTransaction(&st, func() {
-		st.MaxRewardPerRound = params.MaxRewardPerRound
-	})
-	return nil
+	return propose(rt, builtin.MethodsStake.ChangeMaxRewardsPerRound, params)
 }
 
 type ChangeInflationFactorParams = stake2.ChangeInflationFactorParams
 
-func (a Actor) ChangeInflationFactor(rt Runtime, params *ChangeInflationFactorParams) *abi.EmptyValue {
+func (a Actor) ChangeInflationFactor(rt Runtime, params *ChangeInflationFactorParams) *ProposeReturn {
 	if params.InflationFactor.LessThan(big.Zero()) {
 		rt.Abortf(exitcode.ErrIllegalArgument, "invalid inflation factor: %v", params.InflationFactor)
 	}
@@ -268,23 +524,298 @@ func (a Actor) ChangeInflationFactor(rt Runtime, params *ChangeInflationFactorPa
 	rt.StateReadonly(&st)
 	rt.ValidateImmediateCallerIs(st.RootKey)
 
+	return propose(rt, builtin.MethodsStake.ChangeInflationFactor, params)
+}
+
+func (a Actor) ChangeRootKey(rt Runtime, newRootKey *addr.Address) *ProposeReturn {
+	var st State
+	rt.StateReadonly(&st)
+	rt.ValidateImmediateCallerIs(st.RootKey)
+
+	return propose(rt, builtin.MethodsStake.ChangeRootKey, newRootKey)
+}
+
+type ChangeProposalDelayParams struct {
+	ProposalDelay abi.ChainEpoch
+}
+
+// Proposes a new delay for later proposals. Pending proposals keep their activation epoch.
+func (a Actor) ChangeProposalDelay(rt Runtime, params *ChangeProposalDelayParams) *ProposeReturn {
+	if params.ProposalDelay < MinProposalDelay {
+		rt.Abortf(exitcode.ErrIllegalArgument, "invalid proposal delay %d, must be at least %d", params.ProposalDelay, MinProposalDelay)
+	}
+
+	var st State
+	rt.StateReadonly(&st)
+	rt.ValidateImmediateCallerIs(st.RootKey)
+
+	return propose(rt, builtin.MethodsStake.ChangeProposalDelay, params)
+}
+
+type ChangeRewardVestingSpecParams struct {
+	Spec miner.VestSpec
+}
+
+// Proposes a new vesting spec for later rewards. Rewards already vesting keep their schedule.
+func (a Actor) ChangeRewardVestingSpec(rt Runtime, params *ChangeRewardVestingSpecParams) *ProposeReturn {
+	if err := ValidateVestSpec(&params.Spec); err != nil {
+		rt.Abortf(exitcode.ErrIllegalArgument, "invalid reward vesting spec: %v", err)
+	}
+
+	var st State
+	rt.StateReadonly(&st)
+	rt.ValidateImmediateCallerIs(st.RootKey)
+
+	return propose(rt, builtin.MethodsStake.ChangeRewardVestingSpec, params)
+}
+
+type ChangeRewardCurveParams struct {
+	RewardCurve RewardCurveParams
+}
+
+// Proposes a new curve for the rewards of later rounds.
+func (a Actor) ChangeRewardCurve(rt Runtime, params *ChangeRewardCurveParams) *ProposeReturn {
+	var st State
+	rt.StateReadonly(&st)
+	rt.ValidateImmediateCallerIs(st.RootKey)
+
+	if err := params.RewardCurve.Validate(); err != nil {
+		rt.Abortf(exitcode.ErrIllegalArgument, "invalid reward curve: %v", err)
+	}
+
+	return propose(rt, builtin.MethodsStake.ChangeRewardCurve, params)
+}
+
+type CancelProposalParams struct {
+	ID ProposalID
+}
+
+// Removes a proposal before it takes effect.
+func (a Actor) CancelProposal(rt Runtime, params *CancelProposalParams) *abi.EmptyValue {
+	var st State
+	rt.StateReadonly(&st)
+	rt.ValidateImmediateCallerIs(st.RootKey)
+
 	rt.StateTransaction(&st, func() {
-		st.InflationFactor = params.InflationFactor
+		proposals, err := adt.AsMap(adt.AsStore(rt), st.Proposals, builtin.DefaultHamtBitwidth)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load proposals")
+		found, err := proposals.TryDelete(params.ID)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to delete proposal %d", params.ID)
+		if !found {
+			rt.Abortf(exitcode.ErrNotFound, "no such proposal %d", params.ID)
+		}
+		st.Proposals, err = proposals.Root()
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to flush proposals")
 	})
 	return nil
 }
 
-func (a Actor) ChangeRootKey(rt Runtime, newRootKey *addr.Address) *abi.EmptyValue {
+type GetProposalsReturn struct {
+	Proposals []PendingProposal
+}
+
+// Returns the parameter changes waiting to take effect, in the order they were proposed.
+func (a Actor) GetProposals(rt Runtime, _ *abi.EmptyValue) *GetProposalsReturn {
+	rt.ValidateImmediateCallerAcceptAny()
+	var st State
+	rt.StateReadonly(&st)
+
+	proposals, err := adt.AsMap(adt.AsStore(rt), st.Proposals, builtin.DefaultHamtBitwidth)
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load proposals")
+	pending, err := st.loadProposals(proposals)
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load proposals")
+	if pending == nil {
+		pending = []PendingProposal{}
+	}
+	return &GetProposalsReturn{Proposals: pending}
+}
+
+// Proposes to permit an actor to slash stakers.
+func (a Actor) AddSlasher(rt Runtime, slasher *addr.Address) *ProposeReturn {
+	var st State
+	rt.StateReadonly(&st)
+	rt.ValidateImmediateCallerIs(st.RootKey)
+
+	resolved, ok := rt.ResolveAddress(*slasher)
+	if !ok {
+		rt.Abortf(exitcode.ErrIllegalArgument, "unable to resolve address %v", *slasher)
+	}
+
+	return propose(rt, builtin.MethodsStake.AddSlasher, &resolved)
+}
+
+// Proposes to stop an actor from slashing stakers.
+func (a Actor) RemoveSlasher(rt Runtime, slasher *addr.Address) *ProposeReturn {
 	var st State
 	rt.StateReadonly(&st)
 	rt.ValidateImmediateCallerIs(st.RootKey)
 
+	resolved, ok := rt.ResolveAddress(*slasher)
+	if !ok {
+		rt.Abortf(exitcode.ErrIllegalArgument, "unable to resolve address %v", *slasher)
+	}
+	slashers, err := adt.AsSet(adt.AsStore(rt), st.Slashers, builtin.DefaultHamtBitwidth)
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load slashers")
+	isSlasher, err := slashers.Has(abi.AddrKey(resolved))
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to check slasher %v", resolved)
+	if !isSlasher {
+		rt.Abortf(exitcode.ErrNotFound, "%v is not a slasher", resolved)
+	}
+
+	return propose(rt, builtin.MethodsStake.RemoveSlasher, &resolved)
+}
+
+type SlashParams struct {
+	Staker addr.Address
+	Amount abi.TokenAmount
+	Reason string
+}
+
+// Takes up to Amount from a staker and burns it, recomputing the staker's power right away.
+// Locked principal is taken first, then available principal, then unbonding principal, then vesting rewards.
+// Only slashers added by proposal of the root key may slash.
+func (a Actor) Slash(rt Runtime, params *SlashParams) *abi.EmptyValue {
+	rt.ValidateImmediateCallerAcceptAny()
+	if params.Amount.LessThanEqual(big.Zero()) {
+		rt.Abortf(exitcode.ErrIllegalArgument, "negative or zero amount to slash: %s", params.Amount)
+	}
+	staker, ok := rt.ResolveAddress(params.Staker)
+	if !ok {
+		rt.Abortf(exitcode.ErrIllegalArgument, "unable to resolve address %v", params.Staker)
+	}
+
+	store := adt.AsStore(rt)
+	var st State
+	var slashed abi.TokenAmount
 	rt.StateTransaction(&st, func() {
-		st.RootKey = *newRootKey
+		slashers, err := adt.AsSet(store, st.Slashers, builtin.DefaultHamtBitwidth)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load slashers")
+		permitted, err := slashers.Has(abi.AddrKey(rt.Caller()))
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to check slasher %v", rt.Caller())
+		if !permitted {
+			rt.Abortf(exitcode.ErrForbidden, "caller %v is not a slasher", rt.Caller())
+		}
+
+		slashed, err = st.slash(store, staker, params.Amount, rt.CurrEpoch())
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to slash %v", staker)
+		_, err = st.releaseDelegation(store, staker)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to release delegation for %v", staker)
 	})
+
+	rt.Log(rtt.INFO, "slashed %v of requested %v from staker %v: %s", slashed, params.Amount, staker, params.Reason)
+	if slashed.GreaterThan(big.Zero()) {
+		code := rt.Send(builtin.BurntFundsActorAddr, builtin.MethodSend, nil, slashed, &builtin.Discard{})
+		builtin.RequireSuccess(rt, code, "failed to burn slashed funds")
+	}
 	return nil
 }
 
+type GetStakerInfoReturn struct {
+	LockedPrincipals   []LockedPrincipal
+	AvailablePrincipal abi.TokenAmount
+	StakePower         abi.StakePower
+	VestingFunds       []VestingFund
+	AvailableReward    abi.TokenAmount
+	Unbondings         []Unbonding
+}
+
+// Returns the principal, power, rewards and unbonding principal recorded for a staker.
+// The stake power is as of the last cron tick that processed the staker.
+func (a Actor) GetStakerInfo(rt Runtime, staker *addr.Address) *GetStakerInfoReturn {
+	rt.ValidateImmediateCallerAcceptAny()
+	resolved, ok := rt.ResolveAddress(*staker)
+	if !ok {
+		rt.Abortf(exitcode.ErrIllegalArgument, "unable to resolve address %v", *staker)
+	}
+
+	var st State
+	rt.StateReadonly(&st)
+	snapshot, _, err := st.GetStaker(adt.AsStore(rt), resolved)
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load staker %v", resolved)
+
+	return &GetStakerInfoReturn{
+		LockedPrincipals:   snapshot.LockedPrincipals,
+		AvailablePrincipal: snapshot.AvailablePrincipal,
+		StakePower:         snapshot.StakePower,
+		VestingFunds:       snapshot.VestingFunds,
+		AvailableReward:    snapshot.AvailableReward,
+		Unbondings:         snapshot.Unbondings,
+	}
+}
+
+type GetStakeParamsReturn struct {
+	MaturePeriod          abi.ChainEpoch
+	RoundPeriod           abi.ChainEpoch
+	PrincipalLockDuration abi.ChainEpoch
+	MinDepositAmount      abi.TokenAmount
+	MaxRewardPerRound     abi.TokenAmount
+	InflationFactor       big.Int
+	UnbondingPeriod       abi.ChainEpoch
+	ProposalDelay         abi.ChainEpoch
+	RewardCurve           RewardCurveParams
+	RewardVestingSpec     miner.VestSpec
+}
+
+// Returns the policy parameters set by the root key.
+func (a Actor) GetStakeParams(rt Runtime, _ *abi.EmptyValue) *GetStakeParamsReturn {
+	rt.ValidateImmediateCallerAcceptAny()
+	var st State
+	rt.StateReadonly(&st)
+	vestSpec, _, err := st.rewardVestingSpec(adt.AsStore(rt))
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load reward vesting spec")
+
+	return &GetStakeParamsReturn{
+		MaturePeriod:          st.MaturePeriod,
+		RoundPeriod:           st.RoundPeriod,
+		PrincipalLockDuration: st.PrincipalLockDuration,
+		MinDepositAmount:      st.MinDepositAmount,
+		MaxRewardPerRound:     st.MaxRewardPerRound,
+		InflationFactor:       st.InflationFactor,
+		UnbondingPeriod:       st.UnbondingPeriod,
+		ProposalDelay:         st.ProposalDelay,
+		RewardCurve:           st.RewardCurve,
+		RewardVestingSpec:     *vestSpec,
+	}
+}
+
+type GetTotalStakePowerReturn struct {
+	TotalStakePower abi.StakePower
+}
+
+// Returns the total stake power recorded by the stake actor.
+func (a Actor) GetTotalStakePower(rt Runtime, _ *abi.EmptyValue) *GetTotalStakePowerReturn {
+	rt.ValidateImmediateCallerAcceptAny()
+	var st State
+	rt.StateReadonly(&st)
+
+	return &GetTotalStakePowerReturn{
+		TotalStakePower: st.TotalStakePower,
+	}
+}
+
+// Stake power is read by the miner actor to discount pledge, so the return value is defined there.
+type GetStakePowerReturn = miner.StakePowerReturn
+
+// Returns the stake power of a staker together with the total stake power.
+// The stake power is as of the last cron tick that processed the staker.
+func (a Actor) GetStakePower(rt Runtime, staker *addr.Address) *GetStakePowerReturn {
+	rt.ValidateImmediateCallerAcceptAny()
+	resolved, ok := rt.ResolveAddress(*staker)
+	if !ok {
+		rt.Abortf(exitcode.ErrIllegalArgument, "unable to resolve address %v", *staker)
+	}
+
+	var st State
+	rt.StateReadonly(&st)
+	stakePower, err := st.GetStakePower(adt.AsStore(rt), resolved)
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to get stake power for %v", resolved)
+
+	return &GetStakePowerReturn{
+		StakePower:      stakePower,
+		TotalStakePower: st.TotalStakePower,
+	}
+}
+
 // Called by Cron.
 func (a Actor) OnEpochTickEnd(rt Runtime, _ *abi.EmptyValue) *abi.EmptyValue {
 	rt.ValidateImmediateCallerIs(builtin.CronActorAddr)
@@ -295,7 +826,8 @@ func (a Actor) OnEpochTickEnd(rt Runtime, _ *abi.EmptyValue) *abi.EmptyValue {
 	currEpoch := rt.CurrEpoch()
 
 	rt.StateTransaction(&st, func() {
-		// 1. unlocked locked principals、 update available principals、update stake powers
+		activateProposals(rt, &st)
+
 		lockedPrincipalMap, err := adt.AsMap(store, st.LockedPrincipalMap, builtin.DefaultHamtBitwidth)
 		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load locked principal map")
 
@@ -305,19 +837,29 @@ func (a Actor) OnEpochTickEnd(rt Runtime, _ *abi.EmptyValue) *abi.EmptyValue {
 		stakePowerMap, err := adt.AsMap(store, st.StakePowerMap, builtin.DefaultHamtBitwidth)
 		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load stake powers")
 
-		lpKeys, err := lockedPrincipalMap.CollectKeys()
-		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to collect locked principal keys")
+		availableRewardMap, err := adt.AsMap(store, st.AvailableRewardMap, builtin.DefaultHamtBitwidth)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load available rewards")
+
+		vestingRewardMap, err := adt.AsMap(store, st.VestingRewardMap, builtin.DefaultHamtBitwidth)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load vesting rewards")
+
+		queue, err := adt.AsMultimap(store, st.StakerEventQueue, StakerQueueHamtBitwidth, StakerQueueAmtBitwidth)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load staker event queue")
+
+		autoCompound, err := adt.AsSet(store, st.AutoCompound, builtin.DefaultHamtBitwidth)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load auto compound stakers")
 
-		totalStakePower := big.Zero()
-		for _, key := range lpKeys {
-			staker, err := addr.NewFromBytes([]byte(key))
-			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to convert locked principal keys")
+		dueStakers, err := st.popDueStakers(queue, currEpoch)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to pop due stakers")
 
+		// 1. for stakers with due events, unlock locked principals, update available principals and stake powers,
+		// and unlock vesting rewards, restaking them for stakers that auto compound
+		newVestingRewards := make(map[addr.Address]*VestingFunds)
+		for _, staker := range dueStakers {
 			lockedPrincipals, found, err := st.LoadLockedPrincipals(store, lockedPrincipalMap, staker)
 			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load locked principal for: %v", staker)
 			if !found {
-				// should never happen
-				continue
+				lockedPrincipals = ConstructLockedPrincipals()
 			}
 
 			amountUnlocked := lockedPrincipals.unlockLockedPrincipals(st.PrincipalLockDuration, currEpoch)
@@ -330,12 +872,35 @@ func (a Actor) OnEpochTickEnd(rt Runtime, _ *abi.EmptyValue) *abi.EmptyValue {
 			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to update available principals")
 
 			powerInLockedPrincipals := lockedPrincipals.stakePower(st.MaturePeriod, currEpoch)
-			stakePower := big.Add(powerInLockedPrincipals, newAvailablePrincipal)
-			err = stakePowerMap.Put(abi.AddrKey(staker), &stakePower)
+			err = st.setStakePower(stakePowerMap, staker, big.Add(powerInLockedPrincipals, newAvailablePrincipal))
 			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to update stake power for %v", staker)
-			totalStakePower = big.Add(totalStakePower, stakePower)
+
+			vestingFunds, found, err := st.LoadVestingFunds(store, vestingRewardMap, staker)
+			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load vesting funds for: %v", staker)
+			if found {
+				rewardUnlocked := vestingFunds.unlockVestedFunds(currEpoch)
+				if !rewardUnlocked.IsZero() {
+					newVestingRewards[staker] = vestingFunds
+
+					compound, err := autoCompound.Has(abi.AddrKey(staker))
+					builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to check auto compound for %v", staker)
+					if compound {
+						// the restaked reward matures like a deposit made now, which nextStakerEventEpoch accounts for
+						lockedPrincipals.addLockedPrincipal(rewardUnlocked, currEpoch)
+						err = st.putLockedPrincipals(store, lockedPrincipalMap, staker, lockedPrincipals)
+						builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to put locked principals for %v", staker)
+					} else {
+						err = st.updateAvailableReward(availableRewardMap, staker, rewardUnlocked)
+						builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to update available reward for %v", staker)
+					}
+				}
+			}
+
+			if nextEpoch, ok := st.nextStakerEventEpoch(lockedPrincipals, vestingFunds, currEpoch); ok {
+				err = st.enqueueStaker(queue, nextEpoch, staker)
+				builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to enqueue staker %v", staker)
+			}
 		}
-		st.TotalStakePower = totalStakePower
 
 		lpm, err := lockedPrincipalMap.Root()
 		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to flush locked principalMap")
@@ -349,47 +914,22 @@ func (a Actor) OnEpochTickEnd(rt Runtime, _ *abi.EmptyValue) *abi.EmptyValue {
 		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to flush stake powers")
 		st.StakePowerMap = sp
 
-		// 2. unlock vesting and update available reward
-		availableRewardMap, err := adt.AsMap(store, st.AvailableRewardMap, builtin.DefaultHamtBitwidth)
-		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load available rewards")
-
-		vestingRewardMap, err := adt.AsMap(store, st.VestingRewardMap, builtin.DefaultHamtBitwidth)
-		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load vesting rewards")
-		vrKeys, err := vestingRewardMap.CollectKeys()
-		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to collect vesting rewards keys")
-		newVestingRewards := make(map[addr.Address]*VestingFunds)
-
-		for _, key := range vrKeys {
-			staker, err := addr.NewFromBytes([]byte(key))
-			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to convert vesting rewards keys")
-
-			vestingFunds, found, err := st.LoadVestingFunds(store, vestingRewardMap, staker)
-			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load vesting funds for: %v", staker)
-			if !found {
-				// should never happen
-				continue
-			}
-			amountUnlocked := vestingFunds.unlockVestedFunds(currEpoch)
-			if amountUnlocked.IsZero() {
-				continue
-			}
-			newVestingRewards[staker] = vestingFunds
-
-			err = st.updateAvailableReward(availableRewardMap, staker, amountUnlocked)
-			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to update available reward for %v", staker)
-		}
 		ar, err := availableRewardMap.Root()
 		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to flush available rewards")
 		st.AvailableRewardMap = ar
 
-		// 3. distribute vesting reward
+		// 2. distribute vesting reward
 		if currEpoch >= st.NextRoundEpoch {
 			totalReward := abi.NewTokenAmount(0)
 			if st.TotalStakePower.GreaterThan(big.Zero()) {
-				totalReward = big.Mul(st.TotalStakePower, st.InflationFactor)
-				totalReward = big.Div(totalReward, InflationDenominator)
+				totalReward = st.roundReward(currEpoch, rt.TotalFilCircSupply())
 				totalReward = big.Min(totalReward, st.MaxRewardPerRound)
 				if totalReward.GreaterThan(big.Zero()) {
+					delegations, err := adt.AsMap(store, st.Delegations, builtin.DefaultHamtBitwidth)
+					builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load delegations")
+					vestSpec, vestSpecKey, err := st.rewardVestingSpec(store)
+					builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load reward vesting spec")
+
 					var power abi.StakePower
 					err = stakePowerMap.ForEach(&power, func(key string) error {
 						reward := big.Mul(power, totalReward)
@@ -399,17 +939,31 @@ func (a Actor) OnEpochTickEnd(rt Runtime, _ *abi.EmptyValue) *abi.EmptyValue {
 							if err != nil {
 								return err
 							}
-							vestingFunds, ok := newVestingRewards[staker]
+							// rewards for delegated principal vest for the delegation's reward receiver
+							receiver, err := st.rewardReceiver(delegations, staker)
+							builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load reward receiver for %v", staker)
+
+							vestingFunds, ok := newVestingRewards[receiver]
 							if !ok {
 								var found bool
-								vestingFunds, found, err = st.LoadVestingFunds(store, vestingRewardMap, staker)
-								builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load vesting funds for: %v", staker)
+								vestingFunds, found, err = st.LoadVestingFunds(store, vestingRewardMap, receiver)
+								builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load vesting funds for: %v", receiver)
 								if !found {
 									vestingFunds = ConstructVestingFunds()
 								}
 							}
-							vestingFunds.addLockedFunds(currEpoch, reward, st.StakePeriodStart, &RewardVestingSpec)
-							newVestingRewards[staker] = vestingFunds
+							// the receiver is queued for its earliest vesting epoch already, unless the new funds vest sooner
+							firstVesting := len(vestingFunds.Funds) == 0
+							var prevFirstEpoch abi.ChainEpoch
+							if !firstVesting {
+								prevFirstEpoch = vestingFunds.Funds[0].Epoch
+							}
+							vestingFunds.addLockedFunds(currEpoch, reward, st.StakePeriodStart, vestSpec, vestSpecKey)
+							newVestingRewards[receiver] = vestingFunds
+							if len(vestingFunds.Funds) > 0 && (firstVesting || vestingFunds.Funds[0].Epoch < prevFirstEpoch) {
+								err = st.enqueueStaker(queue, vestingFunds.Funds[0].Epoch+1, receiver)
+								builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to enqueue reward receiver %v", receiver)
+							}
 						}
 						return nil
 					})
@@ -427,6 +981,282 @@ func (a Actor) OnEpochTickEnd(rt Runtime, _ *abi.EmptyValue) *abi.EmptyValue {
 		vr, err := vestingRewardMap.Root()
 		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to flush vesting rewards")
 		st.VestingRewardMap = vr
+
+		st.StakerEventQueue, err = queue.Root()
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to flush staker event queue")
 	})
 	return nil
 }
+
+type ProposeReturn struct {
+	ID              ProposalID
+	ActivationEpoch abi.ChainEpoch
+}
+
+// Records a proposal to invoke the effect of method with params once the proposal delay has passed.
+func propose(rt Runtime, method abi.MethodNum, params cbor.Marshaler) *ProposeReturn {
+	buf := new(bytes.Buffer)
+	err := params.MarshalCBOR(buf)
+	builtin.RequireNoErr(rt, err, exitcode.ErrSerialization, "failed to serialize params")
+
+	var ret ProposeReturn
+	var st State
+	rt.StateTransaction(&st, func() {
+		proposals, err := adt.AsMap(adt.AsStore(rt), st.Proposals, builtin.DefaultHamtBitwidth)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load proposals")
+		ret.ID, ret.ActivationEpoch, err = st.addProposal(proposals, method, buf.Bytes(), rt.CurrEpoch())
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to add proposal")
+		st.Proposals, err = proposals.Root()
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to flush proposals")
+	})
+	return &ret
+}
+
+// Applies and removes the proposals whose activation epoch has been reached, in the order they were made.
+// A proposal that is no longer valid against the current parameters is dropped.
+func activateProposals(rt Runtime, st *State) {
+	proposals, err := adt.AsMap(adt.AsStore(rt), st.Proposals, builtin.DefaultHamtBitwidth)
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load proposals")
+	pending, err := st.loadProposals(proposals)
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load proposals")
+
+	activated := false
+	for _, p := range pending {
+		if p.Proposal.ActivationEpoch > rt.CurrEpoch() {
+			continue
+		}
+		if err := applyProposal(rt, st, &p.Proposal); err != nil {
+			rt.Log(rtt.WARN, "dropping proposal %d: %v", p.ID, err)
+		}
+		err = proposals.Delete(p.ID)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to delete proposal %d", p.ID)
+		activated = true
+	}
+	if activated {
+		st.Proposals, err = proposals.Root()
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to flush proposals")
+	}
+}
+
+// Applies the change of a proposal, returning an error if it conflicts with the current parameters.
+func applyProposal(rt Runtime, st *State, proposal *Proposal) error {
+	params := bytes.NewReader(proposal.Params)
+	switch proposal.Method {
+	case builtin.MethodsStake.ChangeMaturePeriod:
+		var p ChangeMaturePeriodParams
+		if err := p.UnmarshalCBOR(params); err != nil {
+			return xerrors.Errorf("failed to unmarshal mature period: %w", err)
+		}
+		if p.MaturePeriod > st.PrincipalLockDuration {
+			return xerrors.Errorf("mature period %d cannot be greater than principal lock duration %d", p.MaturePeriod, st.PrincipalLockDuration)
+		}
+		st.MaturePeriod = p.MaturePeriod
+		rescheduleAllStakers(rt, st)
+	case builtin.MethodsStake.ChangeRoundPeriod:
+		var p ChangeRoundPeriodParams
+		if err := p.UnmarshalCBOR(params); err != nil {
+			return xerrors.Errorf("failed to unmarshal round period: %w", err)
+		}
+		st.RoundPeriod = p.RoundPeriod
+	case builtin.MethodsStake.ChangePrincipalLockDuration:
+		var p ChangePrincipalLockDurationParams
+		if err := p.UnmarshalCBOR(params); err != nil {
+			return xerrors.Errorf("failed to unmarshal principal lock duration: %w", err)
+		}
+		if p.PrincipalLockDuration < st.MaturePeriod {
+			return xerrors.Errorf("principal lock duration %d cannot be less than mature period %d", p.PrincipalLockDuration, st.MaturePeriod)
+		}
+		st.PrincipalLockDuration = p.PrincipalLockDuration
+		rescheduleAllStakers(rt, st)
+	case builtin.MethodsStake.ChangeUnbondingPeriod:
+		var p ChangeUnbondingPeriodParams
+		if err := p.UnmarshalCBOR(params); err != nil {
+			return xerrors.Errorf("failed to unmarshal unbonding period: %w", err)
+		}
+		st.UnbondingPeriod = p.UnbondingPeriod
+	case builtin.MethodsStake.ChangeMinDepositAmount:
+		var p ChangeMinDepositAmountParams
+		if err := p.UnmarshalCBOR(params); err != nil {
+			return xerrors.Errorf("failed to unmarshal min deposit amount: %w", err)
+		}
+		st.MinDepositAmount = p.MinDepositAmount
+	case builtin.MethodsStake.ChangeMaxRewardsPerRound:
+		var p ChangeMaxRewardsPerRoundParams
+		if err := p.UnmarshalCBOR(params); err != nil {
+			return xerrors.Errorf("failed to unmarshal max reward per round: %w", err)
+		}
+		st.MaxRewardPerRound = p.MaxRewardPerRound
+	case builtin.MethodsStake.ChangeInflationFactor:
+		var p ChangeInflationFactorParams
+		if err := p.UnmarshalCBOR(params); err != nil {
+			return xerrors.Errorf("failed to unmarshal inflation factor: %w", err)
+		}
+		st.InflationFactor = p.InflationFactor
+	case builtin.MethodsStake.ChangeRootKey:
+		var newRootKey addr.Address
+		if err := newRootKey.UnmarshalCBOR(params); err != nil {
+			return xerrors.Errorf("failed to unmarshal root key: %w", err)
+		}
+		st.RootKey = newRootKey
+	case builtin.MethodsStake.ChangeProposalDelay:
+		var p ChangeProposalDelayParams
+		if err := p.UnmarshalCBOR(params); err != nil {
+			return xerrors.Errorf("failed to unmarshal proposal delay: %w", err)
+		}
+		st.ProposalDelay = p.ProposalDelay
+	case builtin.MethodsStake.ChangeRewardVestingSpec:
+		var p ChangeRewardVestingSpecParams
+		if err := p.UnmarshalCBOR(params); err != nil {
+			return xerrors.Errorf("failed to unmarshal reward vesting spec: %w", err)
+		}
+		if err := st.setRewardVestingSpec(adt.AsStore(rt), rt.CurrEpoch(), &p.Spec); err != nil {
+			return err
+		}
+	case builtin.MethodsStake.ChangeRewardCurve:
+		var p ChangeRewardCurveParams
+		if err := p.UnmarshalCBOR(params); err != nil {
+			return xerrors.Errorf("failed to unmarshal reward curve: %w", err)
+		}
+		st.RewardCurve = p.RewardCurve
+	case builtin.MethodsStake.AddSlasher:
+		var slasher addr.Address
+		if err := slasher.UnmarshalCBOR(params); err != nil {
+			return xerrors.Errorf("failed to unmarshal slasher: %w", err)
+		}
+		slashers, err := adt.AsSet(adt.AsStore(rt), st.Slashers, builtin.DefaultHamtBitwidth)
+		if err != nil {
+			return xerrors.Errorf("failed to load slashers: %w", err)
+		}
+		if err = slashers.Put(abi.AddrKey(slasher)); err != nil {
+			return xerrors.Errorf("failed to add slasher %v: %w", slasher, err)
+		}
+		if st.Slashers, err = slashers.Root(); err != nil {
+			return xerrors.Errorf("failed to flush slashers: %w", err)
+		}
+	case builtin.MethodsStake.RemoveSlasher:
+		var slasher addr.Address
+		if err := slasher.UnmarshalCBOR(params); err != nil {
+			return xerrors.Errorf("failed to unmarshal slasher: %w", err)
+		}
+		slashers, err := adt.AsSet(adt.AsStore(rt), st.Slashers, builtin.DefaultHamtBitwidth)
+		if err != nil {
+			return xerrors.Errorf("failed to load slashers: %w", err)
+		}
+		removed, err := slashers.TryDelete(abi.AddrKey(slasher))
+		if err != nil {
+			return xerrors.Errorf("failed to remove slasher %v: %w", slasher, err)
+		}
+		if !removed {
+			return xerrors.Errorf("%v is not a slasher", slasher)
+		}
+		if st.Slashers, err = slashers.Root(); err != nil {
+			return xerrors.Errorf("failed to flush slashers: %w", err)
+		}
+	default:
+		return xerrors.Errorf("method %d cannot be proposed", proposal.Method)
+	}
+	return nil
+}
+
+// Queues every staker for re-evaluation at the current epoch's tick, since a change to the
+// mature period or principal lock duration moves the epochs at which their principal matures or unlocks.
+func rescheduleAllStakers(rt Runtime, st *State) {
+	store := adt.AsStore(rt)
+	queue, err := adt.AsMultimap(store, st.StakerEventQueue, StakerQueueHamtBitwidth, StakerQueueAmtBitwidth)
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load staker event queue")
+	err = st.enqueueAllStakers(store, queue, rt.CurrEpoch())
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to enqueue stakers")
+	st.StakerEventQueue, err = queue.Root()
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to flush staker event queue")
+}
+
+func minEpoch(a, b abi.ChainEpoch) abi.ChainEpoch {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// Locks amount as newly deposited principal of staker, first releasing any of its principal that has unlocked.
+func depositPrincipal(rt Runtime, st *State, staker addr.Address, amount abi.TokenAmount) {
+	store := adt.AsStore(rt)
+	currEpoch := rt.CurrEpoch()
+
+	lockedPrincipalMap, err := adt.AsMap(store, st.LockedPrincipalMap, builtin.DefaultHamtBitwidth)
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load locked principalsMap")
+	lockedPrincipals, found, err := st.LoadLockedPrincipals(store, lockedPrincipalMap, staker)
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load locked principals for %v", staker)
+	if !found {
+		lockedPrincipals = ConstructLockedPrincipals()
+	}
+	newlyUnlocked := lockedPrincipals.unlockLockedPrincipals(st.PrincipalLockDuration, currEpoch)
+
+	availablePrincipalMap, err := adt.AsMap(store, st.AvailablePrincipalMap, builtin.DefaultHamtBitwidth)
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load available principals")
+	_, err = st.updateAvailablePrincipal(availablePrincipalMap, staker, newlyUnlocked)
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to update available principals")
+	ap, err := availablePrincipalMap.Root()
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to flush available principals")
+	st.AvailablePrincipalMap = ap
+
+	// A deposit first needs processing when it matures or unlocks, whichever comes first.
+	// Earlier deposits in the same epoch have already been queued.
+	if n := len(lockedPrincipals.Data); n == 0 || lockedPrincipals.Data[n-1].Epoch != currEpoch {
+		queue, err := adt.AsMultimap(store, st.StakerEventQueue, StakerQueueHamtBitwidth, StakerQueueAmtBitwidth)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load staker event queue")
+		dueEpoch := currEpoch + minEpoch(st.MaturePeriod, st.PrincipalLockDuration) + 1
+		err = st.enqueueStaker(queue, dueEpoch, staker)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to enqueue staker %v", staker)
+		st.StakerEventQueue, err = queue.Root()
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to flush staker event queue")
+	}
+
+	lockedPrincipals.addLockedPrincipal(amount, currEpoch)
+	err = st.putLockedPrincipals(store, lockedPrincipalMap, staker, lockedPrincipals)
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to put locked principals")
+	lpm, err := lockedPrincipalMap.Root()
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to flush locked principalMap")
+	st.LockedPrincipalMap = lpm
+}
+
+// Deducts amount from the available principal of staker along with the stake power it carries.
+func withdrawAvailablePrincipal(rt Runtime, st *State, staker addr.Address, amount abi.TokenAmount) {
+	store := adt.AsStore(rt)
+
+	availablePrincipalMap, err := adt.AsMap(store, st.AvailablePrincipalMap, builtin.DefaultHamtBitwidth)
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load available principals")
+	_, err = st.updateAvailablePrincipal(availablePrincipalMap, staker, amount.Neg())
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to update available principals")
+	ap, err := availablePrincipalMap.Root()
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to flush available principals")
+	st.AvailablePrincipalMap = ap
+
+	stakePowerMap, err := adt.AsMap(store, st.StakePowerMap, builtin.DefaultHamtBitwidth)
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load stake powers")
+	err = st.updateStakePower(stakePowerMap, staker, amount.Neg())
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to update stake power")
+	sp, err := stakePowerMap.Root()
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to flush stake power")
+	st.StakePowerMap = sp
+}
+
+// Aborts if the principal of staker is deposited by another address through DepositFor.
+// A delegation with no principal left staked is removed instead: delegated principal still unbonding
+// is paid to the recipient recorded when it was withdrawn, so the staker may stake on its own again.
+func requireNotDelegated(rt Runtime, st *State, staker addr.Address) {
+	store := adt.AsStore(rt)
+	delegations, err := adt.AsMap(store, st.Delegations, builtin.DefaultHamtBitwidth)
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load delegations")
+	delegation, found, err := st.LoadDelegation(delegations, staker)
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load delegation for %v", staker)
+	if !found {
+		return
+	}
+	principal, err := st.totalPrincipal(store, staker)
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load principal of %v", staker)
+	if !principal.IsZero() {
+		rt.Abortf(exitcode.ErrForbidden, "principal of %v is deposited by %v and may only be added through DepositFor", staker, delegation.Depositor)
+	}
+	_, err = st.deleteDelegation(store, staker)
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to delete delegation for %v", staker)
+}
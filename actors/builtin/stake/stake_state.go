@@ -4,6 +4,7 @@ import (
 	addr "github.com/filecoin-project/go-address"
 	"github.com/filecoin-project/go-state-types/big"
 	"github.com/filecoin-project/specs-actors/v3/actors/builtin"
+	"github.com/filecoin-project/specs-actors/v3/actors/builtin/miner"
 	cbg "github.com/whyrusleeping/cbor-gen"
 	"golang.org/x/xerrors"
 	"reflect"
@@ -15,6 +16,18 @@ import (
 
 var InflationDenominator = big.NewInt(10000)
 
+// Number of epochs withdrawn principal spends unbonding, until the root key changes it.
+var DefaultUnbondingPeriod = abi.ChainEpoch(7 * builtin.EpochsInDay) // PARAM_SPEC
+
+// Bitwidth of the HAMT indexing the staker event queue by epoch.
+const StakerQueueHamtBitwidth = 6
+
+// Bitwidth of the AMTs holding the stakers with events due at an epoch.
+const StakerQueueAmtBitwidth = 6
+
+// Bitwidth of the AMT holding the reward vesting specs, which the root key seldom changes.
+const RewardVestingSpecsAmtBitwidth = 3
+
 type State struct {
 	RootKey         addr.Address
 	TotalStakePower abi.StakePower
@@ -25,6 +38,7 @@ type State struct {
 	MinDepositAmount      abi.TokenAmount
 	MaxRewardPerRound     abi.TokenAmount
 	InflationFactor       big.Int
+	RewardCurve           RewardCurveParams
 	LastRoundReward       abi.TokenAmount
 	StakePeriodStart      abi.ChainEpoch
 	NextRoundEpoch        abi.ChainEpoch
@@ -34,13 +48,60 @@ type State struct {
 	StakePowerMap         cid.Cid // Map, (HAMT[address]StakePower)
 	VestingRewardMap      cid.Cid // Map, (HAMT[address]VestingFundsCid)
 	AvailableRewardMap    cid.Cid // Map, (HAMT[address]TokenAmount)
+
+	// Stakers with principal maturing or unlocking, or rewards vesting, at each epoch.
+	// A staker appears in the queue at or before the first epoch at which one of its events is due.
+	StakerEventQueue cid.Cid // Multimap, (HAMT[ChainEpoch]AMT[address])
+
+	// First epoch in which an event may be present in the staker event queue.
+	// Events are processed by cron for every epoch from this one up to the current epoch.
+	FirstEventEpoch abi.ChainEpoch
+
+	Delegations cid.Cid // Map, (HAMT[address]Delegation)
+
+	// Stakers whose rewards are restaked as locked principal when they vest.
+	AutoCompound cid.Cid // Set, (HAMT[address]struct{})
+
+	// Actors permitted to slash stakers.
+	Slashers cid.Cid // Set, (HAMT[address]struct{})
+
+	// Number of epochs withdrawn principal spends unbonding before it can be claimed.
+	UnbondingPeriod abi.ChainEpoch
+	UnbondingMap    cid.Cid // Map, (HAMT[address]UnbondingsCid)
+
+	// Number of epochs a proposed parameter change waits before cron applies it.
+	ProposalDelay  abi.ChainEpoch
+	Proposals      cid.Cid // Map, (HAMT[ProposalID]Proposal)
+	NextProposalID ProposalID
+
+	// Every spec rewards have been scheduled to vest under, keyed by the epoch at which it took effect,
+	// which VestingFund.Spec records.
+	RewardVestingSpecs cid.Cid // Array, AMT[ChainEpoch]VestSpec
+	// Key of the spec new rewards vest under.
+	CurrentRewardVestingSpec uint64
 }
 
-func ConstructState(store adt.Store, params *ConstructorParams) (*State, error) {
+// Constructs the initial stake state. Rewards follow rewardCurve, or the linear curve if it is nil.
+func ConstructState(store adt.Store, params *ConstructorParams, rewardCurve *RewardCurveParams) (*State, error) {
+	curve := LinearRewardCurve()
+	if rewardCurve != nil {
+		if err := rewardCurve.Validate(); err != nil {
+			return nil, xerrors.Errorf("invalid reward curve: %w", err)
+		}
+		curve = *rewardCurve
+	}
 	emptyMapCid, err := adt.StoreEmptyMap(store, builtin.DefaultHamtBitwidth)
 	if err != nil {
 		return nil, xerrors.Errorf("failed to create empty map: %w", err)
 	}
+	emptyQueueCid, err := adt.StoreEmptyMultimap(store, StakerQueueHamtBitwidth, StakerQueueAmtBitwidth)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to create empty multimap: %w", err)
+	}
+	vestSpecsCid, err := ConstructRewardVestingSpecs(store)
+	if err != nil {
+		return nil, err
+	}
 
 	return &State{
 		RootKey:               params.RootKey,
@@ -51,6 +112,7 @@ func ConstructState(store adt.Store, params *ConstructorParams) (*State, error)
 		MinDepositAmount:      params.MinDepositAmount,
 		MaxRewardPerRound:     params.MaxRewardPerRound,
 		InflationFactor:       params.InflationFactor,
+		RewardCurve:           curve,
 		LastRoundReward:       abi.NewTokenAmount(0),
 		StakePeriodStart:      params.FirstRoundEpoch,
 		NextRoundEpoch:        params.FirstRoundEpoch,
@@ -59,9 +121,69 @@ func ConstructState(store adt.Store, params *ConstructorParams) (*State, error)
 		StakePowerMap:         emptyMapCid,
 		VestingRewardMap:      emptyMapCid,
 		AvailableRewardMap:    emptyMapCid,
+		StakerEventQueue:      emptyQueueCid,
+		FirstEventEpoch:       0,
+		Delegations:           emptyMapCid,
+		AutoCompound:          emptyMapCid,
+		Slashers:              emptyMapCid,
+		UnbondingPeriod:       DefaultUnbondingPeriod,
+		UnbondingMap:          emptyMapCid,
+		ProposalDelay:         DefaultProposalDelay,
+		Proposals:             emptyMapCid,
+		NextProposalID:        0,
+		RewardVestingSpecs:    vestSpecsCid,
 	}, nil
 }
 
+// Stores the reward vesting specs of a new stake actor, holding only the default spec under key 0,
+// which is the zero value of State.CurrentRewardVestingSpec.
+func ConstructRewardVestingSpecs(store adt.Store) (cid.Cid, error) {
+	vestSpecs, err := adt.MakeEmptyArray(store, RewardVestingSpecsAmtBitwidth)
+	if err != nil {
+		return cid.Undef, xerrors.Errorf("failed to create empty array: %w", err)
+	}
+	spec := DefaultRewardVestingSpec
+	if err = vestSpecs.Set(0, &spec); err != nil {
+		return cid.Undef, xerrors.Errorf("failed to set default reward vesting spec: %w", err)
+	}
+	return vestSpecs.Root()
+}
+
+// Returns the spec new rewards vest under, and its key.
+func (st *State) rewardVestingSpec(store adt.Store) (*miner.VestSpec, uint64, error) {
+	vestSpecs, err := adt.AsArray(store, st.RewardVestingSpecs, RewardVestingSpecsAmtBitwidth)
+	if err != nil {
+		return nil, 0, xerrors.Errorf("failed to load reward vesting specs: %w", err)
+	}
+	var spec miner.VestSpec
+	found, err := vestSpecs.Get(st.CurrentRewardVestingSpec, &spec)
+	if err != nil {
+		return nil, 0, xerrors.Errorf("failed to get reward vesting spec %d: %w", st.CurrentRewardVestingSpec, err)
+	}
+	if !found {
+		return nil, 0, xerrors.Errorf("reward vesting spec %d not found", st.CurrentRewardVestingSpec)
+	}
+	return &spec, st.CurrentRewardVestingSpec, nil
+}
+
+// Makes spec the one new rewards vest under from currEpoch, keeping earlier specs for the rewards vesting under them.
+// Specs are only ever added by cron, at most once per epoch, before rewards of the epoch vest under them.
+func (st *State) setRewardVestingSpec(store adt.Store, currEpoch abi.ChainEpoch, spec *miner.VestSpec) error {
+	vestSpecs, err := adt.AsArray(store, st.RewardVestingSpecs, RewardVestingSpecsAmtBitwidth)
+	if err != nil {
+		return xerrors.Errorf("failed to load reward vesting specs: %w", err)
+	}
+	key := uint64(currEpoch)
+	if err = vestSpecs.Set(key, spec); err != nil {
+		return xerrors.Errorf("failed to set reward vesting spec %d: %w", key, err)
+	}
+	if st.RewardVestingSpecs, err = vestSpecs.Root(); err != nil {
+		return xerrors.Errorf("failed to flush reward vesting specs: %w", err)
+	}
+	st.CurrentRewardVestingSpec = key
+	return nil
+}
+
 func (st *State) LoadLockedPrincipals(store adt.Store, lockedPrincipalMap *adt.Map, staker addr.Address) (*LockedPrincipals, bool, error) {
 	var lockedPrincipalsCid cid.Cid
 	var lockedPrincipalsCborCid cbg.CborCid
@@ -133,6 +255,7 @@ func (st *State) updateAvailableReward(availableRewardMap *adt.Map, staker addr.
 	return nil
 }
 
+// Adds powerDelta to the stake power of a staker, keeping the total stake power in sync.
 func (st *State) updateStakePower(stakePowerMap *adt.Map, staker addr.Address, powerDelta abi.StakePower) error {
 	var power abi.StakePower
 	found, err := stakePowerMap.Get(abi.AddrKey(staker), &power)
@@ -142,20 +265,201 @@ func (st *State) updateStakePower(stakePowerMap *adt.Map, staker addr.Address, p
 	if !found {
 		power = big.Zero()
 	}
-	newPower := big.Add(power, powerDelta)
+	return st.setStakePower(stakePowerMap, staker, big.Add(power, powerDelta))
+}
+
+// Returns the locked and available principal of a staker.
+func (st *State) totalPrincipal(store adt.Store, staker addr.Address) (abi.TokenAmount, error) {
+	lockedPrincipalMap, err := adt.AsMap(store, st.LockedPrincipalMap, builtin.DefaultHamtBitwidth)
+	if err != nil {
+		return big.Zero(), xerrors.Errorf("failed to load locked principals: %w", err)
+	}
+	lockedPrincipals, found, err := st.LoadLockedPrincipals(store, lockedPrincipalMap, staker)
+	if err != nil {
+		return big.Zero(), err
+	}
+	total := big.Zero()
+	if found {
+		for _, lp := range lockedPrincipals.Data {
+			total = big.Add(total, lp.Amount)
+		}
+	}
+
+	availablePrincipalMap, err := adt.AsMap(store, st.AvailablePrincipalMap, builtin.DefaultHamtBitwidth)
+	if err != nil {
+		return big.Zero(), xerrors.Errorf("failed to load available principals: %w", err)
+	}
+	var available abi.TokenAmount
+	found, err = availablePrincipalMap.Get(abi.AddrKey(staker), &available)
+	if err != nil {
+		return big.Zero(), xerrors.Errorf("failed to get available principal for %v: %w", staker, err)
+	}
+	if found {
+		total = big.Add(total, available)
+	}
+	return total, nil
+}
+
+// Returns the stake power of a staker, zero if it has none.
+func (st *State) GetStakePower(store adt.Store, staker addr.Address) (abi.StakePower, error) {
+	stakePowerMap, err := adt.AsMap(store, st.StakePowerMap, builtin.DefaultHamtBitwidth)
+	if err != nil {
+		return big.Zero(), xerrors.Errorf("failed to load stake powers: %w", err)
+	}
+	var power abi.StakePower
+	found, err := stakePowerMap.Get(abi.AddrKey(staker), &power)
+	if err != nil {
+		return big.Zero(), xerrors.Errorf("failed to get stake power for %v: %w", staker, err)
+	}
+	if !found {
+		return big.Zero(), nil
+	}
+	return power, nil
+}
+
+// Sets the stake power of a staker, keeping the total stake power in sync.
+func (st *State) setStakePower(stakePowerMap *adt.Map, staker addr.Address, newPower abi.StakePower) error {
+	var power abi.StakePower
+	found, err := stakePowerMap.Get(abi.AddrKey(staker), &power)
+	if err != nil {
+		return xerrors.Errorf("failed to get stake power for %v: %w", staker, err)
+	}
+	if !found {
+		power = big.Zero()
+	}
 	if newPower.LessThan(big.Zero()) {
 		return xerrors.Errorf("stake power cannot be negative %s", newPower)
 	}
-	if newPower.IsZero() {
-		if err = stakePowerMap.Delete(abi.AddrKey(staker)); err != nil {
-			return xerrors.Errorf("failed to remove staker: %w", err)
+	if found && newPower.Equals(power) {
+		return nil
+	}
+	if err = stakePowerMap.Put(abi.AddrKey(staker), &newPower); err != nil {
+		return xerrors.Errorf("failed to put stake power: %w", err)
+	}
+	st.TotalStakePower = big.Add(st.TotalStakePower, big.Sub(newPower, power))
+	return nil
+}
+
+// Takes up to amount from a staker, first from its locked principal starting with the earliest deposits,
+// then from its available principal, then from its unbonding principal in order of withdrawal,
+// then from its vesting rewards starting with the earliest to vest.
+// The staker's power is recomputed as of currEpoch. Returns the amount taken.
+func (st *State) slash(store adt.Store, staker addr.Address, amount abi.TokenAmount, currEpoch abi.ChainEpoch) (abi.TokenAmount, error) {
+	remaining := amount
+	take := func(available abi.TokenAmount) abi.TokenAmount {
+		taken := big.Min(available, remaining)
+		remaining = big.Sub(remaining, taken)
+		return taken
+	}
+
+	lockedPrincipalMap, err := adt.AsMap(store, st.LockedPrincipalMap, builtin.DefaultHamtBitwidth)
+	if err != nil {
+		return big.Zero(), xerrors.Errorf("failed to load locked principals: %w", err)
+	}
+	lockedPrincipals, found, err := st.LoadLockedPrincipals(store, lockedPrincipalMap, staker)
+	if err != nil {
+		return big.Zero(), err
+	}
+	if !found {
+		lockedPrincipals = ConstructLockedPrincipals()
+	}
+	var keptPrincipals []LockedPrincipal
+	for _, lp := range lockedPrincipals.Data {
+		lp.Amount = big.Sub(lp.Amount, take(lp.Amount))
+		if lp.Amount.GreaterThan(big.Zero()) {
+			keptPrincipals = append(keptPrincipals, lp)
 		}
-	} else {
-		if err = stakePowerMap.Put(abi.AddrKey(staker), &newPower); err != nil {
-			return xerrors.Errorf("failed to put stake power: %w", err)
+	}
+	if found {
+		lockedPrincipals.Data = keptPrincipals
+		if err = st.putLockedPrincipals(store, lockedPrincipalMap, staker, lockedPrincipals); err != nil {
+			return big.Zero(), err
+		}
+		if st.LockedPrincipalMap, err = lockedPrincipalMap.Root(); err != nil {
+			return big.Zero(), xerrors.Errorf("failed to flush locked principals: %w", err)
 		}
 	}
-	return nil
+
+	availablePrincipalMap, err := adt.AsMap(store, st.AvailablePrincipalMap, builtin.DefaultHamtBitwidth)
+	if err != nil {
+		return big.Zero(), xerrors.Errorf("failed to load available principals: %w", err)
+	}
+	// a zero delta reads the current available principal
+	availablePrincipal, err := st.updateAvailablePrincipal(availablePrincipalMap, staker, big.Zero())
+	if err != nil {
+		return big.Zero(), err
+	}
+	availablePrincipal, err = st.updateAvailablePrincipal(availablePrincipalMap, staker, take(availablePrincipal).Neg())
+	if err != nil {
+		return big.Zero(), err
+	}
+	if st.AvailablePrincipalMap, err = availablePrincipalMap.Root(); err != nil {
+		return big.Zero(), xerrors.Errorf("failed to flush available principals: %w", err)
+	}
+
+	stakePowerMap, err := adt.AsMap(store, st.StakePowerMap, builtin.DefaultHamtBitwidth)
+	if err != nil {
+		return big.Zero(), xerrors.Errorf("failed to load stake powers: %w", err)
+	}
+	power := big.Add(lockedPrincipals.stakePower(st.MaturePeriod, currEpoch), availablePrincipal)
+	if err = st.setStakePower(stakePowerMap, staker, power); err != nil {
+		return big.Zero(), err
+	}
+	if st.StakePowerMap, err = stakePowerMap.Root(); err != nil {
+		return big.Zero(), xerrors.Errorf("failed to flush stake powers: %w", err)
+	}
+
+	unbondingMap, err := adt.AsMap(store, st.UnbondingMap, builtin.DefaultHamtBitwidth)
+	if err != nil {
+		return big.Zero(), xerrors.Errorf("failed to load unbondings: %w", err)
+	}
+	unbondings, found, err := st.LoadUnbondings(store, unbondingMap, staker)
+	if err != nil {
+		return big.Zero(), err
+	}
+	if found && remaining.GreaterThan(big.Zero()) {
+		var keptUnbondings []Unbonding
+		for _, ub := range unbondings.Data {
+			ub.Amount = big.Sub(ub.Amount, take(ub.Amount))
+			if ub.Amount.GreaterThan(big.Zero()) {
+				keptUnbondings = append(keptUnbondings, ub)
+			}
+		}
+		unbondings.Data = keptUnbondings
+		if err = st.putUnbondings(store, unbondingMap, staker, unbondings); err != nil {
+			return big.Zero(), err
+		}
+		if st.UnbondingMap, err = unbondingMap.Root(); err != nil {
+			return big.Zero(), xerrors.Errorf("failed to flush unbondings: %w", err)
+		}
+	}
+
+	vestingRewardMap, err := adt.AsMap(store, st.VestingRewardMap, builtin.DefaultHamtBitwidth)
+	if err != nil {
+		return big.Zero(), xerrors.Errorf("failed to load vesting rewards: %w", err)
+	}
+	vestingFunds, found, err := st.LoadVestingFunds(store, vestingRewardMap, staker)
+	if err != nil {
+		return big.Zero(), err
+	}
+	if found && remaining.GreaterThan(big.Zero()) {
+		var keptFunds []VestingFund
+		for _, vf := range vestingFunds.Funds {
+			vf.Amount = big.Sub(vf.Amount, take(vf.Amount))
+			if vf.Amount.GreaterThan(big.Zero()) {
+				keptFunds = append(keptFunds, vf)
+			}
+		}
+		vestingFunds.Funds = keptFunds
+		if err = st.putVestingFunds(store, vestingRewardMap, staker, vestingFunds); err != nil {
+			return big.Zero(), err
+		}
+		if st.VestingRewardMap, err = vestingRewardMap.Root(); err != nil {
+			return big.Zero(), xerrors.Errorf("failed to flush vesting rewards: %w", err)
+		}
+	}
+
+	return big.Sub(amount, remaining), nil
 }
 
 func (st *State) LoadVestingFunds(store adt.Store, vestingRewardMap *adt.Map, staker addr.Address) (*VestingFunds, bool, error) {
@@ -188,6 +492,97 @@ func (st *State) putVestingFunds(store adt.Store, vestingRewardMap *adt.Map, sta
 	return nil
 }
 
+func (st *State) enqueueStaker(queue *adt.Multimap, epoch abi.ChainEpoch, staker addr.Address) error {
+	// if event is in past, alter FirstEventEpoch so it will be found.
+	if epoch < st.FirstEventEpoch {
+		st.FirstEventEpoch = epoch
+	}
+
+	if err := queue.Add(epochKey(epoch), &staker); err != nil {
+		return xerrors.Errorf("failed to enqueue staker %v at epoch %v: %w", staker, epoch, err)
+	}
+	return nil
+}
+
+// Enqueues every staker with locked principal at epoch, so that all stakers are re-evaluated
+// after a change to the mature period or principal lock duration.
+func (st *State) enqueueAllStakers(store adt.Store, queue *adt.Multimap, epoch abi.ChainEpoch) error {
+	lockedPrincipalMap, err := adt.AsMap(store, st.LockedPrincipalMap, builtin.DefaultHamtBitwidth)
+	if err != nil {
+		return xerrors.Errorf("failed to load locked principals: %w", err)
+	}
+	var lockedPrincipalsCid cbg.CborCid
+	return lockedPrincipalMap.ForEach(&lockedPrincipalsCid, func(key string) error {
+		staker, err := addr.NewFromBytes([]byte(key))
+		if err != nil {
+			return err
+		}
+		return st.enqueueStaker(queue, epoch, staker)
+	})
+}
+
+// Removes and returns the distinct stakers queued at any epoch from FirstEventEpoch up to and including currEpoch.
+func (st *State) popDueStakers(queue *adt.Multimap, currEpoch abi.ChainEpoch) ([]addr.Address, error) {
+	var stakers []addr.Address
+	seen := make(map[addr.Address]struct{})
+	for epoch := st.FirstEventEpoch; epoch <= currEpoch; epoch++ {
+		var staker addr.Address
+		found := false
+		err := queue.ForEach(epochKey(epoch), &staker, func(i int64) error {
+			found = true
+			if _, ok := seen[staker]; !ok {
+				seen[staker] = struct{}{}
+				stakers = append(stakers, staker)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, xerrors.Errorf("failed to load stakers queued at %v: %w", epoch, err)
+		}
+		if found {
+			if err = queue.RemoveAll(epochKey(epoch)); err != nil {
+				return nil, xerrors.Errorf("failed to clear stakers queued at %v: %w", epoch, err)
+			}
+		}
+	}
+	st.FirstEventEpoch = currEpoch + 1
+	return stakers, nil
+}
+
+// Returns the first epoch after currEpoch at which a locked principal matures or unlocks,
+// or a vesting fund vests, or false if there is no such epoch.
+func (st *State) nextStakerEventEpoch(lockedPrincipals *LockedPrincipals, vestingFunds *VestingFunds, currEpoch abi.ChainEpoch) (abi.ChainEpoch, bool) {
+	next := abi.ChainEpoch(0)
+	found := false
+	consider := func(epoch abi.ChainEpoch) {
+		if epoch > currEpoch && (!found || epoch < next) {
+			next = epoch
+			found = true
+		}
+	}
+	// Principal matures and unlocks, and funds vest, at the first tick strictly after their recorded epochs.
+	if lockedPrincipals != nil {
+		for _, lp := range lockedPrincipals.Data {
+			consider(lp.Epoch + st.MaturePeriod + 1)
+			consider(lp.Epoch + st.PrincipalLockDuration + 1)
+		}
+	}
+	if vestingFunds != nil {
+		// Vesting funds are sorted by epoch, so the first one still vesting is the earliest.
+		for _, vf := range vestingFunds.Funds {
+			if vf.Epoch+1 > currEpoch {
+				consider(vf.Epoch + 1)
+				break
+			}
+		}
+	}
+	return next, found
+}
+
+func epochKey(e abi.ChainEpoch) abi.Keyer {
+	return abi.IntKey(int64(e))
+}
+
 func init() {
 	// Check that ChainEpoch is indeed a signed integer to confirm that epochKey is making the right interpretation.
 	var e abi.ChainEpoch
@@ -7,9 +7,11 @@ import (
 	"github.com/filecoin-project/go-state-types/exitcode"
 
 	"github.com/stretchr/testify/assert"
+	"strings"
 	"testing"
 
 	"github.com/filecoin-project/specs-actors/v3/actors/builtin"
+	"github.com/filecoin-project/specs-actors/v3/actors/builtin/miner"
 	"github.com/filecoin-project/specs-actors/v3/actors/builtin/stake"
 	"github.com/filecoin-project/specs-actors/v3/actors/util/adt"
 	"github.com/filecoin-project/specs-actors/v3/support/mock"
@@ -55,6 +57,143 @@ func TestConstructor(t *testing.T) {
 		assert.Equal(t, abi.NewTokenAmount(100_000_000_000), st.MaxRewardPerRound)
 		assert.Equal(t, big.NewInt(100), st.InflationFactor)
 		assert.Equal(t, big.Zero(), st.LastRoundReward)
+		assert.Equal(t, stake.RewardCurveLinear, st.RewardCurve.Curve)
+	})
+
+	t.Run("genesis selects the reward curve", func(t *testing.T) {
+		rt := mock.NewBuilder(builtin.StakeActorAddr).Build(t)
+		params := defaultConstructorParams(admin, abi.NewTokenAmount(100_000_000))
+		curve := stake.RewardCurveParams{
+			Curve:                 stake.RewardCurveExponentialDecay,
+			InitialRewardPerRound: abi.NewTokenAmount(1_000_000),
+			HalfLife:              abi.ChainEpoch(40),
+			TargetStakeRatio:      big.Zero(),
+			BudgetPerRound:        big.Zero(),
+		}
+		st, err := stake.ConstructState(rt.AdtStore(), params, &curve)
+		assert.NoError(t, err)
+		assert.Equal(t, curve, st.RewardCurve)
+
+		st, err = stake.ConstructState(rt.AdtStore(), params, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, stake.LinearRewardCurve(), st.RewardCurve)
+
+		_, err = stake.ConstructState(rt.AdtStore(), params, &stake.RewardCurveParams{Curve: stake.RewardCurveExponentialDecay})
+		assert.Error(t, err)
+	})
+}
+
+func TestRewardCurves(t *testing.T) {
+	actor := stakeHarness{stake.Actor{}, t}
+	admin := tutil.NewIDAddr(t, 100)
+	staker1 := tutil.NewIDAddr(t, 101)
+	staker2 := tutil.NewIDAddr(t, 102)
+
+	// stakers with 100 and 200 of power take part from the round at epoch 23
+	setup := func(t *testing.T, curve stake.RewardCurveParams) *mock.Runtime {
+		rt := actor.setupActor(t, defaultConstructorParams(admin, abi.NewTokenAmount(100_000_000)), abi.ChainEpoch(0))
+		actor.setProposalDelay(rt, abi.ChainEpoch(0))
+		actor.changeRewardCurve(rt, abi.ChainEpoch(1), admin, curve)
+		actor.deposit(rt, abi.ChainEpoch(1), staker1, abi.NewTokenAmount(100_000_000))
+		actor.deposit(rt, abi.ChainEpoch(1), staker2, abi.NewTokenAmount(200_000_000))
+		actor.tickThrough(rt, abi.ChainEpoch(1), abi.ChainEpoch(22))
+		assert.Equal(t, abi.NewStakePower(300_000_000), getState(rt).TotalStakePower)
+		return rt
+	}
+
+	vestingTotal := func(t *testing.T, rt *mock.Runtime, staker addr.Address) abi.TokenAmount {
+		total := big.Zero()
+		for _, vf := range actor.getStakerInfo(rt, staker).VestingFunds {
+			total = big.Add(total, vf.Amount)
+		}
+		return total
+	}
+
+	t.Run("only valid curves are proposed", func(t *testing.T) {
+		rt := actor.setupActor(t, defaultConstructorParams(admin, abi.NewTokenAmount(100_000_000)), abi.ChainEpoch(0))
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "invalid half life", func() {
+			actor.changeRewardCurve(rt, abi.ChainEpoch(1), admin, stake.RewardCurveParams{
+				Curve:                 stake.RewardCurveExponentialDecay,
+				InitialRewardPerRound: abi.NewTokenAmount(1_000_000),
+			})
+		})
+		rt.Reset()
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "invalid target stake ratio", func() {
+			actor.changeRewardCurve(rt, abi.ChainEpoch(1), admin, stake.RewardCurveParams{Curve: stake.RewardCurveTargetRatio, TargetStakeRatio: big.NewInt(10_001)})
+		})
+		rt.Reset()
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "unknown reward curve", func() {
+			actor.changeRewardCurve(rt, abi.ChainEpoch(1), admin, stake.RewardCurveParams{Curve: stake.RewardCurve(4)})
+		})
+		rt.Reset()
+		rt.ExpectAbortContainsMessage(exitcode.SysErrForbidden, "forbidden", func() {
+			actor.changeRewardCurve(rt, abi.ChainEpoch(1), staker1, stake.LinearRewardCurve())
+		})
+		rt.Reset()
+		// the caller is checked before the curve
+		rt.ExpectAbortContainsMessage(exitcode.SysErrForbidden, "forbidden", func() {
+			actor.changeRewardCurve(rt, abi.ChainEpoch(1), staker1, stake.RewardCurveParams{Curve: stake.RewardCurve(4)})
+		})
+		rt.Reset()
+		assert.Empty(t, actor.getProposals(rt))
+	})
+
+	t.Run("linear", func(t *testing.T) {
+		rt := setup(t, stake.LinearRewardCurve())
+		actor.onEpochTickEnd(rt, abi.ChainEpoch(23))
+		assert.Equal(t, abi.NewTokenAmount(3_000_000), getState(rt).LastRoundReward)
+	})
+
+	t.Run("exponential decay", func(t *testing.T) {
+		rt := setup(t, stake.RewardCurveParams{
+			Curve:                 stake.RewardCurveExponentialDecay,
+			InitialRewardPerRound: abi.NewTokenAmount(1_000_000),
+			HalfLife:              abi.ChainEpoch(40),
+		})
+
+		// half a half life after the stake period start
+		actor.onEpochTickEnd(rt, abi.ChainEpoch(23))
+		assert.Equal(t, abi.NewTokenAmount(707_106), getState(rt).LastRoundReward)
+
+		actor.tickThrough(rt, abi.ChainEpoch(24), abi.ChainEpoch(43))
+		assert.Equal(t, abi.NewTokenAmount(500_000), getState(rt).LastRoundReward)
+
+		actor.tickThrough(rt, abi.ChainEpoch(44), abi.ChainEpoch(83))
+		assert.Equal(t, abi.NewTokenAmount(250_000), getState(rt).LastRoundReward)
+	})
+
+	t.Run("target ratio", func(t *testing.T) {
+		rt := setup(t, stake.RewardCurveParams{
+			Curve:            stake.RewardCurveTargetRatio,
+			TargetStakeRatio: big.NewInt(5_000),
+		})
+
+		// 1% of the half of circulating supply expected to be staked, whatever is staked
+		rt.SetCirculatingSupply(abi.NewTokenAmount(10_000_000_000))
+		actor.onEpochTickEnd(rt, abi.ChainEpoch(23))
+		assert.Equal(t, abi.NewTokenAmount(50_000_000), getState(rt).LastRoundReward)
+
+		rt.SetCirculatingSupply(abi.NewTokenAmount(20_000_000_000))
+		actor.tickThrough(rt, abi.ChainEpoch(24), abi.ChainEpoch(43))
+		assert.Equal(t, abi.NewTokenAmount(100_000_000), getState(rt).LastRoundReward)
+	})
+
+	t.Run("fixed budget is split pro rata and capped", func(t *testing.T) {
+		rt := setup(t, stake.RewardCurveParams{
+			Curve:          stake.RewardCurveFixedBudget,
+			BudgetPerRound: abi.NewTokenAmount(3_000_000),
+		})
+		actor.onEpochTickEnd(rt, abi.ChainEpoch(23))
+		assert.Equal(t, abi.NewTokenAmount(3_000_000), getState(rt).LastRoundReward)
+		assert.Equal(t, abi.NewTokenAmount(1_000_000), vestingTotal(t, rt, staker1))
+		assert.Equal(t, abi.NewTokenAmount(2_000_000), vestingTotal(t, rt, staker2))
+
+		st := getState(rt)
+		st.MaxRewardPerRound = abi.NewTokenAmount(1_500_000)
+		rt.ReplaceState(st)
+		actor.tickThrough(rt, abi.ChainEpoch(24), abi.ChainEpoch(43))
+		assert.Equal(t, abi.NewTokenAmount(1_500_000), getState(rt).LastRoundReward)
+		actor.checkStateWithBalance(rt, abi.NewTokenAmount(1_000_000_000))
 	})
 }
 
@@ -356,6 +495,797 @@ func TestStake(t *testing.T) {
 		assert.Equal(t, abi.NewTokenAmount(100_000_000), sp1)
 	})
 
+	t.Run("event queue", func(t *testing.T) {
+		rt := actor.setupActor(t, defaultConstructorParams(admin, abi.NewTokenAmount(100_000_000)), abi.ChainEpoch(3))
+
+		// the deposit is queued for the epoch after it matures
+		actor.deposit(rt, abi.ChainEpoch(4), staker1, abi.NewTokenAmount(100_000_000))
+		actor.deposit(rt, abi.ChainEpoch(4), staker1, abi.NewTokenAmount(100_000_000))
+		assert.Equal(t, []addr.Address{staker1}, queuedStakers(t, rt, abi.ChainEpoch(15)))
+
+		actor.tickThrough(rt, abi.ChainEpoch(4), abi.ChainEpoch(14))
+		st := getState(rt)
+		assert.Equal(t, abi.NewStakePower(0), st.TotalStakePower)
+		assert.Equal(t, abi.ChainEpoch(15), st.FirstEventEpoch)
+
+		// once matured the staker is queued again for when its principal unlocks
+		actor.onEpochTickEnd(rt, abi.ChainEpoch(15))
+		st = getState(rt)
+		assert.Equal(t, abi.NewStakePower(200_000_000), st.TotalStakePower)
+		assert.Empty(t, queuedStakers(t, rt, abi.ChainEpoch(15)))
+		assert.Equal(t, []addr.Address{staker1}, queuedStakers(t, rt, abi.ChainEpoch(35)))
+
+		// changing the mature period re-evaluates every staker when the change takes effect
+		actor.setProposalDelay(rt, abi.ChainEpoch(1))
+		actor.deposit(rt, abi.ChainEpoch(16), staker2, abi.NewTokenAmount(100_000_000))
+		assert.Equal(t, []addr.Address{staker2}, queuedStakers(t, rt, abi.ChainEpoch(27)))
+		actor.changeMaturePeriod(rt, abi.ChainEpoch(16), admin, abi.ChainEpoch(5))
+		actor.onEpochTickEnd(rt, abi.ChainEpoch(16))
+		assert.Equal(t, abi.ChainEpoch(10), getState(rt).MaturePeriod)
+		actor.onEpochTickEnd(rt, abi.ChainEpoch(17))
+		assert.Equal(t, abi.ChainEpoch(5), getState(rt).MaturePeriod)
+		assert.Equal(t, []addr.Address{staker2}, queuedStakers(t, rt, abi.ChainEpoch(22)))
+
+		actor.tickThrough(rt, abi.ChainEpoch(18), abi.ChainEpoch(21))
+		assert.Equal(t, abi.NewStakePower(200_000_000), getState(rt).TotalStakePower)
+		actor.onEpochTickEnd(rt, abi.ChainEpoch(22))
+		assert.Equal(t, abi.NewStakePower(300_000_000), getState(rt).TotalStakePower)
+
+		rt.SetBalance(abi.NewTokenAmount(300_000_000))
+		actor.checkState(rt)
+	})
+}
+
+func TestDelegation(t *testing.T) {
+	actor := stakeHarness{stake.Actor{}, t}
+	admin := tutil.NewIDAddr(t, 100)
+	staker := tutil.NewIDAddr(t, 101)
+	depositor := tutil.NewIDAddr(t, 102)
+	receiver := tutil.NewIDAddr(t, 103)
+	amount := abi.NewTokenAmount(100_000_000)
+
+	setup := func(t *testing.T) *mock.Runtime {
+		return actor.setupActor(t, defaultConstructorParams(admin, amount), abi.ChainEpoch(3))
+	}
+
+	t.Run("rewards vest for the reward receiver", func(t *testing.T) {
+		rt := setup(t)
+		actor.depositFor(rt, abi.ChainEpoch(4), depositor, amount, &stake.DepositForParams{
+			Beneficiary:    staker,
+			RewardReceiver: receiver,
+			WithdrawPolicy: stake.WithdrawPolicyDepositor,
+		})
+		actor.tickThrough(rt, abi.ChainEpoch(4), abi.ChainEpoch(23))
+
+		st := getState(rt)
+		assert.Equal(t, abi.NewTokenAmount(1_000_000), st.LastRoundReward)
+		vestingRewardMap, err := adt.AsMap(rt.AdtStore(), st.VestingRewardMap, builtin.DefaultHamtBitwidth)
+		assert.NoError(t, err)
+		_, found, err := st.LoadVestingFunds(rt.AdtStore(), vestingRewardMap, staker)
+		assert.NoError(t, err)
+		assert.False(t, found)
+		vestingFunds, found, err := st.LoadVestingFunds(rt.AdtStore(), vestingRewardMap, receiver)
+		assert.NoError(t, err)
+		assert.True(t, found)
+		total := big.Zero()
+		for _, vf := range vestingFunds.Funds {
+			total = big.Add(total, vf.Amount)
+		}
+		assert.Equal(t, abi.NewTokenAmount(1_000_000), total)
+
+		rt.SetBalance(big.Add(amount, total))
+		summary := actor.checkState(rt)
+		assert.Equal(t, amount, summary.StakePowers[staker])
+	})
+
+	t.Run("delegated principal only accepts deposits from its depositor", func(t *testing.T) {
+		rt := setup(t)
+		params := &stake.DepositForParams{Beneficiary: staker, RewardReceiver: staker, WithdrawPolicy: stake.WithdrawPolicyStaker}
+		actor.depositFor(rt, abi.ChainEpoch(4), depositor, amount, params)
+		actor.depositFor(rt, abi.ChainEpoch(5), depositor, amount, params)
+
+		rt.ExpectAbortContainsMessage(exitcode.ErrForbidden, "may only be added through DepositFor", func() {
+			actor.deposit(rt, abi.ChainEpoch(5), staker, amount)
+		})
+		rt.ExpectAbortContainsMessage(exitcode.ErrForbidden, "is deposited by", func() {
+			actor.depositFor(rt, abi.ChainEpoch(5), receiver, amount, params)
+		})
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "already exists with reward receiver", func() {
+			actor.depositFor(rt, abi.ChainEpoch(5), depositor, amount, &stake.DepositForParams{
+				Beneficiary: staker, RewardReceiver: receiver, WithdrawPolicy: stake.WithdrawPolicyStaker,
+			})
+		})
+
+		// an address holding principal of its own cannot receive delegated principal
+		actor.deposit(rt, abi.ChainEpoch(5), receiver, amount)
+		rt.ExpectAbortContainsMessage(exitcode.ErrForbidden, "already holds principal", func() {
+			actor.depositFor(rt, abi.ChainEpoch(5), depositor, amount, &stake.DepositForParams{
+				Beneficiary: receiver, RewardReceiver: receiver, WithdrawPolicy: stake.WithdrawPolicyStaker,
+			})
+		})
+	})
+
+	t.Run("depositor withdraws under depositor policy", func(t *testing.T) {
+		rt := setup(t)
+		actor.depositFor(rt, abi.ChainEpoch(4), depositor, amount, &stake.DepositForParams{
+			Beneficiary:    staker,
+			RewardReceiver: staker,
+			WithdrawPolicy: stake.WithdrawPolicyDepositor,
+		})
+		actor.tickThrough(rt, abi.ChainEpoch(4), abi.ChainEpoch(35))
+
+		rt.ExpectAbortContainsMessage(exitcode.ErrForbidden, "only be withdrawn through WithdrawDelegatedPrincipal", func() {
+			actor.withdrawPrincipal(rt, abi.ChainEpoch(36), staker, amount)
+		})
+		rt.Reset()
+		rt.ExpectAbortContainsMessage(exitcode.ErrForbidden, "only depositor", func() {
+			actor.withdrawDelegatedPrincipal(rt, abi.ChainEpoch(36), staker, staker, amount, addr.Undef)
+		})
+		actor.withdrawDelegatedPrincipal(rt, abi.ChainEpoch(36), depositor, staker, amount, depositor)
+		assert.Equal(t, big.Zero(), getState(rt).TotalStakePower)
+	})
+
+	t.Run("joint policy requires both parties", func(t *testing.T) {
+		rt := setup(t)
+		actor.depositFor(rt, abi.ChainEpoch(4), depositor, amount, &stake.DepositForParams{
+			Beneficiary:    staker,
+			RewardReceiver: staker,
+			WithdrawPolicy: stake.WithdrawPolicyJoint,
+		})
+		actor.tickThrough(rt, abi.ChainEpoch(4), abi.ChainEpoch(35))
+
+		// the staker's request is recorded, and repeating it does not complete the withdrawal
+		actor.withdrawDelegatedPrincipal(rt, abi.ChainEpoch(36), staker, staker, amount, addr.Undef)
+		actor.withdrawDelegatedPrincipal(rt, abi.ChainEpoch(36), staker, staker, amount, addr.Undef)
+		rt.ExpectAbortContainsMessage(exitcode.ErrForbidden, "only depositor", func() {
+			actor.withdrawDelegatedPrincipal(rt, abi.ChainEpoch(36), receiver, staker, amount, addr.Undef)
+		})
+		assert.Equal(t, amount, getState(rt).TotalStakePower)
+
+		// the depositor approving the same amount withdraws it to the depositor
+		actor.withdrawDelegatedPrincipal(rt, abi.ChainEpoch(36), depositor, staker, amount, depositor)
+		st := getState(rt)
+		assert.Equal(t, big.Zero(), st.TotalStakePower)
+		delegations, err := adt.AsMap(rt.AdtStore(), st.Delegations, builtin.DefaultHamtBitwidth)
+		assert.NoError(t, err)
+		delegation, found, err := st.LoadDelegation(delegations, staker)
+		assert.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, big.Zero(), delegation.PendingWithdrawal)
+	})
+}
+
+func TestRestake(t *testing.T) {
+	actor := stakeHarness{stake.Actor{}, t}
+	admin := tutil.NewIDAddr(t, 100)
+	staker := tutil.NewIDAddr(t, 101)
+	depositor := tutil.NewIDAddr(t, 102)
+	amount := abi.NewTokenAmount(100_000_000)
+	// rewards are earned from epoch 23 and first vest at epoch 4323
+	firstVestedEpoch := abi.ChainEpoch(4324)
+
+	setup := func(t *testing.T) *mock.Runtime {
+		return actor.setupActor(t, defaultConstructorParams(admin, abi.NewTokenAmount(1)), abi.ChainEpoch(3))
+	}
+
+	t.Run("restake available reward", func(t *testing.T) {
+		rt := setup(t)
+		actor.deposit(rt, abi.ChainEpoch(4), staker, amount)
+		for epoch := 4; epoch <= int(firstVestedEpoch); epoch += 1 {
+			actor.onEpochTickEnd(rt, abi.ChainEpoch(epoch))
+		}
+		summary := actor.checkStateWithBalance(rt, abi.NewTokenAmount(1_000_000_000_000))
+		reward := summary.TotalAvailableReward
+		assert.True(t, reward.GreaterThan(big.Zero()))
+
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalState, "available reward cannot be negative", func() {
+			actor.restakeReward(rt, firstVestedEpoch+1, staker, big.Add(reward, big.NewInt(1)))
+		})
+		actor.restakeReward(rt, firstVestedEpoch+1, staker, reward)
+		summary = actor.checkStateWithBalance(rt, abi.NewTokenAmount(1_000_000_000_000))
+		assert.Equal(t, big.Zero(), summary.TotalAvailableReward)
+		assert.Equal(t, big.Add(amount, reward), big.Add(summary.TotalLockedPrincipal, summary.TotalAvailablePrincipal))
+		assert.Equal(t, amount, summary.TotalStakePower)
+
+		// the restaked reward earns power once it matures
+		for epoch := firstVestedEpoch + 1; epoch <= firstVestedEpoch+12; epoch += 1 {
+			actor.onEpochTickEnd(rt, epoch)
+		}
+		assert.Equal(t, big.Add(amount, reward), getState(rt).TotalStakePower)
+	})
+
+	t.Run("auto compound restakes vested reward", func(t *testing.T) {
+		rt := setup(t)
+		actor.deposit(rt, abi.ChainEpoch(4), staker, amount)
+		actor.setAutoCompound(rt, abi.ChainEpoch(4), staker, true)
+		for epoch := 4; epoch <= int(firstVestedEpoch); epoch += 1 {
+			actor.onEpochTickEnd(rt, abi.ChainEpoch(epoch))
+		}
+
+		st := getState(rt)
+		summary := actor.checkStateWithBalance(rt, abi.NewTokenAmount(1_000_000_000_000))
+		assert.Equal(t, big.Zero(), summary.TotalAvailableReward)
+		lockedPrincipalMap, err := adt.AsMap(rt.AdtStore(), st.LockedPrincipalMap, builtin.DefaultHamtBitwidth)
+		assert.NoError(t, err)
+		lockedPrincipals, found, err := st.LoadLockedPrincipals(rt.AdtStore(), lockedPrincipalMap, staker)
+		assert.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, 1, len(lockedPrincipals.Data))
+		assert.Equal(t, firstVestedEpoch, lockedPrincipals.Data[0].Epoch)
+		compounded := lockedPrincipals.Data[0].Amount
+		assert.True(t, compounded.GreaterThan(big.Zero()))
+
+		for epoch := firstVestedEpoch + 1; epoch <= firstVestedEpoch+11; epoch += 1 {
+			actor.onEpochTickEnd(rt, epoch)
+		}
+		assert.Equal(t, big.Add(amount, compounded), getState(rt).TotalStakePower)
+
+		// disabling auto compound makes later vested reward available again
+		actor.setAutoCompound(rt, firstVestedEpoch+12, staker, false)
+		for epoch := firstVestedEpoch + 12; epoch <= firstVestedEpoch+builtin.EpochsInDay/2; epoch += 1 {
+			actor.onEpochTickEnd(rt, epoch)
+		}
+		summary = actor.checkStateWithBalance(rt, abi.NewTokenAmount(1_000_000_000_000))
+		assert.True(t, summary.TotalAvailableReward.GreaterThan(big.Zero()))
+	})
+
+	t.Run("delegated staker cannot restake", func(t *testing.T) {
+		rt := setup(t)
+		actor.depositFor(rt, abi.ChainEpoch(4), depositor, amount, &stake.DepositForParams{
+			Beneficiary:    staker,
+			RewardReceiver: staker,
+			WithdrawPolicy: stake.WithdrawPolicyStaker,
+		})
+		rt.ExpectAbortContainsMessage(exitcode.ErrForbidden, "may only be added through DepositFor", func() {
+			actor.setAutoCompound(rt, abi.ChainEpoch(4), staker, true)
+		})
+		rt.ExpectAbortContainsMessage(exitcode.ErrForbidden, "may only be added through DepositFor", func() {
+			actor.restakeReward(rt, abi.ChainEpoch(4), staker, amount)
+		})
+	})
+
+	t.Run("auto compound staker cannot take delegated principal", func(t *testing.T) {
+		rt := setup(t)
+		actor.setAutoCompound(rt, abi.ChainEpoch(4), staker, true)
+		rt.ExpectAbortContainsMessage(exitcode.ErrForbidden, "auto compounds its rewards", func() {
+			actor.depositFor(rt, abi.ChainEpoch(4), depositor, amount, &stake.DepositForParams{
+				Beneficiary:    staker,
+				RewardReceiver: staker,
+				WithdrawPolicy: stake.WithdrawPolicyDepositor,
+			})
+		})
+
+		// vested rewards are compounded into the staker's own principal only
+		actor.deposit(rt, abi.ChainEpoch(4), staker, amount)
+		for epoch := 4; epoch <= int(firstVestedEpoch); epoch += 1 {
+			actor.onEpochTickEnd(rt, abi.ChainEpoch(epoch))
+		}
+		summary := actor.checkStateWithBalance(rt, abi.NewTokenAmount(1_000_000_000_000))
+		assert.Equal(t, big.Zero(), summary.TotalAvailableReward)
+		assert.True(t, summary.TotalLockedPrincipal.GreaterThan(big.Zero()))
+	})
+}
+
+func TestSlash(t *testing.T) {
+	actor := stakeHarness{stake.Actor{}, t}
+	admin := tutil.NewIDAddr(t, 100)
+	staker := tutil.NewIDAddr(t, 101)
+	slasher := tutil.NewIDAddr(t, 102)
+	amount := abi.NewTokenAmount(100_000_000)
+
+	rt := actor.setupActor(t, defaultConstructorParams(admin, amount), abi.ChainEpoch(3))
+	actor.setProposalDelay(rt, abi.ChainEpoch(0))
+
+	// the first deposit has unlocked by epoch 40, when the second is locked
+	actor.deposit(rt, abi.ChainEpoch(4), staker, amount)
+	actor.tickThrough(rt, abi.ChainEpoch(4), abi.ChainEpoch(39))
+	actor.deposit(rt, abi.ChainEpoch(40), staker, amount)
+	actor.tickThrough(rt, abi.ChainEpoch(40), abi.ChainEpoch(45))
+
+	rt.ExpectAbortContainsMessage(exitcode.ErrForbidden, "is not a slasher", func() {
+		actor.slash(rt, abi.ChainEpoch(45), slasher, staker, amount, big.Zero())
+	})
+	rt.ExpectAbortContainsMessage(exitcode.SysErrForbidden, "forbidden", func() {
+		actor.addSlasher(rt, staker, slasher)
+	})
+	// the slasher is only added once the proposal activates
+	actor.addSlasher(rt, admin, slasher)
+	rt.ExpectAbortContainsMessage(exitcode.ErrForbidden, "is not a slasher", func() {
+		actor.slash(rt, abi.ChainEpoch(45), slasher, staker, amount, big.Zero())
+	})
+	actor.onEpochTickEnd(rt, abi.ChainEpoch(46))
+
+	summary := actor.checkStateWithBalance(rt, abi.NewTokenAmount(1_000_000_000))
+	assert.Equal(t, amount, summary.TotalLockedPrincipal)
+	assert.Equal(t, amount, summary.TotalAvailablePrincipal)
+	assert.Equal(t, amount, summary.TotalStakePower)
+	vesting := summary.TotalVestingReward
+	assert.True(t, vesting.GreaterThan(big.Zero()))
+
+	// half of the available principal is unbonding
+	half := big.Div(amount, big.NewInt(2))
+	quarter := big.Div(amount, big.NewInt(4))
+	actor.withdrawPrincipal(rt, abi.ChainEpoch(46), staker, half)
+
+	// locked principal is taken first, then available principal
+	actor.slash(rt, abi.ChainEpoch(46), slasher, staker, big.Add(amount, quarter), big.Add(amount, quarter))
+	summary = actor.checkStateWithBalance(rt, abi.NewTokenAmount(1_000_000_000))
+	assert.Equal(t, big.Zero(), summary.TotalLockedPrincipal)
+	assert.Equal(t, quarter, summary.TotalAvailablePrincipal)
+	assert.Equal(t, half, summary.TotalUnbondingPrincipal)
+	assert.Equal(t, quarter, summary.TotalStakePower)
+	assert.Equal(t, vesting, summary.TotalVestingReward)
+
+	// then unbonding principal, before any vesting rewards
+	actor.slash(rt, abi.ChainEpoch(46), slasher, staker, half, half)
+	summary = actor.checkStateWithBalance(rt, abi.NewTokenAmount(1_000_000_000))
+	assert.Equal(t, big.Zero(), summary.TotalAvailablePrincipal)
+	assert.Equal(t, quarter, summary.TotalUnbondingPrincipal)
+	assert.Equal(t, vesting, summary.TotalVestingReward)
+
+	// slashing more than the staker holds takes its vesting rewards and everything else
+	actor.slash(rt, abi.ChainEpoch(46), slasher, staker, big.Mul(amount, big.NewInt(10)), big.Add(quarter, vesting))
+	summary = actor.checkStateWithBalance(rt, abi.NewTokenAmount(1_000_000_000))
+	assert.Equal(t, big.Zero(), summary.TotalAvailablePrincipal)
+	assert.Equal(t, big.Zero(), summary.TotalUnbondingPrincipal)
+	assert.Equal(t, big.Zero(), summary.TotalVestingReward)
+	assert.Equal(t, big.Zero(), summary.TotalStakePower)
+	assert.Empty(t, actor.unbondings(rt, staker))
+
+	// nothing is left to burn
+	actor.slash(rt, abi.ChainEpoch(46), slasher, staker, amount, big.Zero())
+
+	actor.removeSlasher(rt, admin, slasher)
+	actor.onEpochTickEnd(rt, abi.ChainEpoch(47))
+	rt.ExpectAbortContainsMessage(exitcode.ErrNotFound, "is not a slasher", func() {
+		actor.removeSlasher(rt, admin, slasher)
+	})
+	rt.ExpectAbortContainsMessage(exitcode.ErrForbidden, "is not a slasher", func() {
+		actor.slash(rt, abi.ChainEpoch(47), slasher, staker, amount, big.Zero())
+	})
+}
+
+func TestUnbonding(t *testing.T) {
+	actor := stakeHarness{stake.Actor{}, t}
+	admin := tutil.NewIDAddr(t, 100)
+	staker := tutil.NewIDAddr(t, 101)
+	depositor := tutil.NewIDAddr(t, 102)
+	delegated := tutil.NewIDAddr(t, 103)
+	slasher := tutil.NewIDAddr(t, 104)
+	amount := abi.NewTokenAmount(100_000_000)
+
+	hasDelegation := func(rt *mock.Runtime, staker addr.Address) bool {
+		st := getState(rt)
+		delegations, err := adt.AsMap(rt.AdtStore(), st.Delegations, builtin.DefaultHamtBitwidth)
+		assert.NoError(t, err)
+		_, found, err := st.LoadDelegation(delegations, staker)
+		assert.NoError(t, err)
+		return found
+	}
+
+	setup := func(t *testing.T) *mock.Runtime {
+		rt := actor.setupActor(t, defaultConstructorParams(admin, amount), abi.ChainEpoch(0))
+		actor.setProposalDelay(rt, abi.ChainEpoch(0))
+		actor.changeUnbondingPeriod(rt, admin, abi.ChainEpoch(10))
+		actor.tickThrough(rt, abi.ChainEpoch(1), abi.ChainEpoch(3))
+		actor.deposit(rt, abi.ChainEpoch(4), staker, amount)
+		actor.tickThrough(rt, abi.ChainEpoch(4), abi.ChainEpoch(35))
+		return rt
+	}
+
+	t.Run("withdrawn principal is claimable after the unbonding period", func(t *testing.T) {
+		rt := setup(t)
+		half := big.Div(amount, big.NewInt(2))
+		actor.withdrawPrincipal(rt, abi.ChainEpoch(36), staker, half)
+		assert.Equal(t, half, getState(rt).TotalStakePower)
+
+		// a longer unbonding period applies only to later withdrawals
+		actor.changeUnbondingPeriod(rt, admin, abi.ChainEpoch(20))
+		actor.onEpochTickEnd(rt, abi.ChainEpoch(36))
+		actor.withdrawPrincipal(rt, abi.ChainEpoch(37), staker, half)
+		assert.Equal(t, big.Zero(), getState(rt).TotalStakePower)
+		assert.Equal(t, []stake.Unbonding{
+			{Amount: half, ReleaseEpoch: abi.ChainEpoch(46), Recipient: staker},
+			{Amount: half, ReleaseEpoch: abi.ChainEpoch(57), Recipient: staker},
+		}, actor.getStakerInfo(rt, staker).Unbondings)
+
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "is released", func() {
+			actor.claimUnbonded(rt, abi.ChainEpoch(45), staker, staker)
+		})
+		rt.Reset()
+		actor.claimUnbonded(rt, abi.ChainEpoch(46), depositor, staker, stake.Unbonding{Amount: half, Recipient: staker})
+		summary := actor.checkStateWithBalance(rt, abi.NewTokenAmount(1_000_000_000))
+		assert.Equal(t, half, summary.TotalUnbondingPrincipal)
+
+		actor.claimUnbonded(rt, abi.ChainEpoch(60), staker, staker, stake.Unbonding{Amount: half, Recipient: staker})
+		assert.Empty(t, actor.unbondings(rt, staker))
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "no unbonding principal", func() {
+			actor.claimUnbonded(rt, abi.ChainEpoch(60), staker, staker)
+		})
+	})
+
+	t.Run("delegated principal is claimed by the withdraw recipient", func(t *testing.T) {
+		rt := setup(t)
+		actor.depositFor(rt, abi.ChainEpoch(36), depositor, amount, &stake.DepositForParams{
+			Beneficiary:    delegated,
+			RewardReceiver: delegated,
+			WithdrawPolicy: stake.WithdrawPolicyDepositor,
+		})
+		actor.tickThrough(rt, abi.ChainEpoch(36), abi.ChainEpoch(67))
+		actor.withdrawDelegatedPrincipal(rt, abi.ChainEpoch(68), depositor, delegated, amount, depositor)
+		assert.True(t, hasDelegation(rt, delegated))
+		actor.claimUnbonded(rt, abi.ChainEpoch(78), delegated, delegated, stake.Unbonding{Amount: amount, Recipient: depositor})
+
+		// with none of the delegated principal left, the delegation is released
+		assert.False(t, hasDelegation(rt, delegated))
+		actor.deposit(rt, abi.ChainEpoch(78), delegated, amount)
+	})
+
+	t.Run("beneficiary stakes on its own once no delegated principal is staked", func(t *testing.T) {
+		rt := setup(t)
+		actor.depositFor(rt, abi.ChainEpoch(36), depositor, amount, &stake.DepositForParams{
+			Beneficiary:    delegated,
+			RewardReceiver: delegated,
+			WithdrawPolicy: stake.WithdrawPolicyDepositor,
+		})
+		actor.tickThrough(rt, abi.ChainEpoch(36), abi.ChainEpoch(67))
+		actor.withdrawDelegatedPrincipal(rt, abi.ChainEpoch(68), depositor, delegated, amount, depositor)
+
+		// the unbonding principal is still paid to the depositor, who no longer controls the beneficiary's principal
+		actor.deposit(rt, abi.ChainEpoch(68), delegated, amount)
+		assert.False(t, hasDelegation(rt, delegated))
+		rt.ExpectAbortContainsMessage(exitcode.ErrNotFound, "no delegated principal", func() {
+			actor.withdrawDelegatedPrincipal(rt, abi.ChainEpoch(68), depositor, delegated, amount, addr.Undef)
+		})
+		actor.claimUnbonded(rt, abi.ChainEpoch(78), delegated, delegated, stake.Unbonding{Amount: amount, Recipient: depositor})
+	})
+
+	t.Run("slashing takes unbonding principal before vesting rewards", func(t *testing.T) {
+		rt := setup(t)
+		actor.addSlasher(rt, admin, slasher)
+		actor.withdrawPrincipal(rt, abi.ChainEpoch(36), staker, amount)
+		actor.onEpochTickEnd(rt, abi.ChainEpoch(36))
+		summary := actor.checkStateWithBalance(rt, abi.NewTokenAmount(1_000_000_000))
+		vesting := summary.TotalVestingReward
+
+		quarter := big.Div(amount, big.NewInt(4))
+		actor.slash(rt, abi.ChainEpoch(37), slasher, staker, quarter, quarter)
+		summary = actor.checkStateWithBalance(rt, abi.NewTokenAmount(1_000_000_000))
+		assert.Equal(t, big.Sub(amount, quarter), summary.TotalUnbondingPrincipal)
+		assert.Equal(t, vesting, summary.TotalVestingReward)
+
+		actor.slash(rt, abi.ChainEpoch(37), slasher, staker, amount, big.Add(big.Sub(amount, quarter), vesting))
+		summary = actor.checkStateWithBalance(rt, abi.NewTokenAmount(1_000_000_000))
+		assert.Equal(t, big.Zero(), summary.TotalUnbondingPrincipal)
+		assert.Equal(t, big.Zero(), summary.TotalVestingReward)
+		assert.Empty(t, actor.unbondings(rt, staker))
+	})
+
+	t.Run("only the root key changes the unbonding period", func(t *testing.T) {
+		rt := setup(t)
+		rt.ExpectAbortContainsMessage(exitcode.SysErrForbidden, "forbidden", func() {
+			actor.changeUnbondingPeriod(rt, staker, abi.ChainEpoch(0))
+		})
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "invalid unbonding period", func() {
+			actor.changeUnbondingPeriod(rt, admin, abi.ChainEpoch(-1))
+		})
+	})
+}
+
+func TestProposals(t *testing.T) {
+	actor := stakeHarness{stake.Actor{}, t}
+	admin := tutil.NewIDAddr(t, 100)
+	other := tutil.NewIDAddr(t, 101)
+
+	setup := func(t *testing.T) *mock.Runtime {
+		return actor.setupActor(t, defaultConstructorParams(admin, abi.NewTokenAmount(100_000_000)), abi.ChainEpoch(0))
+	}
+
+	t.Run("changes take effect after the proposal delay", func(t *testing.T) {
+		rt := setup(t)
+		ret := actor.changeInflationFactor(rt, abi.ChainEpoch(5), admin, big.NewInt(200))
+		assert.Equal(t, stake.ProposalID(0), ret.ID)
+		assert.Equal(t, abi.ChainEpoch(5)+stake.DefaultProposalDelay, ret.ActivationEpoch)
+		assert.Equal(t, big.NewInt(100), getState(rt).InflationFactor)
+
+		proposals := actor.getProposals(rt)
+		assert.Equal(t, 1, len(proposals))
+		assert.Equal(t, ret.ID, proposals[0].ID)
+		assert.Equal(t, builtin.MethodsStake.ChangeInflationFactor, proposals[0].Proposal.Method)
+		assert.Equal(t, ret.ActivationEpoch, proposals[0].Proposal.ActivationEpoch)
+
+		actor.onEpochTickEnd(rt, ret.ActivationEpoch-1)
+		assert.Equal(t, big.NewInt(100), getState(rt).InflationFactor)
+		actor.onEpochTickEnd(rt, ret.ActivationEpoch)
+		assert.Equal(t, big.NewInt(200), getState(rt).InflationFactor)
+		assert.Empty(t, actor.getProposals(rt))
+		actor.checkState(rt)
+	})
+
+	t.Run("proposals may be cancelled before activation", func(t *testing.T) {
+		rt := setup(t)
+		first := actor.changeInflationFactor(rt, abi.ChainEpoch(5), admin, big.NewInt(200))
+		second := actor.changeMaturePeriod(rt, abi.ChainEpoch(5), admin, abi.ChainEpoch(20))
+		assert.Equal(t, stake.ProposalID(1), second.ID)
+
+		rt.ExpectAbortContainsMessage(exitcode.SysErrForbidden, "forbidden", func() {
+			actor.cancelProposal(rt, other, first.ID)
+		})
+		actor.cancelProposal(rt, admin, first.ID)
+		rt.ExpectAbortContainsMessage(exitcode.ErrNotFound, "no such proposal", func() {
+			actor.cancelProposal(rt, admin, first.ID)
+		})
+		proposals := actor.getProposals(rt)
+		assert.Equal(t, 1, len(proposals))
+		assert.Equal(t, second.ID, proposals[0].ID)
+
+		actor.onEpochTickEnd(rt, second.ActivationEpoch)
+		st := getState(rt)
+		assert.Equal(t, big.NewInt(100), st.InflationFactor)
+		assert.Equal(t, abi.ChainEpoch(20), st.MaturePeriod)
+	})
+
+	t.Run("a proposal that no longer fits the parameters is dropped", func(t *testing.T) {
+		rt := setup(t)
+		actor.changePrincipalLockDuration(rt, abi.ChainEpoch(5), admin, abi.ChainEpoch(15))
+		ret := actor.changeMaturePeriod(rt, abi.ChainEpoch(5), admin, abi.ChainEpoch(20))
+
+		actor.onEpochTickEnd(rt, ret.ActivationEpoch)
+		rt.ExpectLogsContain("mature period 20 cannot be greater than principal lock duration 15")
+		st := getState(rt)
+		assert.Equal(t, abi.ChainEpoch(15), st.PrincipalLockDuration)
+		assert.Equal(t, abi.ChainEpoch(10), st.MaturePeriod)
+		assert.Empty(t, actor.getProposals(rt))
+	})
+
+	t.Run("only the root key proposes changes", func(t *testing.T) {
+		rt := setup(t)
+		rt.ExpectAbortContainsMessage(exitcode.SysErrForbidden, "forbidden", func() {
+			actor.changeInflationFactor(rt, abi.ChainEpoch(5), other, big.NewInt(200))
+		})
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "invalid inflation factor", func() {
+			actor.changeInflationFactor(rt, abi.ChainEpoch(5), admin, big.NewInt(-1))
+		})
+		rt.Reset()
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "invalid proposal delay", func() {
+			actor.changeProposalDelay(rt, abi.ChainEpoch(5), admin, stake.MinProposalDelay-1)
+		})
+		rt.Reset()
+		assert.Empty(t, actor.getProposals(rt))
+	})
+}
+
+func TestRewardVestingSpec(t *testing.T) {
+	actor := stakeHarness{stake.Actor{}, t}
+	admin := tutil.NewIDAddr(t, 100)
+	staker := tutil.NewIDAddr(t, 101)
+
+	rt := actor.setupActor(t, defaultConstructorParams(admin, abi.NewTokenAmount(100_000_000)), abi.ChainEpoch(0))
+	actor.setProposalDelay(rt, abi.ChainEpoch(0))
+	actor.deposit(rt, abi.ChainEpoch(1), staker, abi.NewTokenAmount(100_000_000))
+	actor.tickThrough(rt, abi.ChainEpoch(1), abi.ChainEpoch(23))
+	assert.Equal(t, stake.DefaultRewardVestingSpec, actor.getStakeParams(rt).RewardVestingSpec)
+
+	spec := miner.VestSpec{
+		InitialDelay: abi.ChainEpoch(0),
+		VestPeriod:   abi.ChainEpoch(2 * builtin.EpochsInDay),
+		StepDuration: abi.ChainEpoch(builtin.EpochsInDay),
+		Quantization: abi.ChainEpoch(12 * builtin.EpochsInHour),
+	}
+	rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "invalid step duration", func() {
+		actor.changeRewardVestingSpec(rt, abi.ChainEpoch(24), admin, miner.VestSpec{Quantization: 1})
+	})
+	actor.changeRewardVestingSpec(rt, abi.ChainEpoch(24), admin, spec)
+	actor.tickThrough(rt, abi.ChainEpoch(24), abi.ChainEpoch(43))
+	assert.Equal(t, spec, actor.getStakeParams(rt).RewardVestingSpec)
+
+	// the new spec is keyed by the epoch it took effect at, while rewards of the first round keep vesting under the default spec
+	assert.Equal(t, uint64(24), getState(rt).CurrentRewardVestingSpec)
+	totals := map[uint64]abi.TokenAmount{0: big.Zero(), 24: big.Zero()}
+	counts := map[uint64]int{}
+	for _, vf := range actor.getStakerInfo(rt, staker).VestingFunds {
+		totals[vf.Spec] = big.Add(totals[vf.Spec], vf.Amount)
+		counts[vf.Spec]++
+	}
+	assert.Equal(t, 180, counts[0])
+	assert.Equal(t, 2, counts[24])
+	assert.Equal(t, abi.NewTokenAmount(1_000_000), totals[0])
+	assert.Equal(t, abi.NewTokenAmount(1_000_000), totals[24])
+
+	actor.checkStateWithBalance(rt, abi.NewTokenAmount(1_000_000_000))
+}
+
+func TestGetters(t *testing.T) {
+	actor := stakeHarness{stake.Actor{}, t}
+	admin := tutil.NewIDAddr(t, 100)
+	staker := tutil.NewIDAddr(t, 101)
+	other := tutil.NewIDAddr(t, 102)
+	amount := abi.NewTokenAmount(100_000_000)
+
+	params := defaultConstructorParams(admin, amount)
+	rt := actor.setupActor(t, params, abi.ChainEpoch(0))
+
+	stakeParams := actor.getStakeParams(rt)
+	assert.Equal(t, params.MaturePeriod, stakeParams.MaturePeriod)
+	assert.Equal(t, params.RoundPeriod, stakeParams.RoundPeriod)
+	assert.Equal(t, params.PrincipalLockDuration, stakeParams.PrincipalLockDuration)
+	assert.Equal(t, params.MinDepositAmount, stakeParams.MinDepositAmount)
+	assert.Equal(t, params.MaxRewardPerRound, stakeParams.MaxRewardPerRound)
+	assert.Equal(t, params.InflationFactor, stakeParams.InflationFactor)
+	assert.Equal(t, stake.DefaultUnbondingPeriod, stakeParams.UnbondingPeriod)
+	assert.Equal(t, stake.DefaultProposalDelay, stakeParams.ProposalDelay)
+
+	actor.deposit(rt, abi.ChainEpoch(4), staker, amount)
+	actor.deposit(rt, abi.ChainEpoch(30), staker, amount)
+	actor.tickThrough(rt, abi.ChainEpoch(1), abi.ChainEpoch(35))
+
+	info := actor.getStakerInfo(rt, staker)
+	assert.Equal(t, []stake.LockedPrincipal{{Amount: amount, Epoch: abi.ChainEpoch(30)}}, info.LockedPrincipals)
+	assert.Equal(t, amount, info.AvailablePrincipal)
+	assert.Equal(t, amount, info.StakePower)
+	assert.Equal(t, 180, len(info.VestingFunds))
+	assert.Equal(t, big.Zero(), info.AvailableReward)
+	assert.Empty(t, info.Unbondings)
+	assert.Equal(t, amount, actor.getTotalStakePower(rt))
+	assert.Equal(t, &stake.GetStakePowerReturn{StakePower: amount, TotalStakePower: amount}, actor.getStakePower(rt, staker))
+	assert.Equal(t, &stake.GetStakePowerReturn{StakePower: big.Zero(), TotalStakePower: amount}, actor.getStakePower(rt, other))
+
+	info = actor.getStakerInfo(rt, other)
+	assert.Empty(t, info.LockedPrincipals)
+	assert.Equal(t, big.Zero(), info.AvailablePrincipal)
+	assert.Equal(t, big.Zero(), info.StakePower)
+	assert.Empty(t, info.VestingFunds)
+	assert.Equal(t, big.Zero(), info.AvailableReward)
+}
+
+func TestStakerSnapshots(t *testing.T) {
+	actor := stakeHarness{stake.Actor{}, t}
+	admin := tutil.NewIDAddr(t, 100)
+	staker := tutil.NewIDAddr(t, 101)
+	other := tutil.NewIDAddr(t, 102)
+	absent := tutil.NewIDAddr(t, 103)
+	amount := abi.NewTokenAmount(100_000_000)
+
+	rt := actor.setupActor(t, defaultConstructorParams(admin, amount), abi.ChainEpoch(0))
+
+	actor.deposit(rt, abi.ChainEpoch(4), staker, amount)
+	actor.deposit(rt, abi.ChainEpoch(30), staker, amount)
+	actor.deposit(rt, abi.ChainEpoch(30), other, amount)
+	actor.tickThrough(rt, abi.ChainEpoch(1), abi.ChainEpoch(35))
+	st := getState(rt)
+
+	snapshot, found, err := st.GetStaker(rt.AdtStore(), staker)
+	assert.NoError(t, err)
+	assert.True(t, found)
+	info := actor.getStakerInfo(rt, staker)
+	assert.Equal(t, staker, snapshot.Staker)
+	assert.Equal(t, info.LockedPrincipals, snapshot.LockedPrincipals)
+	assert.Equal(t, info.AvailablePrincipal, snapshot.AvailablePrincipal)
+	assert.Equal(t, info.StakePower, snapshot.StakePower)
+	assert.Equal(t, info.VestingFunds, snapshot.VestingFunds)
+	assert.Equal(t, info.AvailableReward, snapshot.AvailableReward)
+
+	_, found, err = st.GetStaker(rt.AdtStore(), absent)
+	assert.NoError(t, err)
+	assert.False(t, found)
+
+	var stakers []addr.Address
+	err = st.ForEachStaker(rt.AdtStore(), func(s *stake.StakerSnapshot) error {
+		stakers = append(stakers, s.Staker)
+		if s.Staker == other {
+			assert.Equal(t, []stake.LockedPrincipal{{Amount: amount, Epoch: abi.ChainEpoch(30)}}, s.LockedPrincipals)
+			assert.Equal(t, big.Zero(), s.StakePower)
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []addr.Address{staker, other}, stakers)
+
+	// the principal deposited at 30 matures after 40 and unlocks after 60
+	projection := st.ProjectStaker(snapshot, abi.ChainEpoch(40))
+	assert.Empty(t, projection.PrincipalUnlocks)
+	assert.Equal(t, amount, projection.LockedPrincipal)
+	assert.Equal(t, amount, projection.AvailablePrincipal)
+	assert.Equal(t, amount, projection.StakePower)
+
+	projection = st.ProjectStaker(snapshot, abi.ChainEpoch(41))
+	assert.Equal(t, big.Mul(amount, big.NewInt(2)), projection.StakePower)
+
+	projection = st.ProjectStaker(snapshot, abi.ChainEpoch(61))
+	assert.Equal(t, []stake.ScheduledRelease{{Epoch: abi.ChainEpoch(61), Amount: amount}}, projection.PrincipalUnlocks)
+	assert.Equal(t, big.Zero(), projection.LockedPrincipal)
+	assert.Equal(t, big.Mul(amount, big.NewInt(2)), projection.AvailablePrincipal)
+	assert.Equal(t, big.Mul(amount, big.NewInt(2)), projection.StakePower)
+
+	// every reward vests by the epoch after the last vesting fund
+	vesting := big.Zero()
+	for _, vf := range snapshot.VestingFunds {
+		vesting = big.Add(vesting, vf.Amount)
+	}
+	assert.True(t, vesting.GreaterThan(big.Zero()))
+	projection = st.ProjectStaker(snapshot, abi.ChainEpoch(35))
+	assert.Empty(t, projection.RewardVests)
+	assert.Equal(t, vesting, projection.VestingReward)
+
+	last := snapshot.VestingFunds[len(snapshot.VestingFunds)-1]
+	projection = st.ProjectStaker(snapshot, last.Epoch+1)
+	assert.Equal(t, len(snapshot.VestingFunds), len(projection.RewardVests))
+	assert.Equal(t, stake.ScheduledRelease{Epoch: last.Epoch + 1, Amount: last.Amount}, projection.RewardVests[len(projection.RewardVests)-1])
+	assert.Equal(t, big.Zero(), projection.VestingReward)
+	assert.Equal(t, big.Add(snapshot.AvailableReward, vesting), projection.AvailableReward)
+
+	// withdrawn principal is released at its release epoch
+	half := big.Div(amount, big.NewInt(2))
+	actor.withdrawPrincipal(rt, abi.ChainEpoch(35), staker, half)
+	actor.setAutoCompound(rt, abi.ChainEpoch(35), staker, true)
+	st = getState(rt)
+	snapshot, _, err = st.GetStaker(rt.AdtStore(), staker)
+	assert.NoError(t, err)
+	assert.True(t, snapshot.AutoCompound)
+	release := snapshot.Unbondings[0].ReleaseEpoch
+
+	projection = st.ProjectStaker(snapshot, release-1)
+	assert.Empty(t, projection.UnbondingReleases)
+	assert.Equal(t, half, projection.UnbondingPrincipal)
+	assert.Equal(t, big.Zero(), projection.ClaimableUnbonding)
+
+	projection = st.ProjectStaker(snapshot, release)
+	assert.Equal(t, []stake.ScheduledRelease{{Epoch: release, Amount: half}}, projection.UnbondingReleases)
+	assert.Equal(t, big.Zero(), projection.UnbondingPrincipal)
+	assert.Equal(t, half, projection.ClaimableUnbonding)
+
+	// auto-compounding restakes a vested reward as principal locked from the epoch it vests
+	first := snapshot.VestingFunds[0]
+	principal := big.Add(snapshot.AvailablePrincipal, amount)
+	projection = st.ProjectStaker(snapshot, first.Epoch+1)
+	assert.Equal(t, []stake.ScheduledRelease{{Epoch: first.Epoch + 1, Amount: first.Amount}}, projection.RewardVests)
+	assert.Equal(t, snapshot.AvailableReward, projection.AvailableReward)
+	assert.Equal(t, first.Amount, projection.LockedPrincipal)
+	assert.Equal(t, principal, projection.AvailablePrincipal)
+	assert.Equal(t, principal, projection.StakePower)
+
+	projection = st.ProjectStaker(snapshot, first.Epoch+12)
+	assert.Equal(t, big.Add(principal, first.Amount), projection.StakePower)
+
+	projection = st.ProjectStaker(snapshot, first.Epoch+32)
+	assert.Equal(t, stake.ScheduledRelease{Epoch: first.Epoch + 32, Amount: first.Amount}, projection.PrincipalUnlocks[len(projection.PrincipalUnlocks)-1])
+	assert.Equal(t, big.Zero(), projection.LockedPrincipal)
+	assert.Equal(t, big.Add(principal, first.Amount), projection.AvailablePrincipal)
+}
+
+func TestCheckStateInvariants(t *testing.T) {
+	actor := stakeHarness{stake.Actor{}, t}
+	admin := tutil.NewIDAddr(t, 100)
+	staker1 := tutil.NewIDAddr(t, 101)
+	staker2 := tutil.NewIDAddr(t, 102)
+
+	rt := actor.setupActor(t, defaultConstructorParams(admin, abi.NewTokenAmount(100_000_000)), abi.ChainEpoch(3))
+	actor.deposit(rt, abi.ChainEpoch(4), staker1, abi.NewTokenAmount(100_000_000))
+	actor.deposit(rt, abi.ChainEpoch(5), staker2, abi.NewTokenAmount(200_000_000))
+	actor.tickThrough(rt, abi.ChainEpoch(4), abi.ChainEpoch(35))
+
+	rt.SetBalance(abi.NewTokenAmount(303_000_000))
+	summary := actor.checkState(rt)
+	assert.Equal(t, abi.NewStakePower(300_000_000), summary.TotalStakePower)
+	assert.Equal(t, abi.NewStakePower(100_000_000), summary.StakePowers[staker1])
+	assert.Equal(t, abi.NewStakePower(200_000_000), summary.StakePowers[staker2])
+	assert.Equal(t, abi.NewTokenAmount(200_000_000), summary.TotalLockedPrincipal)
+	assert.Equal(t, abi.NewTokenAmount(100_000_000), summary.TotalAvailablePrincipal)
+	assert.Equal(t, abi.NewTokenAmount(3_000_000), summary.TotalVestingReward)
+	assert.Equal(t, abi.NewTokenAmount(0), summary.TotalAvailableReward)
+
+	// total stake power stays in sync with the power map between ticks
+	// withdrawn principal is held by the actor until it is claimed
+	actor.withdrawPrincipal(rt, abi.ChainEpoch(36), staker1, abi.NewTokenAmount(30_000_000))
+	summary = actor.checkState(rt)
+	assert.Equal(t, abi.NewStakePower(270_000_000), summary.TotalStakePower)
+	assert.Equal(t, abi.NewTokenAmount(30_000_000), summary.TotalUnbondingPrincipal)
+
+	rt.SetBalance(abi.NewTokenAmount(302_999_999))
+	st := getState(rt)
+	_, msgs := stake.CheckStateInvariants(st, rt.AdtStore(), rt.Balance())
+	assert.Equal(t, 1, len(msgs.Messages()))
+	assert.Contains(t, msgs.Messages()[0], "balance 302999999 is less than locked principal")
 }
 
 type stakeHarness struct {
@@ -363,6 +1293,39 @@ type stakeHarness struct {
 	t testing.TB
 }
 
+// Returns the constructor parameters shared by most tests: rounds of 20 epochs starting at epoch 3,
+// maturity after 10 epochs and principal locked for 30.
+func defaultConstructorParams(rootKey addr.Address, minDepositAmount abi.TokenAmount) *stake.ConstructorParams {
+	return &stake.ConstructorParams{
+		RootKey:               rootKey,
+		MaturePeriod:          abi.ChainEpoch(10),
+		RoundPeriod:           abi.ChainEpoch(20),
+		PrincipalLockDuration: abi.ChainEpoch(30),
+		FirstRoundEpoch:       abi.ChainEpoch(3),
+		MinDepositAmount:      minDepositAmount,
+		MaxRewardPerRound:     abi.NewTokenAmount(100_000_000_000),
+		InflationFactor:       big.NewInt(100),
+	}
+}
+
+// Builds a runtime at epoch 0 holding a stake actor constructed from params, and runs cron through lastTick.
+func (h *stakeHarness) setupActor(t *testing.T, params *stake.ConstructorParams, lastTick abi.ChainEpoch) *mock.Runtime {
+	rt := mock.NewBuilder(builtin.StakeActorAddr).
+		WithCaller(builtin.SystemActorAddr, builtin.SystemActorCodeID).
+		WithEpoch(abi.ChainEpoch(0)).
+		Build(t)
+	h.constructAndVerify(rt, params)
+	h.tickThrough(rt, abi.ChainEpoch(1), lastTick)
+	return rt
+}
+
+// Runs cron at every epoch from first through last.
+func (h *stakeHarness) tickThrough(rt *mock.Runtime, first, last abi.ChainEpoch) {
+	for epoch := first; epoch <= last; epoch++ {
+		h.onEpochTickEnd(rt, epoch)
+	}
+}
+
 func (h *stakeHarness) constructAndVerify(rt *mock.Runtime, params *stake.ConstructorParams) {
 	rt.Reset()
 	rt.ExpectValidateCallerAddr(builtin.SystemActorAddr)
@@ -391,17 +1354,269 @@ func (h *stakeHarness) deposit(rt *mock.Runtime, currEpoch abi.ChainEpoch, stake
 	rt.Verify()
 }
 
+func (h *stakeHarness) depositFor(rt *mock.Runtime, currEpoch abi.ChainEpoch, depositor addr.Address, amount abi.TokenAmount, params *stake.DepositForParams) {
+	rt.SetCaller(depositor, builtin.AccountActorCodeID)
+	rt.ExpectValidateCallerAny()
+	rt.SetEpoch(currEpoch)
+	rt.SetReceived(amount)
+	rt.Call(h.Actor.DepositFor, params)
+	rt.Verify()
+}
+
+// Expects the withdrawn principal to start unbonding for recipient, unless it is undefined.
+func (h *stakeHarness) withdrawDelegatedPrincipal(rt *mock.Runtime, currEpoch abi.ChainEpoch, caller, staker addr.Address, amount abi.TokenAmount, recipient addr.Address) {
+	rt.SetCaller(caller, builtin.AccountActorCodeID)
+	rt.ExpectValidateCallerAny()
+	rt.SetEpoch(currEpoch)
+	rt.SetReceived(abi.NewTokenAmount(0))
+	before := len(h.unbondings(rt, staker))
+	rt.Call(h.Actor.WithdrawDelegatedPrincipal, &stake.WithdrawDelegatedPrincipalParams{Staker: staker, AmountRequested: amount})
+	rt.Verify()
+
+	unbondings := h.unbondings(rt, staker)
+	if recipient == addr.Undef {
+		assert.Equal(h.t, before, len(unbondings))
+		return
+	}
+	if !assert.Equal(h.t, before+1, len(unbondings)) {
+		return
+	}
+	assert.Equal(h.t, stake.Unbonding{
+		Amount:       amount,
+		ReleaseEpoch: currEpoch + getState(rt).UnbondingPeriod,
+		Recipient:    recipient,
+	}, unbondings[before])
+}
+
 func (h *stakeHarness) withdrawPrincipal(rt *mock.Runtime, currEpoch abi.ChainEpoch, staker addr.Address, amount abi.TokenAmount) {
 	rt.SetCaller(staker, builtin.AccountActorCodeID)
 	rt.ExpectValidateCallerAny()
-	rt.ExpectSend(staker, 0, nil, amount, nil, exitcode.Ok)
 	rt.SetEpoch(currEpoch)
 	rt.SetReceived(abi.NewTokenAmount(0))
-	rt.SetBalance(amount)
 	rt.Call(h.Actor.WithdrawPrincipal, &stake.WithdrawParams{AmountRequested: amount})
 	rt.Verify()
 }
 
+// Expects each payout to be sent to its recipient, in order.
+func (h *stakeHarness) claimUnbonded(rt *mock.Runtime, currEpoch abi.ChainEpoch, caller, staker addr.Address, payouts ...stake.Unbonding) {
+	rt.SetCaller(caller, builtin.AccountActorCodeID)
+	rt.ExpectValidateCallerAny()
+	total := big.Zero()
+	for _, payout := range payouts {
+		rt.ExpectSend(payout.Recipient, builtin.MethodSend, nil, payout.Amount, nil, exitcode.Ok)
+		total = big.Add(total, payout.Amount)
+	}
+	rt.SetEpoch(currEpoch)
+	rt.SetReceived(abi.NewTokenAmount(0))
+	rt.SetBalance(total)
+	rt.Call(h.Actor.ClaimUnbonded, &staker)
+	rt.Verify()
+}
+
+func (h *stakeHarness) changeUnbondingPeriod(rt *mock.Runtime, rootKey addr.Address, unbondingPeriod abi.ChainEpoch) *stake.ProposeReturn {
+	rt.SetCaller(rootKey, builtin.AccountActorCodeID)
+	rt.ExpectValidateCallerAddr(getState(rt).RootKey)
+	rt.SetReceived(abi.NewTokenAmount(0))
+	ret := rt.Call(h.Actor.ChangeUnbondingPeriod, &stake.ChangeUnbondingPeriodParams{UnbondingPeriod: unbondingPeriod})
+	rt.Verify()
+	return ret.(*stake.ProposeReturn)
+}
+
+func (h *stakeHarness) unbondings(rt *mock.Runtime, staker addr.Address) []stake.Unbonding {
+	st := getState(rt)
+	unbondingMap, err := adt.AsMap(rt.AdtStore(), st.UnbondingMap, builtin.DefaultHamtBitwidth)
+	assert.NoError(h.t, err)
+	unbondings, found, err := st.LoadUnbondings(rt.AdtStore(), unbondingMap, staker)
+	assert.NoError(h.t, err)
+	if !found {
+		return nil
+	}
+	return unbondings.Data
+}
+
+func (h *stakeHarness) changeMaturePeriod(rt *mock.Runtime, currEpoch abi.ChainEpoch, rootKey addr.Address, maturePeriod abi.ChainEpoch) *stake.ProposeReturn {
+	rt.SetCaller(rootKey, builtin.AccountActorCodeID)
+	rt.ExpectValidateCallerAddr(rootKey)
+	rt.SetEpoch(currEpoch)
+	rt.SetReceived(abi.NewTokenAmount(0))
+	ret := rt.Call(h.Actor.ChangeMaturePeriod, &stake.ChangeMaturePeriodParams{MaturePeriod: maturePeriod})
+	rt.Verify()
+	return ret.(*stake.ProposeReturn)
+}
+
+func (h *stakeHarness) changeInflationFactor(rt *mock.Runtime, currEpoch abi.ChainEpoch, rootKey addr.Address, inflationFactor big.Int) *stake.ProposeReturn {
+	rt.SetCaller(rootKey, builtin.AccountActorCodeID)
+	rt.ExpectValidateCallerAddr(getState(rt).RootKey)
+	rt.SetEpoch(currEpoch)
+	rt.SetReceived(abi.NewTokenAmount(0))
+	ret := rt.Call(h.Actor.ChangeInflationFactor, &stake.ChangeInflationFactorParams{InflationFactor: inflationFactor})
+	rt.Verify()
+	return ret.(*stake.ProposeReturn)
+}
+
+func (h *stakeHarness) changeProposalDelay(rt *mock.Runtime, currEpoch abi.ChainEpoch, rootKey addr.Address, delay abi.ChainEpoch) *stake.ProposeReturn {
+	rt.SetCaller(rootKey, builtin.AccountActorCodeID)
+	rt.ExpectValidateCallerAddr(getState(rt).RootKey)
+	rt.SetEpoch(currEpoch)
+	rt.SetReceived(abi.NewTokenAmount(0))
+	ret := rt.Call(h.Actor.ChangeProposalDelay, &stake.ChangeProposalDelayParams{ProposalDelay: delay})
+	rt.Verify()
+	return ret.(*stake.ProposeReturn)
+}
+
+func (h *stakeHarness) changePrincipalLockDuration(rt *mock.Runtime, currEpoch abi.ChainEpoch, rootKey addr.Address, lockDuration abi.ChainEpoch) *stake.ProposeReturn {
+	rt.SetCaller(rootKey, builtin.AccountActorCodeID)
+	rt.ExpectValidateCallerAddr(getState(rt).RootKey)
+	rt.SetEpoch(currEpoch)
+	rt.SetReceived(abi.NewTokenAmount(0))
+	ret := rt.Call(h.Actor.ChangePrincipalLockDuration, &stake.ChangePrincipalLockDurationParams{PrincipalLockDuration: lockDuration})
+	rt.Verify()
+	return ret.(*stake.ProposeReturn)
+}
+
+func (h *stakeHarness) changeRewardCurve(rt *mock.Runtime, currEpoch abi.ChainEpoch, rootKey addr.Address, curve stake.RewardCurveParams) *stake.ProposeReturn {
+	rt.SetCaller(rootKey, builtin.AccountActorCodeID)
+	rt.ExpectValidateCallerAddr(getState(rt).RootKey)
+	rt.SetEpoch(currEpoch)
+	rt.SetReceived(abi.NewTokenAmount(0))
+	ret := rt.Call(h.Actor.ChangeRewardCurve, &stake.ChangeRewardCurveParams{RewardCurve: curve})
+	rt.Verify()
+	return ret.(*stake.ProposeReturn)
+}
+
+func (h *stakeHarness) changeRewardVestingSpec(rt *mock.Runtime, currEpoch abi.ChainEpoch, rootKey addr.Address, spec miner.VestSpec) *stake.ProposeReturn {
+	rt.SetCaller(rootKey, builtin.AccountActorCodeID)
+	rt.ExpectValidateCallerAddr(getState(rt).RootKey)
+	rt.SetEpoch(currEpoch)
+	rt.SetReceived(abi.NewTokenAmount(0))
+	ret := rt.Call(h.Actor.ChangeRewardVestingSpec, &stake.ChangeRewardVestingSpecParams{Spec: spec})
+	rt.Verify()
+	return ret.(*stake.ProposeReturn)
+}
+
+func (h *stakeHarness) cancelProposal(rt *mock.Runtime, rootKey addr.Address, id stake.ProposalID) {
+	rt.SetCaller(rootKey, builtin.AccountActorCodeID)
+	rt.ExpectValidateCallerAddr(getState(rt).RootKey)
+	rt.SetReceived(abi.NewTokenAmount(0))
+	rt.Call(h.Actor.CancelProposal, &stake.CancelProposalParams{ID: id})
+	rt.Verify()
+}
+
+func (h *stakeHarness) getProposals(rt *mock.Runtime) []stake.PendingProposal {
+	rt.ExpectValidateCallerAny()
+	ret := rt.Call(h.Actor.GetProposals, nil).(*stake.GetProposalsReturn)
+	rt.Verify()
+	return ret.Proposals
+}
+
+// Shortens the proposal delay directly in state, so tests need not wait out the default delay.
+func (h *stakeHarness) setProposalDelay(rt *mock.Runtime, delay abi.ChainEpoch) {
+	st := getState(rt)
+	st.ProposalDelay = delay
+	rt.ReplaceState(st)
+}
+
+func (h *stakeHarness) restakeReward(rt *mock.Runtime, currEpoch abi.ChainEpoch, staker addr.Address, amount abi.TokenAmount) {
+	rt.SetCaller(staker, builtin.AccountActorCodeID)
+	rt.ExpectValidateCallerAny()
+	rt.SetEpoch(currEpoch)
+	rt.SetReceived(abi.NewTokenAmount(0))
+	rt.Call(h.Actor.RestakeReward, &stake.RestakeRewardParams{Amount: amount})
+	rt.Verify()
+}
+
+func (h *stakeHarness) setAutoCompound(rt *mock.Runtime, currEpoch abi.ChainEpoch, staker addr.Address, enabled bool) {
+	rt.SetCaller(staker, builtin.AccountActorCodeID)
+	rt.ExpectValidateCallerAny()
+	rt.SetEpoch(currEpoch)
+	rt.SetReceived(abi.NewTokenAmount(0))
+	rt.Call(h.Actor.SetAutoCompound, &stake.SetAutoCompoundParams{Enabled: enabled})
+	rt.Verify()
+}
+
+func (h *stakeHarness) addSlasher(rt *mock.Runtime, rootKey, slasher addr.Address) *stake.ProposeReturn {
+	rt.SetCaller(rootKey, builtin.AccountActorCodeID)
+	rt.ExpectValidateCallerAddr(getState(rt).RootKey)
+	ret := rt.Call(h.Actor.AddSlasher, &slasher)
+	rt.Verify()
+	return ret.(*stake.ProposeReturn)
+}
+
+func (h *stakeHarness) removeSlasher(rt *mock.Runtime, rootKey, slasher addr.Address) *stake.ProposeReturn {
+	rt.SetCaller(rootKey, builtin.AccountActorCodeID)
+	rt.ExpectValidateCallerAddr(getState(rt).RootKey)
+	ret := rt.Call(h.Actor.RemoveSlasher, &slasher)
+	rt.Verify()
+	return ret.(*stake.ProposeReturn)
+}
+
+// Expects burnt to be sent to the burnt funds actor, unless it is zero.
+func (h *stakeHarness) slash(rt *mock.Runtime, currEpoch abi.ChainEpoch, slasher, staker addr.Address, amount, burnt abi.TokenAmount) {
+	rt.SetCaller(slasher, builtin.StoragePowerActorCodeID)
+	rt.ExpectValidateCallerAny()
+	if burnt.GreaterThan(big.Zero()) {
+		rt.ExpectSend(builtin.BurntFundsActorAddr, builtin.MethodSend, nil, burnt, nil, exitcode.Ok)
+	}
+	rt.SetEpoch(currEpoch)
+	rt.SetReceived(abi.NewTokenAmount(0))
+	rt.Call(h.Actor.Slash, &stake.SlashParams{Staker: staker, Amount: amount, Reason: "test"})
+	rt.Verify()
+}
+
+func (h *stakeHarness) getStakerInfo(rt *mock.Runtime, staker addr.Address) *stake.GetStakerInfoReturn {
+	rt.ExpectValidateCallerAny()
+	ret := rt.Call(h.Actor.GetStakerInfo, &staker).(*stake.GetStakerInfoReturn)
+	rt.Verify()
+	return ret
+}
+
+func (h *stakeHarness) getStakeParams(rt *mock.Runtime) *stake.GetStakeParamsReturn {
+	rt.ExpectValidateCallerAny()
+	ret := rt.Call(h.Actor.GetStakeParams, nil).(*stake.GetStakeParamsReturn)
+	rt.Verify()
+	return ret
+}
+
+func (h *stakeHarness) getTotalStakePower(rt *mock.Runtime) abi.StakePower {
+	rt.ExpectValidateCallerAny()
+	ret := rt.Call(h.Actor.GetTotalStakePower, nil).(*stake.GetTotalStakePowerReturn)
+	rt.Verify()
+	return ret.TotalStakePower
+}
+
+func (h *stakeHarness) getStakePower(rt *mock.Runtime, staker addr.Address) *stake.GetStakePowerReturn {
+	rt.ExpectValidateCallerAny()
+	ret := rt.Call(h.Actor.GetStakePower, &staker).(*stake.GetStakePowerReturn)
+	rt.Verify()
+	return ret
+}
+
+func (h *stakeHarness) checkStateWithBalance(rt *mock.Runtime, balance abi.TokenAmount) *stake.StateSummary {
+	rt.SetBalance(balance)
+	return h.checkState(rt)
+}
+
+func (h *stakeHarness) checkState(rt *mock.Runtime) *stake.StateSummary {
+	st := getState(rt)
+	summary, msgs := stake.CheckStateInvariants(st, rt.AdtStore(), rt.Balance())
+	assert.True(h.t, msgs.IsEmpty(), strings.Join(msgs.Messages(), "\n"))
+	return summary
+}
+
+func queuedStakers(t *testing.T, rt *mock.Runtime, epoch abi.ChainEpoch) []addr.Address {
+	st := getState(rt)
+	queue, err := adt.AsMultimap(rt.AdtStore(), st.StakerEventQueue, stake.StakerQueueHamtBitwidth, stake.StakerQueueAmtBitwidth)
+	assert.NoError(t, err)
+	var stakers []addr.Address
+	var staker addr.Address
+	err = queue.ForEach(abi.IntKey(int64(epoch)), &staker, func(i int64) error {
+		stakers = append(stakers, staker)
+		return nil
+	})
+	assert.NoError(t, err)
+	return stakers
+}
+
 func getState(rt *mock.Runtime) *stake.State {
 	var st stake.State
 	rt.GetState(&st)
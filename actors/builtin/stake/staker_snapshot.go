@@ -0,0 +1,269 @@
+package stake
+
+import (
+	"sort"
+
+	addr "github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/specs-actors/v3/actors/builtin"
+	"github.com/filecoin-project/specs-actors/v3/actors/util/adt"
+)
+
+// StakerSnapshot combines everything the stake actor records for a staker.
+// The stake power is as of the last cron tick that processed the staker.
+type StakerSnapshot struct {
+	Staker             addr.Address
+	LockedPrincipals   []LockedPrincipal
+	AvailablePrincipal abi.TokenAmount
+	StakePower         abi.StakePower
+	VestingFunds       []VestingFund
+	AvailableReward    abi.TokenAmount
+	Unbondings         []Unbonding
+	// Whether the staker's rewards are restaked as locked principal when they vest.
+	AutoCompound bool
+}
+
+// ScheduledRelease is an amount that becomes available at an epoch.
+type ScheduledRelease struct {
+	Epoch  abi.ChainEpoch
+	Amount abi.TokenAmount
+}
+
+// StakerProjection is a staker's snapshot carried forward to an epoch, assuming cron has run up to it and
+// the staker makes no further deposits, withdrawals or claims, does not change auto-compounding,
+// earns no further rewards and is not slashed.
+type StakerProjection struct {
+	Epoch abi.ChainEpoch
+	// Locked principal unlocking up to Epoch, in the order it unlocks, including rewards restaked by auto-compounding.
+	PrincipalUnlocks []ScheduledRelease
+	// Vesting rewards vesting up to Epoch, in the order they vest.
+	// Under auto-compounding they are restaked as locked principal instead of becoming available.
+	RewardVests []ScheduledRelease
+	// Unbonding principal released up to Epoch, in the order it is released, whether or not already claimable.
+	UnbondingReleases []ScheduledRelease
+
+	LockedPrincipal    abi.TokenAmount
+	AvailablePrincipal abi.TokenAmount
+	StakePower         abi.StakePower
+	VestingReward      abi.TokenAmount
+	AvailableReward    abi.TokenAmount
+	// Withdrawn principal not yet released at Epoch.
+	UnbondingPrincipal abi.TokenAmount
+	// Withdrawn principal released and claimable by its recipients at Epoch.
+	ClaimableUnbonding abi.TokenAmount
+}
+
+// The per-staker maps, loaded once to read any number of stakers.
+type stakerMaps struct {
+	lockedPrincipals   *adt.Map
+	availablePrincipal *adt.Map
+	stakePower         *adt.Map
+	vestingRewards     *adt.Map
+	availableReward    *adt.Map
+	unbondings         *adt.Map
+	autoCompound       *adt.Set
+}
+
+func (st *State) loadStakerMaps(store adt.Store) (*stakerMaps, error) {
+	var m stakerMaps
+	var err error
+	if m.lockedPrincipals, err = adt.AsMap(store, st.LockedPrincipalMap, builtin.DefaultHamtBitwidth); err != nil {
+		return nil, xerrors.Errorf("failed to load locked principals: %w", err)
+	}
+	if m.availablePrincipal, err = adt.AsMap(store, st.AvailablePrincipalMap, builtin.DefaultHamtBitwidth); err != nil {
+		return nil, xerrors.Errorf("failed to load available principals: %w", err)
+	}
+	if m.stakePower, err = adt.AsMap(store, st.StakePowerMap, builtin.DefaultHamtBitwidth); err != nil {
+		return nil, xerrors.Errorf("failed to load stake powers: %w", err)
+	}
+	if m.vestingRewards, err = adt.AsMap(store, st.VestingRewardMap, builtin.DefaultHamtBitwidth); err != nil {
+		return nil, xerrors.Errorf("failed to load vesting rewards: %w", err)
+	}
+	if m.availableReward, err = adt.AsMap(store, st.AvailableRewardMap, builtin.DefaultHamtBitwidth); err != nil {
+		return nil, xerrors.Errorf("failed to load available rewards: %w", err)
+	}
+	if m.unbondings, err = adt.AsMap(store, st.UnbondingMap, builtin.DefaultHamtBitwidth); err != nil {
+		return nil, xerrors.Errorf("failed to load unbondings: %w", err)
+	}
+	if m.autoCompound, err = adt.AsSet(store, st.AutoCompound, builtin.DefaultHamtBitwidth); err != nil {
+		return nil, xerrors.Errorf("failed to load auto compound stakers: %w", err)
+	}
+	return &m, nil
+}
+
+// Returns the snapshot of a staker, or false if the stake actor records nothing for it.
+func (st *State) GetStaker(store adt.Store, staker addr.Address) (*StakerSnapshot, bool, error) {
+	maps, err := st.loadStakerMaps(store)
+	if err != nil {
+		return nil, false, err
+	}
+	return st.loadStakerSnapshot(store, maps, staker)
+}
+
+// Calls fn with the snapshot of every staker for which the stake actor records any principal, power or reward.
+func (st *State) ForEachStaker(store adt.Store, fn func(*StakerSnapshot) error) error {
+	maps, err := st.loadStakerMaps(store)
+	if err != nil {
+		return err
+	}
+
+	// A staker may be present in any of the maps, e.g. with only unbonding principal or available reward left.
+	var stakers []addr.Address
+	seen := make(map[addr.Address]struct{})
+	for _, m := range []*adt.Map{maps.lockedPrincipals, maps.availablePrincipal, maps.stakePower,
+		maps.vestingRewards, maps.availableReward, maps.unbondings} {
+		keys, err := m.CollectKeys()
+		if err != nil {
+			return xerrors.Errorf("failed to iterate stakers: %w", err)
+		}
+		for _, key := range keys {
+			staker, err := addr.NewFromBytes([]byte(key))
+			if err != nil {
+				return err
+			}
+			if _, ok := seen[staker]; !ok {
+				seen[staker] = struct{}{}
+				stakers = append(stakers, staker)
+			}
+		}
+	}
+
+	for _, staker := range stakers {
+		snapshot, _, err := st.loadStakerSnapshot(store, maps, staker)
+		if err != nil {
+			return err
+		}
+		if err = fn(snapshot); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (st *State) loadStakerSnapshot(store adt.Store, maps *stakerMaps, staker addr.Address) (*StakerSnapshot, bool, error) {
+	s := &StakerSnapshot{
+		Staker:             staker,
+		LockedPrincipals:   []LockedPrincipal{},
+		AvailablePrincipal: big.Zero(),
+		StakePower:         big.Zero(),
+		VestingFunds:       []VestingFund{},
+		AvailableReward:    big.Zero(),
+		Unbondings:         []Unbonding{},
+	}
+	exists := false
+
+	lockedPrincipals, found, err := st.LoadLockedPrincipals(store, maps.lockedPrincipals, staker)
+	if err != nil {
+		return nil, false, err
+	}
+	if found && lockedPrincipals.Data != nil {
+		s.LockedPrincipals = lockedPrincipals.Data
+	}
+	exists = exists || found
+
+	if found, err = maps.availablePrincipal.Get(abi.AddrKey(staker), &s.AvailablePrincipal); err != nil {
+		return nil, false, xerrors.Errorf("failed to get available principal for %v: %w", staker, err)
+	}
+	exists = exists || found
+
+	if found, err = maps.stakePower.Get(abi.AddrKey(staker), &s.StakePower); err != nil {
+		return nil, false, xerrors.Errorf("failed to get stake power for %v: %w", staker, err)
+	}
+	exists = exists || found
+
+	vestingFunds, found, err := st.LoadVestingFunds(store, maps.vestingRewards, staker)
+	if err != nil {
+		return nil, false, err
+	}
+	if found && vestingFunds.Funds != nil {
+		s.VestingFunds = vestingFunds.Funds
+	}
+	exists = exists || found
+
+	if found, err = maps.availableReward.Get(abi.AddrKey(staker), &s.AvailableReward); err != nil {
+		return nil, false, xerrors.Errorf("failed to get available reward for %v: %w", staker, err)
+	}
+	exists = exists || found
+
+	unbondings, found, err := st.LoadUnbondings(store, maps.unbondings, staker)
+	if err != nil {
+		return nil, false, err
+	}
+	if found && unbondings.Data != nil {
+		s.Unbondings = unbondings.Data
+	}
+	exists = exists || found
+
+	// The flag alone does not make a staker exist.
+	if s.AutoCompound, err = maps.autoCompound.Has(abi.AddrKey(staker)); err != nil {
+		return nil, false, xerrors.Errorf("failed to check auto compound for %v: %w", staker, err)
+	}
+
+	return s, exists, nil
+}
+
+// Projects a staker's snapshot forward to epoch under the current mature period, principal lock duration
+// and auto-compounding.
+// Principal unlocks and rewards vest at the first cron tick strictly after their recorded epochs,
+// and a reward restaked by auto-compounding is locked from the epoch it vests.
+// Unbonding principal is released at its release epoch.
+func (st *State) ProjectStaker(s *StakerSnapshot, epoch abi.ChainEpoch) *StakerProjection {
+	p := &StakerProjection{
+		Epoch:              epoch,
+		PrincipalUnlocks:   []ScheduledRelease{},
+		RewardVests:        []ScheduledRelease{},
+		UnbondingReleases:  []ScheduledRelease{},
+		LockedPrincipal:    big.Zero(),
+		AvailablePrincipal: s.AvailablePrincipal,
+		StakePower:         big.Zero(),
+		VestingReward:      big.Zero(),
+		AvailableReward:    s.AvailableReward,
+		UnbondingPrincipal: big.Zero(),
+		ClaimableUnbonding: big.Zero(),
+	}
+
+	lockedPrincipals := append([]LockedPrincipal{}, s.LockedPrincipals...)
+	for _, vf := range s.VestingFunds {
+		if vestEpoch := vf.Epoch + 1; vestEpoch <= epoch {
+			p.RewardVests = append(p.RewardVests, ScheduledRelease{Epoch: vestEpoch, Amount: vf.Amount})
+			if s.AutoCompound {
+				lockedPrincipals = append(lockedPrincipals, LockedPrincipal{Amount: vf.Amount, Epoch: vestEpoch})
+			} else {
+				p.AvailableReward = big.Add(p.AvailableReward, vf.Amount)
+			}
+			continue
+		}
+		p.VestingReward = big.Add(p.VestingReward, vf.Amount)
+	}
+
+	maturePrincipal := big.Zero()
+	for _, lp := range lockedPrincipals {
+		if unlockEpoch := lp.Epoch + st.PrincipalLockDuration + 1; unlockEpoch <= epoch {
+			p.PrincipalUnlocks = append(p.PrincipalUnlocks, ScheduledRelease{Epoch: unlockEpoch, Amount: lp.Amount})
+			p.AvailablePrincipal = big.Add(p.AvailablePrincipal, lp.Amount)
+			continue
+		}
+		p.LockedPrincipal = big.Add(p.LockedPrincipal, lp.Amount)
+		if lp.Epoch+st.MaturePeriod < epoch {
+			maturePrincipal = big.Add(maturePrincipal, lp.Amount)
+		}
+	}
+	p.StakePower = big.Add(maturePrincipal, p.AvailablePrincipal)
+
+	for _, ub := range s.Unbondings {
+		if ub.ReleaseEpoch <= epoch {
+			p.UnbondingReleases = append(p.UnbondingReleases, ScheduledRelease{Epoch: ub.ReleaseEpoch, Amount: ub.Amount})
+			p.ClaimableUnbonding = big.Add(p.ClaimableUnbonding, ub.Amount)
+			continue
+		}
+		p.UnbondingPrincipal = big.Add(p.UnbondingPrincipal, ub.Amount)
+	}
+	// Unbondings are kept in order of withdrawal, which differs from release order after the unbonding period changes.
+	sort.SliceStable(p.UnbondingReleases, func(i, j int) bool {
+		return p.UnbondingReleases[i].Epoch < p.UnbondingReleases[j].Epoch
+	})
+	return p
+}
@@ -0,0 +1,452 @@
+package stake
+
+import (
+	addr "github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	cbg "github.com/whyrusleeping/cbor-gen"
+
+	"github.com/filecoin-project/specs-actors/v3/actors/builtin"
+	"github.com/filecoin-project/specs-actors/v3/actors/builtin/miner"
+	"github.com/filecoin-project/specs-actors/v3/actors/util/adt"
+)
+
+type StateSummary struct {
+	TotalStakePower         abi.StakePower
+	StakePowers             map[addr.Address]abi.StakePower
+	TotalLockedPrincipal    abi.TokenAmount
+	TotalAvailablePrincipal abi.TokenAmount
+	TotalVestingReward      abi.TokenAmount
+	TotalAvailableReward    abi.TokenAmount
+	TotalUnbondingPrincipal abi.TokenAmount
+}
+
+// Checks internal invariants of stake state.
+func CheckStateInvariants(st *State, store adt.Store, balance abi.TokenAmount) (*StateSummary, *builtin.MessageAccumulator) {
+	acc := &builtin.MessageAccumulator{}
+	summary := &StateSummary{
+		TotalStakePower:         big.Zero(),
+		StakePowers:             make(map[addr.Address]abi.StakePower),
+		TotalLockedPrincipal:    big.Zero(),
+		TotalAvailablePrincipal: big.Zero(),
+		TotalVestingReward:      big.Zero(),
+		TotalAvailableReward:    big.Zero(),
+		TotalUnbondingPrincipal: big.Zero(),
+	}
+
+	acc.Require(st.TotalStakePower.GreaterThanEqual(big.Zero()), "total stake power is negative %v", st.TotalStakePower)
+	acc.Require(st.MaturePeriod <= st.PrincipalLockDuration,
+		"mature period %d is greater than principal lock duration %d", st.MaturePeriod, st.PrincipalLockDuration)
+	acc.Require(st.UnbondingPeriod >= 0, "unbonding period %d is negative", st.UnbondingPeriod)
+	acc.Require(st.ProposalDelay >= 0, "proposal delay %d is negative", st.ProposalDelay)
+	acc.Require(st.RoundPeriod > 0, "round period %d is not positive", st.RoundPeriod)
+	acc.Require(st.NextRoundEpoch >= st.StakePeriodStart,
+		"next round epoch %d is before stake period start %d", st.NextRoundEpoch, st.StakePeriodStart)
+
+	principals := make(map[addr.Address]abi.TokenAmount)
+	CheckLockedPrincipals(st, store, principals, summary, acc)
+	CheckAvailablePrincipals(st, store, principals, summary, acc)
+	CheckStakePowers(st, store, principals, summary, acc)
+	vestSpecs := CheckRewardVestingSpecs(st, store, acc)
+	CheckVestingRewards(st, store, vestSpecs, summary, acc)
+	CheckAvailableRewards(st, store, summary, acc)
+	CheckStakerEventQueue(st, store, acc)
+	CheckDelegations(st, store, acc)
+	CheckAutoCompound(st, store, acc)
+	CheckSlashers(st, store, acc)
+	CheckUnbondings(st, store, summary, acc)
+	CheckProposals(st, store, acc)
+
+	summary.TotalStakePower = st.TotalStakePower
+
+	required := big.Sum(summary.TotalLockedPrincipal, summary.TotalAvailablePrincipal,
+		summary.TotalVestingReward, summary.TotalAvailableReward, summary.TotalUnbondingPrincipal)
+	acc.Require(balance.GreaterThanEqual(required),
+		"balance %v is less than locked principal %v + available principal %v + vesting reward %v + available reward %v + unbonding principal %v",
+		balance, summary.TotalLockedPrincipal, summary.TotalAvailablePrincipal, summary.TotalVestingReward, summary.TotalAvailableReward,
+		summary.TotalUnbondingPrincipal)
+
+	return summary, acc
+}
+
+func CheckLockedPrincipals(st *State, store adt.Store, principals map[addr.Address]abi.TokenAmount, summary *StateSummary, acc *builtin.MessageAccumulator) {
+	lockedPrincipalMap, err := adt.AsMap(store, st.LockedPrincipalMap, builtin.DefaultHamtBitwidth)
+	if err != nil {
+		acc.Addf("error loading locked principals: %v", err)
+		return
+	}
+
+	var lockedPrincipalsCid cbg.CborCid
+	err = lockedPrincipalMap.ForEach(&lockedPrincipalsCid, func(key string) error {
+		staker, err := addr.NewFromBytes([]byte(key))
+		if err != nil {
+			return err
+		}
+		acc.Require(staker.Protocol() == addr.ID, "locked principal key %v is not an ID address", staker)
+
+		lockedPrincipals, found, err := st.LoadLockedPrincipals(store, lockedPrincipalMap, staker)
+		if err != nil || !found {
+			acc.Addf("error loading locked principals for %v: %v", staker, err)
+			return nil
+		}
+
+		total := big.Zero()
+		prevEpoch := abi.ChainEpoch(-1)
+		for i, lp := range lockedPrincipals.Data {
+			acc.Require(lp.Amount.GreaterThan(big.Zero()),
+				"locked principal %d for %v is not positive %v", i, staker, lp.Amount)
+			acc.Require(lp.Epoch >= prevEpoch,
+				"locked principals for %v are not sorted by epoch: %d after %d", staker, lp.Epoch, prevEpoch)
+			prevEpoch = lp.Epoch
+			total = big.Add(total, lp.Amount)
+		}
+		principals[staker] = big.Add(principalOf(principals, staker), total)
+		summary.TotalLockedPrincipal = big.Add(summary.TotalLockedPrincipal, total)
+		return nil
+	})
+	acc.RequireNoError(err, "error iterating locked principals")
+}
+
+func CheckAvailablePrincipals(st *State, store adt.Store, principals map[addr.Address]abi.TokenAmount, summary *StateSummary, acc *builtin.MessageAccumulator) {
+	availablePrincipalMap, err := adt.AsMap(store, st.AvailablePrincipalMap, builtin.DefaultHamtBitwidth)
+	if err != nil {
+		acc.Addf("error loading available principals: %v", err)
+		return
+	}
+
+	var amount abi.TokenAmount
+	err = availablePrincipalMap.ForEach(&amount, func(key string) error {
+		staker, err := addr.NewFromBytes([]byte(key))
+		if err != nil {
+			return err
+		}
+		acc.Require(staker.Protocol() == addr.ID, "available principal key %v is not an ID address", staker)
+		acc.Require(amount.GreaterThanEqual(big.Zero()), "available principal for %v is negative %v", staker, amount)
+
+		principals[staker] = big.Add(principalOf(principals, staker), amount)
+		summary.TotalAvailablePrincipal = big.Add(summary.TotalAvailablePrincipal, amount)
+		return nil
+	})
+	acc.RequireNoError(err, "error iterating available principals")
+}
+
+func CheckStakePowers(st *State, store adt.Store, principals map[addr.Address]abi.TokenAmount, summary *StateSummary, acc *builtin.MessageAccumulator) {
+	stakePowerMap, err := adt.AsMap(store, st.StakePowerMap, builtin.DefaultHamtBitwidth)
+	if err != nil {
+		acc.Addf("error loading stake powers: %v", err)
+		return
+	}
+
+	totalPower := big.Zero()
+	var power abi.StakePower
+	err = stakePowerMap.ForEach(&power, func(key string) error {
+		staker, err := addr.NewFromBytes([]byte(key))
+		if err != nil {
+			return err
+		}
+		acc.Require(power.GreaterThanEqual(big.Zero()), "stake power for %v is negative %v", staker, power)
+		principal := principalOf(principals, staker)
+		acc.Require(power.LessThanEqual(principal),
+			"stake power %v for %v exceeds its principal %v", power, staker, principal)
+
+		summary.StakePowers[staker] = power
+		totalPower = big.Add(totalPower, power)
+		return nil
+	})
+	acc.RequireNoError(err, "error iterating stake powers")
+
+	acc.Require(totalPower.Equals(st.TotalStakePower),
+		"sum of stake powers %v does not match recorded total stake power %v", totalPower, st.TotalStakePower)
+}
+
+// Checks every reward vesting spec is valid and the current one is present, returning the keys of all of them.
+func CheckRewardVestingSpecs(st *State, store adt.Store, acc *builtin.MessageAccumulator) map[uint64]struct{} {
+	keys := make(map[uint64]struct{})
+	vestSpecs, err := adt.AsArray(store, st.RewardVestingSpecs, RewardVestingSpecsAmtBitwidth)
+	if err != nil {
+		acc.Addf("error loading reward vesting specs: %v", err)
+		return keys
+	}
+
+	var spec miner.VestSpec
+	err = vestSpecs.ForEach(&spec, func(key int64) error {
+		acc.RequireNoError(ValidateVestSpec(&spec), "invalid reward vesting spec %d", key)
+		keys[uint64(key)] = struct{}{}
+		return nil
+	})
+	acc.RequireNoError(err, "error iterating reward vesting specs")
+
+	_, found := keys[st.CurrentRewardVestingSpec]
+	acc.Require(found, "current reward vesting spec %d not found", st.CurrentRewardVestingSpec)
+	return keys
+}
+
+func CheckVestingRewards(st *State, store adt.Store, vestSpecs map[uint64]struct{}, summary *StateSummary, acc *builtin.MessageAccumulator) {
+	vestingRewardMap, err := adt.AsMap(store, st.VestingRewardMap, builtin.DefaultHamtBitwidth)
+	if err != nil {
+		acc.Addf("error loading vesting rewards: %v", err)
+		return
+	}
+
+	var vestingFundsCid cbg.CborCid
+	err = vestingRewardMap.ForEach(&vestingFundsCid, func(key string) error {
+		staker, err := addr.NewFromBytes([]byte(key))
+		if err != nil {
+			return err
+		}
+
+		vestingFunds, found, err := st.LoadVestingFunds(store, vestingRewardMap, staker)
+		if err != nil || !found {
+			acc.Addf("error loading vesting funds for %v: %v", staker, err)
+			return nil
+		}
+
+		prevEpoch := abi.ChainEpoch(-1)
+		prevSpec := uint64(0)
+		for i, vf := range vestingFunds.Funds {
+			acc.Require(vf.Amount.GreaterThanEqual(big.Zero()),
+				"vesting fund at epoch %d for %v is negative %v", vf.Epoch, staker, vf.Amount)
+			_, knownSpec := vestSpecs[vf.Spec]
+			acc.Require(knownSpec,
+				"vesting fund at epoch %d for %v has unknown vesting spec %d", vf.Epoch, staker, vf.Spec)
+			acc.Require(i == 0 || vf.Epoch > prevEpoch || (vf.Epoch == prevEpoch && vf.Spec > prevSpec),
+				"vesting funds for %v are not strictly sorted by epoch and spec: (%d, %d) after (%d, %d)",
+				staker, vf.Epoch, vf.Spec, prevEpoch, prevSpec)
+			prevEpoch = vf.Epoch
+			prevSpec = vf.Spec
+			summary.TotalVestingReward = big.Add(summary.TotalVestingReward, vf.Amount)
+		}
+		return nil
+	})
+	acc.RequireNoError(err, "error iterating vesting rewards")
+}
+
+func CheckAvailableRewards(st *State, store adt.Store, summary *StateSummary, acc *builtin.MessageAccumulator) {
+	availableRewardMap, err := adt.AsMap(store, st.AvailableRewardMap, builtin.DefaultHamtBitwidth)
+	if err != nil {
+		acc.Addf("error loading available rewards: %v", err)
+		return
+	}
+
+	var amount abi.TokenAmount
+	err = availableRewardMap.ForEach(&amount, func(key string) error {
+		staker, err := addr.NewFromBytes([]byte(key))
+		if err != nil {
+			return err
+		}
+		acc.Require(amount.GreaterThanEqual(big.Zero()), "available reward for %v is negative %v", staker, amount)
+		summary.TotalAvailableReward = big.Add(summary.TotalAvailableReward, amount)
+		return nil
+	})
+	acc.RequireNoError(err, "error iterating available rewards")
+}
+
+func CheckStakerEventQueue(st *State, store adt.Store, acc *builtin.MessageAccumulator) {
+	queue, err := adt.AsMultimap(store, st.StakerEventQueue, StakerQueueHamtBitwidth, StakerQueueAmtBitwidth)
+	if err != nil {
+		acc.Addf("error loading staker event queue: %v", err)
+		return
+	}
+
+	firstQueued := make(map[addr.Address]abi.ChainEpoch)
+	err = queue.ForAll(func(ekey string, arr *adt.Array) error {
+		epoch, err := abi.ParseIntKey(ekey)
+		acc.Require(err == nil, "non-int key in staker event queue")
+		if err != nil {
+			return nil // error noted above
+		}
+		acc.Require(abi.ChainEpoch(epoch) >= st.FirstEventEpoch, "staker event at epoch %d before FirstEventEpoch %d",
+			epoch, st.FirstEventEpoch)
+
+		var staker addr.Address
+		return arr.ForEach(&staker, func(i int64) error {
+			acc.Require(staker.Protocol() == addr.ID, "queued staker %v is not an ID address", staker)
+			if prev, ok := firstQueued[staker]; !ok || abi.ChainEpoch(epoch) < prev {
+				firstQueued[staker] = abi.ChainEpoch(epoch)
+			}
+			return nil
+		})
+	})
+	acc.RequireNoError(err, "error iterating staker event queue")
+
+	lockedPrincipalMap, err := adt.AsMap(store, st.LockedPrincipalMap, builtin.DefaultHamtBitwidth)
+	if err != nil {
+		acc.Addf("error loading locked principals: %v", err)
+		return
+	}
+	vestingRewardMap, err := adt.AsMap(store, st.VestingRewardMap, builtin.DefaultHamtBitwidth)
+	if err != nil {
+		acc.Addf("error loading vesting rewards: %v", err)
+		return
+	}
+	stakers := make(map[addr.Address]struct{})
+	for _, m := range []*adt.Map{lockedPrincipalMap, vestingRewardMap} {
+		keys, err := m.CollectKeys()
+		if err != nil {
+			acc.Addf("error collecting staker keys: %v", err)
+			return
+		}
+		for _, key := range keys {
+			staker, err := addr.NewFromBytes([]byte(key))
+			if err != nil {
+				acc.Addf("invalid staker key: %v", err)
+				continue
+			}
+			stakers[staker] = struct{}{}
+		}
+	}
+
+	// every staker must be queued no later than its next pending event
+	for staker := range stakers {
+		lockedPrincipals, _, err := st.LoadLockedPrincipals(store, lockedPrincipalMap, staker)
+		if err != nil {
+			acc.Addf("error loading locked principals for %v: %v", staker, err)
+			continue
+		}
+		vestingFunds, _, err := st.LoadVestingFunds(store, vestingRewardMap, staker)
+		if err != nil {
+			acc.Addf("error loading vesting funds for %v: %v", staker, err)
+			continue
+		}
+		next, ok := st.nextStakerEventEpoch(lockedPrincipals, vestingFunds, st.FirstEventEpoch-1)
+		if !ok {
+			continue
+		}
+		queued, found := firstQueued[staker]
+		acc.Require(found && queued <= next, "staker %v has an event due at %d but is first queued at %d (found %t)",
+			staker, next, queued, found)
+	}
+}
+
+func CheckDelegations(st *State, store adt.Store, acc *builtin.MessageAccumulator) {
+	delegations, err := adt.AsMap(store, st.Delegations, builtin.DefaultHamtBitwidth)
+	if err != nil {
+		acc.Addf("error loading delegations: %v", err)
+		return
+	}
+
+	var delegation Delegation
+	err = delegations.ForEach(&delegation, func(key string) error {
+		staker, err := addr.NewFromBytes([]byte(key))
+		if err != nil {
+			return err
+		}
+		acc.Require(staker.Protocol() == addr.ID, "delegation key %v is not an ID address", staker)
+		acc.Require(delegation.Depositor.Protocol() == addr.ID, "depositor %v for %v is not an ID address", delegation.Depositor, staker)
+		acc.Require(delegation.Depositor != staker, "staker %v is its own depositor", staker)
+		acc.Require(delegation.RewardReceiver.Protocol() == addr.ID, "reward receiver %v for %v is not an ID address", delegation.RewardReceiver, staker)
+		acc.Require(delegation.WithdrawPolicy.IsValid(), "invalid withdraw policy %d for %v", delegation.WithdrawPolicy, staker)
+		acc.Require(delegation.PendingWithdrawal.GreaterThanEqual(big.Zero()), "pending withdrawal for %v is negative %v", staker, delegation.PendingWithdrawal)
+		if delegation.WithdrawPolicy != WithdrawPolicyJoint {
+			acc.Require(delegation.PendingWithdrawal.IsZero(), "pending withdrawal %v for %v without joint withdraw policy", delegation.PendingWithdrawal, staker)
+		}
+		return nil
+	})
+	acc.RequireNoError(err, "error iterating delegations")
+}
+
+func CheckAutoCompound(st *State, store adt.Store, acc *builtin.MessageAccumulator) {
+	autoCompound, err := adt.AsSet(store, st.AutoCompound, builtin.DefaultHamtBitwidth)
+	if err != nil {
+		acc.Addf("error loading auto compound stakers: %v", err)
+		return
+	}
+	delegations, err := adt.AsMap(store, st.Delegations, builtin.DefaultHamtBitwidth)
+	if err != nil {
+		acc.Addf("error loading delegations: %v", err)
+		return
+	}
+
+	err = autoCompound.ForEach(func(key string) error {
+		staker, err := addr.NewFromBytes([]byte(key))
+		if err != nil {
+			return err
+		}
+		acc.Require(staker.Protocol() == addr.ID, "auto compound staker %v is not an ID address", staker)
+		_, delegated, err := st.LoadDelegation(delegations, staker)
+		if err != nil {
+			return err
+		}
+		acc.Require(!delegated, "auto compound staker %v holds delegated principal", staker)
+		return nil
+	})
+	acc.RequireNoError(err, "error iterating auto compound stakers")
+}
+
+func CheckSlashers(st *State, store adt.Store, acc *builtin.MessageAccumulator) {
+	slashers, err := adt.AsSet(store, st.Slashers, builtin.DefaultHamtBitwidth)
+	if err != nil {
+		acc.Addf("error loading slashers: %v", err)
+		return
+	}
+
+	err = slashers.ForEach(func(key string) error {
+		slasher, err := addr.NewFromBytes([]byte(key))
+		if err != nil {
+			return err
+		}
+		acc.Require(slasher.Protocol() == addr.ID, "slasher %v is not an ID address", slasher)
+		return nil
+	})
+	acc.RequireNoError(err, "error iterating slashers")
+}
+
+func CheckUnbondings(st *State, store adt.Store, summary *StateSummary, acc *builtin.MessageAccumulator) {
+	unbondingMap, err := adt.AsMap(store, st.UnbondingMap, builtin.DefaultHamtBitwidth)
+	if err != nil {
+		acc.Addf("error loading unbondings: %v", err)
+		return
+	}
+
+	var unbondingsCid cbg.CborCid
+	err = unbondingMap.ForEach(&unbondingsCid, func(key string) error {
+		staker, err := addr.NewFromBytes([]byte(key))
+		if err != nil {
+			return err
+		}
+
+		unbondings, found, err := st.LoadUnbondings(store, unbondingMap, staker)
+		if err != nil || !found {
+			acc.Addf("error loading unbondings for %v: %v", staker, err)
+			return nil
+		}
+
+		acc.Require(len(unbondings.Data) > 0, "empty unbondings recorded for %v", staker)
+		for _, ub := range unbondings.Data {
+			acc.Require(ub.Amount.GreaterThan(big.Zero()),
+				"unbonding at epoch %d for %v is not positive %v", ub.ReleaseEpoch, staker, ub.Amount)
+			acc.Require(ub.Recipient.Protocol() == addr.ID,
+				"unbonding recipient %v for %v is not an ID address", ub.Recipient, staker)
+		}
+		summary.TotalUnbondingPrincipal = big.Add(summary.TotalUnbondingPrincipal, unbondings.total())
+		return nil
+	})
+	acc.RequireNoError(err, "error iterating unbondings")
+}
+
+func CheckProposals(st *State, store adt.Store, acc *builtin.MessageAccumulator) {
+	proposals, err := adt.AsMap(store, st.Proposals, builtin.DefaultHamtBitwidth)
+	if err != nil {
+		acc.Addf("error loading proposals: %v", err)
+		return
+	}
+
+	var proposal Proposal
+	err = proposals.ForEach(&proposal, func(key string) error {
+		id, err := abi.ParseIntKey(key)
+		if err != nil {
+			return err
+		}
+		acc.Require(id >= 0 && ProposalID(id) < st.NextProposalID,
+			"proposal id %d is outside of [0, %d)", id, st.NextProposalID)
+		return nil
+	})
+	acc.RequireNoError(err, "error iterating proposals")
+}
+
+func principalOf(principals map[addr.Address]abi.TokenAmount, staker addr.Address) abi.TokenAmount {
+	if amount, ok := principals[staker]; ok {
+		return amount
+	}
+	return big.Zero()
+}
@@ -0,0 +1,121 @@
+package stake
+
+import (
+	addr "github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/specs-actors/v3/actors/builtin"
+	cbg "github.com/whyrusleeping/cbor-gen"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/specs-actors/v3/actors/util/adt"
+	cid "github.com/ipfs/go-cid"
+)
+
+// Unbonding is withdrawn principal that carries no power and may be claimed by its recipient from ReleaseEpoch.
+type Unbonding struct {
+	Amount       abi.TokenAmount
+	ReleaseEpoch abi.ChainEpoch
+	Recipient    addr.Address
+}
+
+// Unbondings is the queue of a staker's withdrawn principal, in order of withdrawal.
+type Unbondings struct {
+	Data []Unbonding
+}
+
+// ConstructUnbondings constructs an empty unbonding queue.
+func ConstructUnbondings() *Unbondings {
+	u := new(Unbondings)
+	u.Data = nil
+	return u
+}
+
+func (u *Unbondings) addUnbonding(amount abi.TokenAmount, releaseEpoch abi.ChainEpoch, recipient addr.Address) {
+	u.Data = append(u.Data, Unbonding{
+		Amount:       amount,
+		ReleaseEpoch: releaseEpoch,
+		Recipient:    recipient,
+	})
+}
+
+// Removes and returns the entries released at or before currEpoch.
+// Entries are not necessarily ordered by release epoch, since the unbonding period may change.
+func (u *Unbondings) releaseUnbonded(currEpoch abi.ChainEpoch) []Unbonding {
+	var released, kept []Unbonding
+	for _, ub := range u.Data {
+		if ub.ReleaseEpoch <= currEpoch {
+			released = append(released, ub)
+		} else {
+			kept = append(kept, ub)
+		}
+	}
+	u.Data = kept
+	return released
+}
+
+func (u *Unbondings) total() abi.TokenAmount {
+	total := big.Zero()
+	for _, ub := range u.Data {
+		total = big.Add(total, ub.Amount)
+	}
+	return total
+}
+
+func (st *State) LoadUnbondings(store adt.Store, unbondingMap *adt.Map, staker addr.Address) (*Unbondings, bool, error) {
+	var unbondingsCborCid cbg.CborCid
+	found, err := unbondingMap.Get(abi.AddrKey(staker), &unbondingsCborCid)
+	if err != nil {
+		return nil, found, xerrors.Errorf("failed to get unbondings cid for %v: %w", staker, err)
+	}
+	if !found {
+		return nil, found, nil
+	}
+	var unbondings Unbondings
+	if err = store.Get(store.Context(), cid.Cid(unbondingsCborCid), &unbondings); err != nil {
+		return nil, found, xerrors.Errorf("failed to load unbondings for %v: %w", staker, err)
+	}
+	return &unbondings, found, nil
+}
+
+// Stores the unbonding queue of a staker, removing the entry when the queue is empty.
+func (st *State) putUnbondings(store adt.Store, unbondingMap *adt.Map, staker addr.Address, unbondings *Unbondings) error {
+	if len(unbondings.Data) == 0 {
+		if _, err := unbondingMap.TryDelete(abi.AddrKey(staker)); err != nil {
+			return xerrors.Errorf("failed to delete unbondings for %v: %w", staker, err)
+		}
+		return nil
+	}
+	unbondingsCid, err := store.Put(store.Context(), unbondings)
+	if err != nil {
+		return xerrors.Errorf("failed to save unbondings for %v: %w", staker, err)
+	}
+	unbondingsCborCid := cbg.CborCid(unbondingsCid)
+	if err = unbondingMap.Put(abi.AddrKey(staker), &unbondingsCborCid); err != nil {
+		return xerrors.Errorf("failed to put unbondings for %v: %w", staker, err)
+	}
+	return nil
+}
+
+// Queues amount of a staker's withdrawn principal for recipient, claimable once the unbonding period has passed.
+func (st *State) addUnbonding(store adt.Store, staker addr.Address, amount abi.TokenAmount, recipient addr.Address, currEpoch abi.ChainEpoch) error {
+	unbondingMap, err := adt.AsMap(store, st.UnbondingMap, builtin.DefaultHamtBitwidth)
+	if err != nil {
+		return xerrors.Errorf("failed to load unbondings: %w", err)
+	}
+	unbondings, found, err := st.LoadUnbondings(store, unbondingMap, staker)
+	if err != nil {
+		return err
+	}
+	if !found {
+		unbondings = ConstructUnbondings()
+	}
+	unbondings.addUnbonding(amount, currEpoch+st.UnbondingPeriod, recipient)
+	if err = st.putUnbondings(store, unbondingMap, staker, unbondings); err != nil {
+		return err
+	}
+	if st.UnbondingMap, err = unbondingMap.Root(); err != nil {
+		return xerrors.Errorf("failed to flush unbondings: %w", err)
+	}
+	return nil
+}
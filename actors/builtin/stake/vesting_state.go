@@ -3,9 +3,9 @@ package stake
 import (
 	"github.com/filecoin-project/go-state-types/abi"
 	"github.com/filecoin-project/go-state-types/big"
-	stake2 "github.com/filecoin-project/specs-actors/v2/actors/builtin/stake"
 	"github.com/filecoin-project/specs-actors/v3/actors/builtin"
 	"github.com/filecoin-project/specs-actors/v3/actors/builtin/miner"
+	"golang.org/x/xerrors"
 	"sort"
 )
 
@@ -30,19 +30,42 @@ func quantizeUp(e abi.ChainEpoch, unit abi.ChainEpoch, offsetSeed abi.ChainEpoch
 	return unit*(quotient+1) + offset
 }
 
-// The vesting schedule for total rewards (block reward + gas reward) earned by a block producer.
-var RewardVestingSpec = miner.VestSpec{ // PARAM_SPEC
+// The vesting schedule for rewards earned by stakers, until the root key changes it.
+var DefaultRewardVestingSpec = miner.VestSpec{ // PARAM_SPEC
 	InitialDelay: abi.ChainEpoch(0),
 	VestPeriod:   abi.ChainEpoch(180 * builtin.EpochsInDay),
 	StepDuration: abi.ChainEpoch(1 * builtin.EpochsInDay),
 	Quantization: 12 * builtin.EpochsInHour,
 }
 
-type VestingFund = stake2.VestingFund
+// VestingFund is an amount of reward vesting at an epoch, under the vesting spec it was created with.
+type VestingFund struct {
+	Epoch  abi.ChainEpoch
+	Amount abi.TokenAmount
+	// Key of the vesting spec in State.RewardVestingSpecs.
+	Spec uint64
+}
 
-// VestingFunds represents the vesting table state for the miner.
-// It is a slice of (VestingEpoch, VestingAmount).
-// The slice will always be sorted by the VestingEpoch.
+// Checks that a vesting spec schedules every reward to vest in finite time.
+func ValidateVestSpec(spec *miner.VestSpec) error {
+	if spec.InitialDelay < 0 {
+		return xerrors.Errorf("invalid initial delay: %d", spec.InitialDelay)
+	}
+	if spec.VestPeriod < 0 {
+		return xerrors.Errorf("invalid vest period: %d", spec.VestPeriod)
+	}
+	if spec.StepDuration <= 0 {
+		return xerrors.Errorf("invalid step duration: %d", spec.StepDuration)
+	}
+	if spec.Quantization <= 0 {
+		return xerrors.Errorf("invalid quantization: %d", spec.Quantization)
+	}
+	return nil
+}
+
+// VestingFunds represents the vesting table state for a staker.
+// It is a slice of (VestingEpoch, VestingAmount, VestSpec).
+// The slice will always be sorted by the VestingEpoch, then by the VestSpec.
 type VestingFunds struct {
 	Funds []VestingFund
 }
@@ -68,12 +91,16 @@ func (v *VestingFunds) unlockVestedFunds(currEpoch abi.ChainEpoch) abi.TokenAmou
 	return amountUnlocked
 }
 
+// Adds vestingSum to vest under spec, recorded as the spec at specKey.
+// Funds vesting at the same epoch under different specs are kept apart.
 func (v *VestingFunds) addLockedFunds(currEpoch abi.ChainEpoch, vestingSum abi.TokenAmount,
-	stakePeriodStart abi.ChainEpoch, spec *miner.VestSpec) {
-	// maps the epochs in VestingFunds to their indices in the slice
+	stakePeriodStart abi.ChainEpoch, spec *miner.VestSpec, specKey uint64) {
+	// maps the epochs in VestingFunds vesting under spec to their indices in the slice
 	epochToIndex := make(map[abi.ChainEpoch]int, len(v.Funds))
 	for i, vf := range v.Funds {
-		epochToIndex[vf.Epoch] = i
+		if vf.Spec == specKey {
+			epochToIndex[vf.Epoch] = i
+		}
 	}
 
 	// Quantization is aligned with when regular cron will be invoked, in the last epoch of deadlines.
@@ -102,15 +129,18 @@ func (v *VestingFunds) addLockedFunds(currEpoch abi.ChainEpoch, vestingSum abi.T
 			v.Funds[index].Amount = big.Add(currentAmt, vestThisTime)
 		} else {
 			// append a new entry -> slice will be sorted by epoch later.
-			entry := VestingFund{Epoch: vestEpoch, Amount: vestThisTime}
+			entry := VestingFund{Epoch: vestEpoch, Amount: vestThisTime, Spec: specKey}
 			v.Funds = append(v.Funds, entry)
 			epochToIndex[vestEpoch] = len(v.Funds) - 1
 		}
 	}
 
-	// sort slice by epoch
+	// sort slice by epoch, then spec
 	sort.Slice(v.Funds, func(first, second int) bool {
-		return v.Funds[first].Epoch < v.Funds[second].Epoch
+		if v.Funds[first].Epoch != v.Funds[second].Epoch {
+			return v.Funds[first].Epoch < v.Funds[second].Epoch
+		}
+		return v.Funds[first].Spec < v.Funds[second].Spec
 	})
 }
 
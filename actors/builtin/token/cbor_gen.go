@@ -7,6 +7,7 @@ import (
 	"io"
 
 	address "github.com/filecoin-project/go-address"
+	abi "github.com/filecoin-project/go-state-types/abi"
 	big "github.com/filecoin-project/go-state-types/big"
 	cbg "github.com/whyrusleeping/cbor-gen"
 	xerrors "golang.org/x/xerrors"
@@ -14,7 +15,7 @@ import (
 
 var _ = xerrors.Errorf
 
-var lengthBufState = []byte{133}
+var lengthBufState = []byte{141}
 
 func (t *State) MarshalCBOR(w io.Writer) error {
 	if t == nil {
@@ -56,6 +57,54 @@ func (t *State) MarshalCBOR(w io.Writer) error {
 		return xerrors.Errorf("failed to write cid field t.Approves: %w", err)
 	}
 
+	// t.Supplies (cid.Cid) (struct)
+
+	if err := cbg.WriteCidBuf(scratch, w, t.Supplies); err != nil {
+		return xerrors.Errorf("failed to write cid field t.Supplies: %w", err)
+	}
+
+	// t.Allowances (cid.Cid) (struct)
+
+	if err := cbg.WriteCidBuf(scratch, w, t.Allowances); err != nil {
+		return xerrors.Errorf("failed to write cid field t.Allowances: %w", err)
+	}
+
+	// t.Events (cid.Cid) (struct)
+
+	if err := cbg.WriteCidBuf(scratch, w, t.Events); err != nil {
+		return xerrors.Errorf("failed to write cid field t.Events: %w", err)
+	}
+
+	// t.Metadata (cid.Cid) (struct)
+
+	if err := cbg.WriteCidBuf(scratch, w, t.Metadata); err != nil {
+		return xerrors.Errorf("failed to write cid field t.Metadata: %w", err)
+	}
+
+	// t.PendingCreators (cid.Cid) (struct)
+
+	if err := cbg.WriteCidBuf(scratch, w, t.PendingCreators); err != nil {
+		return xerrors.Errorf("failed to write cid field t.PendingCreators: %w", err)
+	}
+
+	// t.Minters (cid.Cid) (struct)
+
+	if err := cbg.WriteCidBuf(scratch, w, t.Minters); err != nil {
+		return xerrors.Errorf("failed to write cid field t.Minters: %w", err)
+	}
+
+	// t.Holdings (cid.Cid) (struct)
+
+	if err := cbg.WriteCidBuf(scratch, w, t.Holdings); err != nil {
+		return xerrors.Errorf("failed to write cid field t.Holdings: %w", err)
+	}
+
+	// t.Minted (cid.Cid) (struct)
+
+	if err := cbg.WriteCidBuf(scratch, w, t.Minted); err != nil {
+		return xerrors.Errorf("failed to write cid field t.Minted: %w", err)
+	}
+
 	return nil
 }
 
@@ -73,7 +122,7 @@ func (t *State) UnmarshalCBOR(r io.Reader) error {
 		return fmt.Errorf("cbor input should be of type array")
 	}
 
-	if extra != 5 {
+	if extra != 13 {
 		return fmt.Errorf("cbor input had wrong number of fields")
 	}
 
@@ -133,6 +182,102 @@ func (t *State) UnmarshalCBOR(r io.Reader) error {
 
 		t.Approves = c
 
+	}
+	// t.Supplies (cid.Cid) (struct)
+
+	{
+
+		c, err := cbg.ReadCid(br)
+		if err != nil {
+			return xerrors.Errorf("failed to read cid field t.Supplies: %w", err)
+		}
+
+		t.Supplies = c
+
+	}
+	// t.Allowances (cid.Cid) (struct)
+
+	{
+
+		c, err := cbg.ReadCid(br)
+		if err != nil {
+			return xerrors.Errorf("failed to read cid field t.Allowances: %w", err)
+		}
+
+		t.Allowances = c
+
+	}
+	// t.Events (cid.Cid) (struct)
+
+	{
+
+		c, err := cbg.ReadCid(br)
+		if err != nil {
+			return xerrors.Errorf("failed to read cid field t.Events: %w", err)
+		}
+
+		t.Events = c
+
+	}
+	// t.Metadata (cid.Cid) (struct)
+
+	{
+
+		c, err := cbg.ReadCid(br)
+		if err != nil {
+			return xerrors.Errorf("failed to read cid field t.Metadata: %w", err)
+		}
+
+		t.Metadata = c
+
+	}
+	// t.PendingCreators (cid.Cid) (struct)
+
+	{
+
+		c, err := cbg.ReadCid(br)
+		if err != nil {
+			return xerrors.Errorf("failed to read cid field t.PendingCreators: %w", err)
+		}
+
+		t.PendingCreators = c
+
+	}
+	// t.Minters (cid.Cid) (struct)
+
+	{
+
+		c, err := cbg.ReadCid(br)
+		if err != nil {
+			return xerrors.Errorf("failed to read cid field t.Minters: %w", err)
+		}
+
+		t.Minters = c
+
+	}
+	// t.Holdings (cid.Cid) (struct)
+
+	{
+
+		c, err := cbg.ReadCid(br)
+		if err != nil {
+			return xerrors.Errorf("failed to read cid field t.Holdings: %w", err)
+		}
+
+		t.Holdings = c
+
+	}
+	// t.Minted (cid.Cid) (struct)
+
+	{
+
+		c, err := cbg.ReadCid(br)
+		if err != nil {
+			return xerrors.Errorf("failed to read cid field t.Minted: %w", err)
+		}
+
+		t.Minted = c
+
 	}
 	return nil
 }
@@ -305,40 +450,46 @@ func (t *TokenURI) UnmarshalCBOR(r io.Reader) error {
 	return nil
 }
 
-var lengthBufCreateTokenParams = []byte{130}
+var lengthBufTransferEvent = []byte{133}
 
-func (t *CreateTokenParams) MarshalCBOR(w io.Writer) error {
+func (t *TransferEvent) MarshalCBOR(w io.Writer) error {
 	if t == nil {
 		_, err := w.Write(cbg.CborNull)
 		return err
 	}
-	if _, err := w.Write(lengthBufCreateTokenParams); err != nil {
+	if _, err := w.Write(lengthBufTransferEvent); err != nil {
 		return err
 	}
 
-	scratch := make([]byte, 9)
+	// t.Operator (address.Address) (struct)
+	if err := t.Operator.MarshalCBOR(w); err != nil {
+		return err
+	}
 
-	// t.ValueInit (big.Int) (struct)
-	if err := t.ValueInit.MarshalCBOR(w); err != nil {
+	// t.From (address.Address) (struct)
+	if err := t.From.MarshalCBOR(w); err != nil {
 		return err
 	}
 
-	// t.TokenURI (string) (string)
-	if len(t.TokenURI) > cbg.MaxLength {
-		return xerrors.Errorf("Value in field t.TokenURI was too long")
+	// t.To (address.Address) (struct)
+	if err := t.To.MarshalCBOR(w); err != nil {
+		return err
 	}
 
-	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajTextString, uint64(len(t.TokenURI))); err != nil {
+	// t.TokenID (big.Int) (struct)
+	if err := t.TokenID.MarshalCBOR(w); err != nil {
 		return err
 	}
-	if _, err := io.WriteString(w, string(t.TokenURI)); err != nil {
+
+	// t.Value (big.Int) (struct)
+	if err := t.Value.MarshalCBOR(w); err != nil {
 		return err
 	}
 	return nil
 }
 
-func (t *CreateTokenParams) UnmarshalCBOR(r io.Reader) error {
-	*t = CreateTokenParams{}
+func (t *TransferEvent) UnmarshalCBOR(r io.Reader) error {
+	*t = TransferEvent{}
 
 	br := cbg.GetPeeker(r)
 	scratch := make([]byte, 8)
@@ -351,82 +502,97 @@ func (t *CreateTokenParams) UnmarshalCBOR(r io.Reader) error {
 		return fmt.Errorf("cbor input should be of type array")
 	}
 
-	if extra != 2 {
+	if extra != 5 {
 		return fmt.Errorf("cbor input had wrong number of fields")
 	}
 
-	// t.ValueInit (big.Int) (struct)
+	// t.Operator (address.Address) (struct)
 
 	{
 
-		if err := t.ValueInit.UnmarshalCBOR(br); err != nil {
-			return xerrors.Errorf("unmarshaling t.ValueInit: %w", err)
+		if err := t.Operator.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.Operator: %w", err)
 		}
 
 	}
-	// t.TokenURI (string) (string)
+	// t.From (address.Address) (struct)
 
 	{
-		sval, err := cbg.ReadStringBuf(br, scratch)
-		if err != nil {
-			return err
+
+		if err := t.From.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.From: %w", err)
+		}
+
+	}
+	// t.To (address.Address) (struct)
+
+	{
+
+		if err := t.To.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.To: %w", err)
+		}
+
+	}
+	// t.TokenID (big.Int) (struct)
+
+	{
+
+		if err := t.TokenID.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.TokenID: %w", err)
+		}
+
+	}
+	// t.Value (big.Int) (struct)
+
+	{
+
+		if err := t.Value.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.Value: %w", err)
 		}
 
-		t.TokenURI = string(sval)
 	}
 	return nil
 }
 
-var lengthBufMintBatchTokenParams = []byte{131}
+var lengthBufURIEvent = []byte{131}
 
-func (t *MintBatchTokenParams) MarshalCBOR(w io.Writer) error {
+func (t *URIEvent) MarshalCBOR(w io.Writer) error {
 	if t == nil {
 		_, err := w.Write(cbg.CborNull)
 		return err
 	}
-	if _, err := w.Write(lengthBufMintBatchTokenParams); err != nil {
+	if _, err := w.Write(lengthBufURIEvent); err != nil {
 		return err
 	}
 
 	scratch := make([]byte, 9)
 
-	// t.TokenID (big.Int) (struct)
-	if err := t.TokenID.MarshalCBOR(w); err != nil {
+	// t.Operator (address.Address) (struct)
+	if err := t.Operator.MarshalCBOR(w); err != nil {
 		return err
 	}
 
-	// t.AddrTos ([]address.Address) (slice)
-	if len(t.AddrTos) > cbg.MaxLength {
-		return xerrors.Errorf("Slice value in field t.AddrTos was too long")
-	}
-
-	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajArray, uint64(len(t.AddrTos))); err != nil {
+	// t.TokenID (big.Int) (struct)
+	if err := t.TokenID.MarshalCBOR(w); err != nil {
 		return err
 	}
-	for _, v := range t.AddrTos {
-		if err := v.MarshalCBOR(w); err != nil {
-			return err
-		}
-	}
 
-	// t.Values ([]big.Int) (slice)
-	if len(t.Values) > cbg.MaxLength {
-		return xerrors.Errorf("Slice value in field t.Values was too long")
+	// t.URI (string) (string)
+	if len(t.URI) > cbg.MaxLength {
+		return xerrors.Errorf("Value in field t.URI was too long")
 	}
 
-	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajArray, uint64(len(t.Values))); err != nil {
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajTextString, uint64(len(t.URI))); err != nil {
 		return err
 	}
-	for _, v := range t.Values {
-		if err := v.MarshalCBOR(w); err != nil {
-			return err
-		}
+	if _, err := io.WriteString(w, string(t.URI)); err != nil {
+		return err
 	}
 	return nil
 }
 
-func (t *MintBatchTokenParams) UnmarshalCBOR(r io.Reader) error {
-	*t = MintBatchTokenParams{}
+func (t *URIEvent) UnmarshalCBOR(r io.Reader) error {
+	*t = URIEvent{}
 
 	br := cbg.GetPeeker(r)
 	scratch := make([]byte, 8)
@@ -443,101 +609,70 @@ func (t *MintBatchTokenParams) UnmarshalCBOR(r io.Reader) error {
 		return fmt.Errorf("cbor input had wrong number of fields")
 	}
 
-	// t.TokenID (big.Int) (struct)
+	// t.Operator (address.Address) (struct)
 
 	{
 
-		if err := t.TokenID.UnmarshalCBOR(br); err != nil {
-			return xerrors.Errorf("unmarshaling t.TokenID: %w", err)
+		if err := t.Operator.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.Operator: %w", err)
 		}
 
 	}
-	// t.AddrTos ([]address.Address) (slice)
-
-	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
-	if err != nil {
-		return err
-	}
-
-	if extra > cbg.MaxLength {
-		return fmt.Errorf("t.AddrTos: array too large (%d)", extra)
-	}
-
-	if maj != cbg.MajArray {
-		return fmt.Errorf("expected cbor array")
-	}
-
-	if extra > 0 {
-		t.AddrTos = make([]address.Address, extra)
-	}
+	// t.TokenID (big.Int) (struct)
 
-	for i := 0; i < int(extra); i++ {
+	{
 
-		var v address.Address
-		if err := v.UnmarshalCBOR(br); err != nil {
-			return err
+		if err := t.TokenID.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.TokenID: %w", err)
 		}
 
-		t.AddrTos[i] = v
-	}
-
-	// t.Values ([]big.Int) (slice)
-
-	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
-	if err != nil {
-		return err
-	}
-
-	if extra > cbg.MaxLength {
-		return fmt.Errorf("t.Values: array too large (%d)", extra)
-	}
-
-	if maj != cbg.MajArray {
-		return fmt.Errorf("expected cbor array")
-	}
-
-	if extra > 0 {
-		t.Values = make([]big.Int, extra)
 	}
+	// t.URI (string) (string)
 
-	for i := 0; i < int(extra); i++ {
-
-		var v big.Int
-		if err := v.UnmarshalCBOR(br); err != nil {
+	{
+		sval, err := cbg.ReadStringBuf(br, scratch)
+		if err != nil {
 			return err
 		}
 
-		t.Values[i] = v
+		t.URI = string(sval)
 	}
-
 	return nil
 }
 
-var lengthBufBalanceOfParams = []byte{130}
+var lengthBufTokenEvent = []byte{131}
 
-func (t *BalanceOfParams) MarshalCBOR(w io.Writer) error {
+func (t *TokenEvent) MarshalCBOR(w io.Writer) error {
 	if t == nil {
 		_, err := w.Write(cbg.CborNull)
 		return err
 	}
-	if _, err := w.Write(lengthBufBalanceOfParams); err != nil {
+	if _, err := w.Write(lengthBufTokenEvent); err != nil {
 		return err
 	}
 
-	// t.AddrOwner (address.Address) (struct)
-	if err := t.AddrOwner.MarshalCBOR(w); err != nil {
+	scratch := make([]byte, 9)
+
+	// t.Kind (token.EventKind) (uint64)
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.Kind)); err != nil {
 		return err
 	}
 
-	// t.TokenID (big.Int) (struct)
-	if err := t.TokenID.MarshalCBOR(w); err != nil {
+	// t.Transfer (token.TransferEvent) (struct)
+	if err := t.Transfer.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.URIChange (token.URIEvent) (struct)
+	if err := t.URIChange.MarshalCBOR(w); err != nil {
 		return err
 	}
 	return nil
 }
 
-func (t *BalanceOfParams) UnmarshalCBOR(r io.Reader) error {
-	*t = BalanceOfParams{}
+func (t *TokenEvent) UnmarshalCBOR(r io.Reader) error {
+	*t = TokenEvent{}
 
 	br := cbg.GetPeeker(r)
 	scratch := make([]byte, 8)
@@ -550,51 +685,96 @@ func (t *BalanceOfParams) UnmarshalCBOR(r io.Reader) error {
 		return fmt.Errorf("cbor input should be of type array")
 	}
 
-	if extra != 2 {
+	if extra != 3 {
 		return fmt.Errorf("cbor input had wrong number of fields")
 	}
 
-	// t.AddrOwner (address.Address) (struct)
+	// t.Kind (token.EventKind) (uint64)
 
 	{
 
-		if err := t.AddrOwner.UnmarshalCBOR(br); err != nil {
-			return xerrors.Errorf("unmarshaling t.AddrOwner: %w", err)
+		maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+		if err != nil {
+			return err
 		}
+		if maj != cbg.MajUnsignedInt {
+			return fmt.Errorf("wrong type for uint64 field")
+		}
+		t.Kind = EventKind(extra)
 
 	}
-	// t.TokenID (big.Int) (struct)
+	// t.Transfer (token.TransferEvent) (struct)
 
 	{
 
-		if err := t.TokenID.UnmarshalCBOR(br); err != nil {
-			return xerrors.Errorf("unmarshaling t.TokenID: %w", err)
+		b, err := br.ReadByte()
+		if err != nil {
+			return err
+		}
+		if b != cbg.CborNull[0] {
+			if err := br.UnreadByte(); err != nil {
+				return err
+			}
+			t.Transfer = new(TransferEvent)
+			if err := t.Transfer.UnmarshalCBOR(br); err != nil {
+				return xerrors.Errorf("unmarshaling t.Transfer pointer: %w", err)
+			}
+		}
+
+	}
+	// t.URIChange (token.URIEvent) (struct)
+
+	{
+
+		b, err := br.ReadByte()
+		if err != nil {
+			return err
+		}
+		if b != cbg.CborNull[0] {
+			if err := br.UnreadByte(); err != nil {
+				return err
+			}
+			t.URIChange = new(URIEvent)
+			if err := t.URIChange.UnmarshalCBOR(br); err != nil {
+				return xerrors.Errorf("unmarshaling t.URIChange pointer: %w", err)
+			}
 		}
 
 	}
 	return nil
 }
 
-var lengthBufBalanceOfResults = []byte{129}
+var lengthBufEpochEvents = []byte{129}
 
-func (t *BalanceOfResults) MarshalCBOR(w io.Writer) error {
+func (t *EpochEvents) MarshalCBOR(w io.Writer) error {
 	if t == nil {
 		_, err := w.Write(cbg.CborNull)
 		return err
 	}
-	if _, err := w.Write(lengthBufBalanceOfResults); err != nil {
+	if _, err := w.Write(lengthBufEpochEvents); err != nil {
 		return err
 	}
 
-	// t.Balance (big.Int) (struct)
-	if err := t.Balance.MarshalCBOR(w); err != nil {
+	scratch := make([]byte, 9)
+
+	// t.Events ([]token.TokenEvent) (slice)
+	if len(t.Events) > cbg.MaxLength {
+		return xerrors.Errorf("Slice value in field t.Events was too long")
+	}
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajArray, uint64(len(t.Events))); err != nil {
 		return err
 	}
+	for _, v := range t.Events {
+		if err := v.MarshalCBOR(w); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
-func (t *BalanceOfResults) UnmarshalCBOR(r io.Reader) error {
-	*t = BalanceOfResults{}
+func (t *EpochEvents) UnmarshalCBOR(r io.Reader) error {
+	*t = EpochEvents{}
 
 	br := cbg.GetPeeker(r)
 	scratch := make([]byte, 8)
@@ -611,63 +791,95 @@ func (t *BalanceOfResults) UnmarshalCBOR(r io.Reader) error {
 		return fmt.Errorf("cbor input had wrong number of fields")
 	}
 
-	// t.Balance (big.Int) (struct)
+	// t.Events ([]token.TokenEvent) (slice)
 
-	{
+	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
 
-		if err := t.Balance.UnmarshalCBOR(br); err != nil {
-			return xerrors.Errorf("unmarshaling t.Balance: %w", err)
+	if extra > cbg.MaxLength {
+		return fmt.Errorf("t.Events: array too large (%d)", extra)
+	}
+
+	if maj != cbg.MajArray {
+		return fmt.Errorf("expected cbor array")
+	}
+
+	if extra > 0 {
+		t.Events = make([]TokenEvent, extra)
+	}
+
+	for i := 0; i < int(extra); i++ {
+
+		var v TokenEvent
+		if err := v.UnmarshalCBOR(br); err != nil {
+			return err
 		}
 
+		t.Events[i] = v
 	}
+
 	return nil
 }
 
-var lengthBufBalanceOfBatchParams = []byte{130}
+var lengthBufTokenMetadata = []byte{133}
 
-func (t *BalanceOfBatchParams) MarshalCBOR(w io.Writer) error {
+func (t *TokenMetadata) MarshalCBOR(w io.Writer) error {
 	if t == nil {
 		_, err := w.Write(cbg.CborNull)
 		return err
 	}
-	if _, err := w.Write(lengthBufBalanceOfBatchParams); err != nil {
+	if _, err := w.Write(lengthBufTokenMetadata); err != nil {
 		return err
 	}
 
 	scratch := make([]byte, 9)
 
-	// t.AddrOwners ([]address.Address) (slice)
-	if len(t.AddrOwners) > cbg.MaxLength {
-		return xerrors.Errorf("Slice value in field t.AddrOwners was too long")
+	// t.Name (string) (string)
+	if len(t.Name) > cbg.MaxLength {
+		return xerrors.Errorf("Value in field t.Name was too long")
 	}
 
-	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajArray, uint64(len(t.AddrOwners))); err != nil {
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajTextString, uint64(len(t.Name))); err != nil {
 		return err
 	}
-	for _, v := range t.AddrOwners {
-		if err := v.MarshalCBOR(w); err != nil {
-			return err
-		}
+	if _, err := io.WriteString(w, string(t.Name)); err != nil {
+		return err
 	}
 
-	// t.TokenIDs ([]big.Int) (slice)
-	if len(t.TokenIDs) > cbg.MaxLength {
-		return xerrors.Errorf("Slice value in field t.TokenIDs was too long")
+	// t.Symbol (string) (string)
+	if len(t.Symbol) > cbg.MaxLength {
+		return xerrors.Errorf("Value in field t.Symbol was too long")
 	}
 
-	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajArray, uint64(len(t.TokenIDs))); err != nil {
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajTextString, uint64(len(t.Symbol))); err != nil {
 		return err
 	}
-	for _, v := range t.TokenIDs {
-		if err := v.MarshalCBOR(w); err != nil {
-			return err
-		}
+	if _, err := io.WriteString(w, string(t.Symbol)); err != nil {
+		return err
+	}
+
+	// t.Decimals (uint64) (uint64)
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.Decimals)); err != nil {
+		return err
+	}
+
+	// t.NonFungible (bool) (bool)
+	if err := cbg.WriteBool(w, t.NonFungible); err != nil {
+		return err
+	}
+
+	// t.MaxSupply (big.Int) (struct)
+	if err := t.MaxSupply.MarshalCBOR(w); err != nil {
+		return err
 	}
 	return nil
 }
 
-func (t *BalanceOfBatchParams) UnmarshalCBOR(r io.Reader) error {
-	*t = BalanceOfBatchParams{}
+func (t *TokenMetadata) UnmarshalCBOR(r io.Reader) error {
+	*t = TokenMetadata{}
 
 	br := cbg.GetPeeker(r)
 	scratch := make([]byte, 8)
@@ -680,102 +892,107 @@ func (t *BalanceOfBatchParams) UnmarshalCBOR(r io.Reader) error {
 		return fmt.Errorf("cbor input should be of type array")
 	}
 
-	if extra != 2 {
+	if extra != 5 {
 		return fmt.Errorf("cbor input had wrong number of fields")
 	}
 
-	// t.AddrOwners ([]address.Address) (slice)
+	// t.Name (string) (string)
 
-	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
-	if err != nil {
-		return err
-	}
+	{
+		sval, err := cbg.ReadStringBuf(br, scratch)
+		if err != nil {
+			return err
+		}
 
-	if extra > cbg.MaxLength {
-		return fmt.Errorf("t.AddrOwners: array too large (%d)", extra)
+		t.Name = string(sval)
 	}
+	// t.Symbol (string) (string)
 
-	if maj != cbg.MajArray {
-		return fmt.Errorf("expected cbor array")
-	}
+	{
+		sval, err := cbg.ReadStringBuf(br, scratch)
+		if err != nil {
+			return err
+		}
 
-	if extra > 0 {
-		t.AddrOwners = make([]address.Address, extra)
+		t.Symbol = string(sval)
 	}
+	// t.Decimals (uint64) (uint64)
 
-	for i := 0; i < int(extra); i++ {
+	{
 
-		var v address.Address
-		if err := v.UnmarshalCBOR(br); err != nil {
+		maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+		if err != nil {
 			return err
 		}
+		if maj != cbg.MajUnsignedInt {
+			return fmt.Errorf("wrong type for uint64 field")
+		}
+		t.Decimals = uint64(extra)
 
-		t.AddrOwners[i] = v
 	}
-
-	// t.TokenIDs ([]big.Int) (slice)
+	// t.NonFungible (bool) (bool)
 
 	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
 		return err
 	}
-
-	if extra > cbg.MaxLength {
-		return fmt.Errorf("t.TokenIDs: array too large (%d)", extra)
-	}
-
-	if maj != cbg.MajArray {
-		return fmt.Errorf("expected cbor array")
+	if maj != cbg.MajOther {
+		return fmt.Errorf("booleans must be major type 7")
 	}
-
-	if extra > 0 {
-		t.TokenIDs = make([]big.Int, extra)
+	switch extra {
+	case 20:
+		t.NonFungible = false
+	case 21:
+		t.NonFungible = true
+	default:
+		return fmt.Errorf("booleans are either major type 7, value 20 or 21 (got %d)", extra)
 	}
+	// t.MaxSupply (big.Int) (struct)
 
-	for i := 0; i < int(extra); i++ {
+	{
 
-		var v big.Int
-		if err := v.UnmarshalCBOR(br); err != nil {
-			return err
+		if err := t.MaxSupply.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.MaxSupply: %w", err)
 		}
 
-		t.TokenIDs[i] = v
 	}
-
 	return nil
 }
 
-var lengthBufBalanceOfBatchResults = []byte{129}
+var lengthBufCreateTokenParams = []byte{130}
 
-func (t *BalanceOfBatchResults) MarshalCBOR(w io.Writer) error {
+func (t *CreateTokenParams) MarshalCBOR(w io.Writer) error {
 	if t == nil {
 		_, err := w.Write(cbg.CborNull)
 		return err
 	}
-	if _, err := w.Write(lengthBufBalanceOfBatchResults); err != nil {
+	if _, err := w.Write(lengthBufCreateTokenParams); err != nil {
 		return err
 	}
 
 	scratch := make([]byte, 9)
 
-	// t.Balances ([]big.Int) (slice)
-	if len(t.Balances) > cbg.MaxLength {
-		return xerrors.Errorf("Slice value in field t.Balances was too long")
+	// t.ValueInit (big.Int) (struct)
+	if err := t.ValueInit.MarshalCBOR(w); err != nil {
+		return err
 	}
 
-	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajArray, uint64(len(t.Balances))); err != nil {
+	// t.TokenURI (string) (string)
+	if len(t.TokenURI) > cbg.MaxLength {
+		return xerrors.Errorf("Value in field t.TokenURI was too long")
+	}
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajTextString, uint64(len(t.TokenURI))); err != nil {
 		return err
 	}
-	for _, v := range t.Balances {
-		if err := v.MarshalCBOR(w); err != nil {
-			return err
-		}
+	if _, err := io.WriteString(w, string(t.TokenURI)); err != nil {
+		return err
 	}
 	return nil
 }
 
-func (t *BalanceOfBatchResults) UnmarshalCBOR(r io.Reader) error {
-	*t = BalanceOfBatchResults{}
+func (t *CreateTokenParams) UnmarshalCBOR(r io.Reader) error {
+	*t = CreateTokenParams{}
 
 	br := cbg.GetPeeker(r)
 	scratch := make([]byte, 8)
@@ -788,62 +1005,71 @@ func (t *BalanceOfBatchResults) UnmarshalCBOR(r io.Reader) error {
 		return fmt.Errorf("cbor input should be of type array")
 	}
 
-	if extra != 1 {
+	if extra != 2 {
 		return fmt.Errorf("cbor input had wrong number of fields")
 	}
 
-	// t.Balances ([]big.Int) (slice)
-
-	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
-	if err != nil {
-		return err
-	}
+	// t.ValueInit (big.Int) (struct)
 
-	if extra > cbg.MaxLength {
-		return fmt.Errorf("t.Balances: array too large (%d)", extra)
-	}
+	{
 
-	if maj != cbg.MajArray {
-		return fmt.Errorf("expected cbor array")
-	}
+		if err := t.ValueInit.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.ValueInit: %w", err)
+		}
 
-	if extra > 0 {
-		t.Balances = make([]big.Int, extra)
 	}
+	// t.TokenURI (string) (string)
 
-	for i := 0; i < int(extra); i++ {
-
-		var v big.Int
-		if err := v.UnmarshalCBOR(br); err != nil {
+	{
+		sval, err := cbg.ReadStringBuf(br, scratch)
+		if err != nil {
 			return err
 		}
 
-		t.Balances[i] = v
+		t.TokenURI = string(sval)
 	}
-
 	return nil
 }
 
-var lengthBufGetURIParams = []byte{129}
+var lengthBufCreateWithMetadataParams = []byte{131}
 
-func (t *GetURIParams) MarshalCBOR(w io.Writer) error {
+func (t *CreateWithMetadataParams) MarshalCBOR(w io.Writer) error {
 	if t == nil {
 		_, err := w.Write(cbg.CborNull)
 		return err
 	}
-	if _, err := w.Write(lengthBufGetURIParams); err != nil {
+	if _, err := w.Write(lengthBufCreateWithMetadataParams); err != nil {
 		return err
 	}
 
-	// t.TokenID (big.Int) (struct)
-	if err := t.TokenID.MarshalCBOR(w); err != nil {
+	scratch := make([]byte, 9)
+
+	// t.ValueInit (big.Int) (struct)
+	if err := t.ValueInit.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.TokenURI (string) (string)
+	if len(t.TokenURI) > cbg.MaxLength {
+		return xerrors.Errorf("Value in field t.TokenURI was too long")
+	}
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajTextString, uint64(len(t.TokenURI))); err != nil {
+		return err
+	}
+	if _, err := io.WriteString(w, string(t.TokenURI)); err != nil {
+		return err
+	}
+
+	// t.Metadata (token.TokenMetadata) (struct)
+	if err := t.Metadata.MarshalCBOR(w); err != nil {
 		return err
 	}
 	return nil
 }
 
-func (t *GetURIParams) UnmarshalCBOR(r io.Reader) error {
-	*t = GetURIParams{}
+func (t *CreateWithMetadataParams) UnmarshalCBOR(r io.Reader) error {
+	*t = CreateWithMetadataParams{}
 
 	br := cbg.GetPeeker(r)
 	scratch := make([]byte, 8)
@@ -856,56 +1082,1970 @@ func (t *GetURIParams) UnmarshalCBOR(r io.Reader) error {
 		return fmt.Errorf("cbor input should be of type array")
 	}
 
-	if extra != 1 {
+	if extra != 3 {
 		return fmt.Errorf("cbor input had wrong number of fields")
 	}
 
-	// t.TokenID (big.Int) (struct)
+	// t.ValueInit (big.Int) (struct)
 
 	{
 
-		if err := t.TokenID.UnmarshalCBOR(br); err != nil {
-			return xerrors.Errorf("unmarshaling t.TokenID: %w", err)
+		if err := t.ValueInit.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.ValueInit: %w", err)
 		}
 
 	}
-	return nil
-}
+	// t.TokenURI (string) (string)
 
-var lengthBufChangeURIParams = []byte{130}
+	{
+		sval, err := cbg.ReadStringBuf(br, scratch)
+		if err != nil {
+			return err
+		}
 
-func (t *ChangeURIParams) MarshalCBOR(w io.Writer) error {
-	if t == nil {
+		t.TokenURI = string(sval)
+	}
+	// t.Metadata (token.TokenMetadata) (struct)
+
+	{
+
+		if err := t.Metadata.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.Metadata: %w", err)
+		}
+
+	}
+	return nil
+}
+
+var lengthBufMintBatchTokenParams = []byte{131}
+
+func (t *MintBatchTokenParams) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufMintBatchTokenParams); err != nil {
+		return err
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.TokenID (big.Int) (struct)
+	if err := t.TokenID.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.AddrTos ([]address.Address) (slice)
+	if len(t.AddrTos) > cbg.MaxLength {
+		return xerrors.Errorf("Slice value in field t.AddrTos was too long")
+	}
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajArray, uint64(len(t.AddrTos))); err != nil {
+		return err
+	}
+	for _, v := range t.AddrTos {
+		if err := v.MarshalCBOR(w); err != nil {
+			return err
+		}
+	}
+
+	// t.Values ([]big.Int) (slice)
+	if len(t.Values) > cbg.MaxLength {
+		return xerrors.Errorf("Slice value in field t.Values was too long")
+	}
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajArray, uint64(len(t.Values))); err != nil {
+		return err
+	}
+	for _, v := range t.Values {
+		if err := v.MarshalCBOR(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *MintBatchTokenParams) UnmarshalCBOR(r io.Reader) error {
+	*t = MintBatchTokenParams{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 3 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.TokenID (big.Int) (struct)
+
+	{
+
+		if err := t.TokenID.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.TokenID: %w", err)
+		}
+
+	}
+	// t.AddrTos ([]address.Address) (slice)
+
+	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+
+	if extra > cbg.MaxLength {
+		return fmt.Errorf("t.AddrTos: array too large (%d)", extra)
+	}
+
+	if maj != cbg.MajArray {
+		return fmt.Errorf("expected cbor array")
+	}
+
+	if extra > 0 {
+		t.AddrTos = make([]address.Address, extra)
+	}
+
+	for i := 0; i < int(extra); i++ {
+
+		var v address.Address
+		if err := v.UnmarshalCBOR(br); err != nil {
+			return err
+		}
+
+		t.AddrTos[i] = v
+	}
+
+	// t.Values ([]big.Int) (slice)
+
+	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+
+	if extra > cbg.MaxLength {
+		return fmt.Errorf("t.Values: array too large (%d)", extra)
+	}
+
+	if maj != cbg.MajArray {
+		return fmt.Errorf("expected cbor array")
+	}
+
+	if extra > 0 {
+		t.Values = make([]big.Int, extra)
+	}
+
+	for i := 0; i < int(extra); i++ {
+
+		var v big.Int
+		if err := v.UnmarshalCBOR(br); err != nil {
+			return err
+		}
+
+		t.Values[i] = v
+	}
+
+	return nil
+}
+
+var lengthBufBalanceOfParams = []byte{130}
+
+func (t *BalanceOfParams) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufBalanceOfParams); err != nil {
+		return err
+	}
+
+	// t.AddrOwner (address.Address) (struct)
+	if err := t.AddrOwner.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.TokenID (big.Int) (struct)
+	if err := t.TokenID.MarshalCBOR(w); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (t *BalanceOfParams) UnmarshalCBOR(r io.Reader) error {
+	*t = BalanceOfParams{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 2 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.AddrOwner (address.Address) (struct)
+
+	{
+
+		if err := t.AddrOwner.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.AddrOwner: %w", err)
+		}
+
+	}
+	// t.TokenID (big.Int) (struct)
+
+	{
+
+		if err := t.TokenID.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.TokenID: %w", err)
+		}
+
+	}
+	return nil
+}
+
+var lengthBufBalanceOfResults = []byte{129}
+
+func (t *BalanceOfResults) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufBalanceOfResults); err != nil {
+		return err
+	}
+
+	// t.Balance (big.Int) (struct)
+	if err := t.Balance.MarshalCBOR(w); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (t *BalanceOfResults) UnmarshalCBOR(r io.Reader) error {
+	*t = BalanceOfResults{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 1 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.Balance (big.Int) (struct)
+
+	{
+
+		if err := t.Balance.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.Balance: %w", err)
+		}
+
+	}
+	return nil
+}
+
+var lengthBufBalanceOfBatchParams = []byte{130}
+
+func (t *BalanceOfBatchParams) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufBalanceOfBatchParams); err != nil {
+		return err
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.AddrOwners ([]address.Address) (slice)
+	if len(t.AddrOwners) > cbg.MaxLength {
+		return xerrors.Errorf("Slice value in field t.AddrOwners was too long")
+	}
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajArray, uint64(len(t.AddrOwners))); err != nil {
+		return err
+	}
+	for _, v := range t.AddrOwners {
+		if err := v.MarshalCBOR(w); err != nil {
+			return err
+		}
+	}
+
+	// t.TokenIDs ([]big.Int) (slice)
+	if len(t.TokenIDs) > cbg.MaxLength {
+		return xerrors.Errorf("Slice value in field t.TokenIDs was too long")
+	}
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajArray, uint64(len(t.TokenIDs))); err != nil {
+		return err
+	}
+	for _, v := range t.TokenIDs {
+		if err := v.MarshalCBOR(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *BalanceOfBatchParams) UnmarshalCBOR(r io.Reader) error {
+	*t = BalanceOfBatchParams{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 2 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.AddrOwners ([]address.Address) (slice)
+
+	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+
+	if extra > cbg.MaxLength {
+		return fmt.Errorf("t.AddrOwners: array too large (%d)", extra)
+	}
+
+	if maj != cbg.MajArray {
+		return fmt.Errorf("expected cbor array")
+	}
+
+	if extra > 0 {
+		t.AddrOwners = make([]address.Address, extra)
+	}
+
+	for i := 0; i < int(extra); i++ {
+
+		var v address.Address
+		if err := v.UnmarshalCBOR(br); err != nil {
+			return err
+		}
+
+		t.AddrOwners[i] = v
+	}
+
+	// t.TokenIDs ([]big.Int) (slice)
+
+	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+
+	if extra > cbg.MaxLength {
+		return fmt.Errorf("t.TokenIDs: array too large (%d)", extra)
+	}
+
+	if maj != cbg.MajArray {
+		return fmt.Errorf("expected cbor array")
+	}
+
+	if extra > 0 {
+		t.TokenIDs = make([]big.Int, extra)
+	}
+
+	for i := 0; i < int(extra); i++ {
+
+		var v big.Int
+		if err := v.UnmarshalCBOR(br); err != nil {
+			return err
+		}
+
+		t.TokenIDs[i] = v
+	}
+
+	return nil
+}
+
+var lengthBufBalanceOfBatchResults = []byte{129}
+
+func (t *BalanceOfBatchResults) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufBalanceOfBatchResults); err != nil {
+		return err
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.Balances ([]big.Int) (slice)
+	if len(t.Balances) > cbg.MaxLength {
+		return xerrors.Errorf("Slice value in field t.Balances was too long")
+	}
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajArray, uint64(len(t.Balances))); err != nil {
+		return err
+	}
+	for _, v := range t.Balances {
+		if err := v.MarshalCBOR(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *BalanceOfBatchResults) UnmarshalCBOR(r io.Reader) error {
+	*t = BalanceOfBatchResults{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 1 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.Balances ([]big.Int) (slice)
+
+	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+
+	if extra > cbg.MaxLength {
+		return fmt.Errorf("t.Balances: array too large (%d)", extra)
+	}
+
+	if maj != cbg.MajArray {
+		return fmt.Errorf("expected cbor array")
+	}
+
+	if extra > 0 {
+		t.Balances = make([]big.Int, extra)
+	}
+
+	for i := 0; i < int(extra); i++ {
+
+		var v big.Int
+		if err := v.UnmarshalCBOR(br); err != nil {
+			return err
+		}
+
+		t.Balances[i] = v
+	}
+
+	return nil
+}
+
+var lengthBufGetURIParams = []byte{129}
+
+func (t *GetURIParams) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufGetURIParams); err != nil {
+		return err
+	}
+
+	// t.TokenID (big.Int) (struct)
+	if err := t.TokenID.MarshalCBOR(w); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (t *GetURIParams) UnmarshalCBOR(r io.Reader) error {
+	*t = GetURIParams{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 1 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.TokenID (big.Int) (struct)
+
+	{
+
+		if err := t.TokenID.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.TokenID: %w", err)
+		}
+
+	}
+	return nil
+}
+
+var lengthBufChangeURIParams = []byte{130}
+
+func (t *ChangeURIParams) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufChangeURIParams); err != nil {
+		return err
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.NewURI (string) (string)
+	if len(t.NewURI) > cbg.MaxLength {
+		return xerrors.Errorf("Value in field t.NewURI was too long")
+	}
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajTextString, uint64(len(t.NewURI))); err != nil {
+		return err
+	}
+	if _, err := io.WriteString(w, string(t.NewURI)); err != nil {
+		return err
+	}
+
+	// t.TokenID (big.Int) (struct)
+	if err := t.TokenID.MarshalCBOR(w); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (t *ChangeURIParams) UnmarshalCBOR(r io.Reader) error {
+	*t = ChangeURIParams{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 2 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.NewURI (string) (string)
+
+	{
+		sval, err := cbg.ReadStringBuf(br, scratch)
+		if err != nil {
+			return err
+		}
+
+		t.NewURI = string(sval)
+	}
+	// t.TokenID (big.Int) (struct)
+
+	{
+
+		if err := t.TokenID.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.TokenID: %w", err)
+		}
+
+	}
+	return nil
+}
+
+var lengthBufSafeTransferFromParams = []byte{132}
+
+func (t *SafeTransferFromParams) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufSafeTransferFromParams); err != nil {
+		return err
+	}
+
+	// t.AddrFrom (address.Address) (struct)
+	if err := t.AddrFrom.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.AddrTo (address.Address) (struct)
+	if err := t.AddrTo.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.TokenID (big.Int) (struct)
+	if err := t.TokenID.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.Value (big.Int) (struct)
+	if err := t.Value.MarshalCBOR(w); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (t *SafeTransferFromParams) UnmarshalCBOR(r io.Reader) error {
+	*t = SafeTransferFromParams{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 4 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.AddrFrom (address.Address) (struct)
+
+	{
+
+		if err := t.AddrFrom.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.AddrFrom: %w", err)
+		}
+
+	}
+	// t.AddrTo (address.Address) (struct)
+
+	{
+
+		if err := t.AddrTo.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.AddrTo: %w", err)
+		}
+
+	}
+	// t.TokenID (big.Int) (struct)
+
+	{
+
+		if err := t.TokenID.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.TokenID: %w", err)
+		}
+
+	}
+	// t.Value (big.Int) (struct)
+
+	{
+
+		if err := t.Value.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.Value: %w", err)
+		}
+
+	}
+	return nil
+}
+
+var lengthBufSafeBatchTransferFromParams = []byte{132}
+
+func (t *SafeBatchTransferFromParams) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufSafeBatchTransferFromParams); err != nil {
+		return err
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.AddrFrom (address.Address) (struct)
+	if err := t.AddrFrom.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.AddrTo (address.Address) (struct)
+	if err := t.AddrTo.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.TokenIDs ([]big.Int) (slice)
+	if len(t.TokenIDs) > cbg.MaxLength {
+		return xerrors.Errorf("Slice value in field t.TokenIDs was too long")
+	}
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajArray, uint64(len(t.TokenIDs))); err != nil {
+		return err
+	}
+	for _, v := range t.TokenIDs {
+		if err := v.MarshalCBOR(w); err != nil {
+			return err
+		}
+	}
+
+	// t.Values ([]big.Int) (slice)
+	if len(t.Values) > cbg.MaxLength {
+		return xerrors.Errorf("Slice value in field t.Values was too long")
+	}
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajArray, uint64(len(t.Values))); err != nil {
+		return err
+	}
+	for _, v := range t.Values {
+		if err := v.MarshalCBOR(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *SafeBatchTransferFromParams) UnmarshalCBOR(r io.Reader) error {
+	*t = SafeBatchTransferFromParams{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 4 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.AddrFrom (address.Address) (struct)
+
+	{
+
+		if err := t.AddrFrom.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.AddrFrom: %w", err)
+		}
+
+	}
+	// t.AddrTo (address.Address) (struct)
+
+	{
+
+		if err := t.AddrTo.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.AddrTo: %w", err)
+		}
+
+	}
+	// t.TokenIDs ([]big.Int) (slice)
+
+	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+
+	if extra > cbg.MaxLength {
+		return fmt.Errorf("t.TokenIDs: array too large (%d)", extra)
+	}
+
+	if maj != cbg.MajArray {
+		return fmt.Errorf("expected cbor array")
+	}
+
+	if extra > 0 {
+		t.TokenIDs = make([]big.Int, extra)
+	}
+
+	for i := 0; i < int(extra); i++ {
+
+		var v big.Int
+		if err := v.UnmarshalCBOR(br); err != nil {
+			return err
+		}
+
+		t.TokenIDs[i] = v
+	}
+
+	// t.Values ([]big.Int) (slice)
+
+	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+
+	if extra > cbg.MaxLength {
+		return fmt.Errorf("t.Values: array too large (%d)", extra)
+	}
+
+	if maj != cbg.MajArray {
+		return fmt.Errorf("expected cbor array")
+	}
+
+	if extra > 0 {
+		t.Values = make([]big.Int, extra)
+	}
+
+	for i := 0; i < int(extra); i++ {
+
+		var v big.Int
+		if err := v.UnmarshalCBOR(br); err != nil {
+			return err
+		}
+
+		t.Values[i] = v
+	}
+
+	return nil
+}
+
+var lengthBufSetApproveForAllParams = []byte{130}
+
+func (t *SetApproveForAllParams) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufSetApproveForAllParams); err != nil {
+		return err
+	}
+
+	// t.AddrTo (address.Address) (struct)
+	if err := t.AddrTo.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.Approved (bool) (bool)
+	if err := cbg.WriteBool(w, t.Approved); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (t *SetApproveForAllParams) UnmarshalCBOR(r io.Reader) error {
+	*t = SetApproveForAllParams{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 2 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.AddrTo (address.Address) (struct)
+
+	{
+
+		if err := t.AddrTo.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.AddrTo: %w", err)
+		}
+
+	}
+	// t.Approved (bool) (bool)
+
+	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajOther {
+		return fmt.Errorf("booleans must be major type 7")
+	}
+	switch extra {
+	case 20:
+		t.Approved = false
+	case 21:
+		t.Approved = true
+	default:
+		return fmt.Errorf("booleans are either major type 7, value 20 or 21 (got %d)", extra)
+	}
+	return nil
+}
+
+var lengthBufIsApproveForAllParams = []byte{130}
+
+func (t *IsApproveForAllParams) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufIsApproveForAllParams); err != nil {
+		return err
+	}
+
+	// t.AddrFrom (address.Address) (struct)
+	if err := t.AddrFrom.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.AddrTo (address.Address) (struct)
+	if err := t.AddrTo.MarshalCBOR(w); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (t *IsApproveForAllParams) UnmarshalCBOR(r io.Reader) error {
+	*t = IsApproveForAllParams{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 2 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.AddrFrom (address.Address) (struct)
+
+	{
+
+		if err := t.AddrFrom.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.AddrFrom: %w", err)
+		}
+
+	}
+	// t.AddrTo (address.Address) (struct)
+
+	{
+
+		if err := t.AddrTo.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.AddrTo: %w", err)
+		}
+
+	}
+	return nil
+}
+
+var lengthBufIsApprovedForAllResults = []byte{129}
+
+func (t *IsApprovedForAllResults) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufIsApprovedForAllResults); err != nil {
+		return err
+	}
+
+	// t.IsApproved (bool) (bool)
+	if err := cbg.WriteBool(w, t.IsApproved); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (t *IsApprovedForAllResults) UnmarshalCBOR(r io.Reader) error {
+	*t = IsApprovedForAllResults{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 1 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.IsApproved (bool) (bool)
+
+	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajOther {
+		return fmt.Errorf("booleans must be major type 7")
+	}
+	switch extra {
+	case 20:
+		t.IsApproved = false
+	case 21:
+		t.IsApproved = true
+	default:
+		return fmt.Errorf("booleans are either major type 7, value 20 or 21 (got %d)", extra)
+	}
+	return nil
+}
+
+var lengthBufTotalSupplyParams = []byte{129}
+
+func (t *TotalSupplyParams) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufTotalSupplyParams); err != nil {
+		return err
+	}
+
+	// t.TokenID (big.Int) (struct)
+	if err := t.TokenID.MarshalCBOR(w); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (t *TotalSupplyParams) UnmarshalCBOR(r io.Reader) error {
+	*t = TotalSupplyParams{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 1 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.TokenID (big.Int) (struct)
+
+	{
+
+		if err := t.TokenID.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.TokenID: %w", err)
+		}
+
+	}
+	return nil
+}
+
+var lengthBufTotalSupplyResults = []byte{129}
+
+func (t *TotalSupplyResults) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufTotalSupplyResults); err != nil {
+		return err
+	}
+
+	// t.Supply (big.Int) (struct)
+	if err := t.Supply.MarshalCBOR(w); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (t *TotalSupplyResults) UnmarshalCBOR(r io.Reader) error {
+	*t = TotalSupplyResults{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 1 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.Supply (big.Int) (struct)
+
+	{
+
+		if err := t.Supply.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.Supply: %w", err)
+		}
+
+	}
+	return nil
+}
+
+var lengthBufTotalSupplyBatchParams = []byte{129}
+
+func (t *TotalSupplyBatchParams) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufTotalSupplyBatchParams); err != nil {
+		return err
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.TokenIDs ([]big.Int) (slice)
+	if len(t.TokenIDs) > cbg.MaxLength {
+		return xerrors.Errorf("Slice value in field t.TokenIDs was too long")
+	}
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajArray, uint64(len(t.TokenIDs))); err != nil {
+		return err
+	}
+	for _, v := range t.TokenIDs {
+		if err := v.MarshalCBOR(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *TotalSupplyBatchParams) UnmarshalCBOR(r io.Reader) error {
+	*t = TotalSupplyBatchParams{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 1 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.TokenIDs ([]big.Int) (slice)
+
+	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+
+	if extra > cbg.MaxLength {
+		return fmt.Errorf("t.TokenIDs: array too large (%d)", extra)
+	}
+
+	if maj != cbg.MajArray {
+		return fmt.Errorf("expected cbor array")
+	}
+
+	if extra > 0 {
+		t.TokenIDs = make([]big.Int, extra)
+	}
+
+	for i := 0; i < int(extra); i++ {
+
+		var v big.Int
+		if err := v.UnmarshalCBOR(br); err != nil {
+			return err
+		}
+
+		t.TokenIDs[i] = v
+	}
+
+	return nil
+}
+
+var lengthBufTotalSupplyBatchResults = []byte{129}
+
+func (t *TotalSupplyBatchResults) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufTotalSupplyBatchResults); err != nil {
+		return err
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.Supplies ([]big.Int) (slice)
+	if len(t.Supplies) > cbg.MaxLength {
+		return xerrors.Errorf("Slice value in field t.Supplies was too long")
+	}
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajArray, uint64(len(t.Supplies))); err != nil {
+		return err
+	}
+	for _, v := range t.Supplies {
+		if err := v.MarshalCBOR(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *TotalSupplyBatchResults) UnmarshalCBOR(r io.Reader) error {
+	*t = TotalSupplyBatchResults{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 1 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.Supplies ([]big.Int) (slice)
+
+	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+
+	if extra > cbg.MaxLength {
+		return fmt.Errorf("t.Supplies: array too large (%d)", extra)
+	}
+
+	if maj != cbg.MajArray {
+		return fmt.Errorf("expected cbor array")
+	}
+
+	if extra > 0 {
+		t.Supplies = make([]big.Int, extra)
+	}
+
+	for i := 0; i < int(extra); i++ {
+
+		var v big.Int
+		if err := v.UnmarshalCBOR(br); err != nil {
+			return err
+		}
+
+		t.Supplies[i] = v
+	}
+
+	return nil
+}
+
+var lengthBufBurnParams = []byte{131}
+
+func (t *BurnParams) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufBurnParams); err != nil {
+		return err
+	}
+
+	// t.AddrFrom (address.Address) (struct)
+	if err := t.AddrFrom.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.TokenID (big.Int) (struct)
+	if err := t.TokenID.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.Amount (big.Int) (struct)
+	if err := t.Amount.MarshalCBOR(w); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (t *BurnParams) UnmarshalCBOR(r io.Reader) error {
+	*t = BurnParams{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 3 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.AddrFrom (address.Address) (struct)
+
+	{
+
+		if err := t.AddrFrom.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.AddrFrom: %w", err)
+		}
+
+	}
+	// t.TokenID (big.Int) (struct)
+
+	{
+
+		if err := t.TokenID.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.TokenID: %w", err)
+		}
+
+	}
+	// t.Amount (big.Int) (struct)
+
+	{
+
+		if err := t.Amount.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.Amount: %w", err)
+		}
+
+	}
+	return nil
+}
+
+var lengthBufBurnBatchParams = []byte{131}
+
+func (t *BurnBatchParams) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufBurnBatchParams); err != nil {
+		return err
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.AddrFrom (address.Address) (struct)
+	if err := t.AddrFrom.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.TokenIDs ([]big.Int) (slice)
+	if len(t.TokenIDs) > cbg.MaxLength {
+		return xerrors.Errorf("Slice value in field t.TokenIDs was too long")
+	}
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajArray, uint64(len(t.TokenIDs))); err != nil {
+		return err
+	}
+	for _, v := range t.TokenIDs {
+		if err := v.MarshalCBOR(w); err != nil {
+			return err
+		}
+	}
+
+	// t.Amounts ([]big.Int) (slice)
+	if len(t.Amounts) > cbg.MaxLength {
+		return xerrors.Errorf("Slice value in field t.Amounts was too long")
+	}
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajArray, uint64(len(t.Amounts))); err != nil {
+		return err
+	}
+	for _, v := range t.Amounts {
+		if err := v.MarshalCBOR(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *BurnBatchParams) UnmarshalCBOR(r io.Reader) error {
+	*t = BurnBatchParams{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 3 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.AddrFrom (address.Address) (struct)
+
+	{
+
+		if err := t.AddrFrom.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.AddrFrom: %w", err)
+		}
+
+	}
+	// t.TokenIDs ([]big.Int) (slice)
+
+	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+
+	if extra > cbg.MaxLength {
+		return fmt.Errorf("t.TokenIDs: array too large (%d)", extra)
+	}
+
+	if maj != cbg.MajArray {
+		return fmt.Errorf("expected cbor array")
+	}
+
+	if extra > 0 {
+		t.TokenIDs = make([]big.Int, extra)
+	}
+
+	for i := 0; i < int(extra); i++ {
+
+		var v big.Int
+		if err := v.UnmarshalCBOR(br); err != nil {
+			return err
+		}
+
+		t.TokenIDs[i] = v
+	}
+
+	// t.Amounts ([]big.Int) (slice)
+
+	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+
+	if extra > cbg.MaxLength {
+		return fmt.Errorf("t.Amounts: array too large (%d)", extra)
+	}
+
+	if maj != cbg.MajArray {
+		return fmt.Errorf("expected cbor array")
+	}
+
+	if extra > 0 {
+		t.Amounts = make([]big.Int, extra)
+	}
+
+	for i := 0; i < int(extra); i++ {
+
+		var v big.Int
+		if err := v.UnmarshalCBOR(br); err != nil {
+			return err
+		}
+
+		t.Amounts[i] = v
+	}
+
+	return nil
+}
+
+var lengthBufApproveParams = []byte{131}
+
+func (t *ApproveParams) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufApproveParams); err != nil {
+		return err
+	}
+
+	// t.Operator (address.Address) (struct)
+	if err := t.Operator.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.TokenID (big.Int) (struct)
+	if err := t.TokenID.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.Amount (big.Int) (struct)
+	if err := t.Amount.MarshalCBOR(w); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (t *ApproveParams) UnmarshalCBOR(r io.Reader) error {
+	*t = ApproveParams{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 3 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.Operator (address.Address) (struct)
+
+	{
+
+		if err := t.Operator.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.Operator: %w", err)
+		}
+
+	}
+	// t.TokenID (big.Int) (struct)
+
+	{
+
+		if err := t.TokenID.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.TokenID: %w", err)
+		}
+
+	}
+	// t.Amount (big.Int) (struct)
+
+	{
+
+		if err := t.Amount.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.Amount: %w", err)
+		}
+
+	}
+	return nil
+}
+
+var lengthBufAllowanceParams = []byte{131}
+
+func (t *AllowanceParams) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufAllowanceParams); err != nil {
+		return err
+	}
+
+	// t.Owner (address.Address) (struct)
+	if err := t.Owner.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.Operator (address.Address) (struct)
+	if err := t.Operator.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.TokenID (big.Int) (struct)
+	if err := t.TokenID.MarshalCBOR(w); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (t *AllowanceParams) UnmarshalCBOR(r io.Reader) error {
+	*t = AllowanceParams{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 3 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.Owner (address.Address) (struct)
+
+	{
+
+		if err := t.Owner.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.Owner: %w", err)
+		}
+
+	}
+	// t.Operator (address.Address) (struct)
+
+	{
+
+		if err := t.Operator.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.Operator: %w", err)
+		}
+
+	}
+	// t.TokenID (big.Int) (struct)
+
+	{
+
+		if err := t.TokenID.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.TokenID: %w", err)
+		}
+
+	}
+	return nil
+}
+
+var lengthBufAllowanceResults = []byte{129}
+
+func (t *AllowanceResults) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufAllowanceResults); err != nil {
+		return err
+	}
+
+	// t.Amount (big.Int) (struct)
+	if err := t.Amount.MarshalCBOR(w); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (t *AllowanceResults) UnmarshalCBOR(r io.Reader) error {
+	*t = AllowanceResults{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 1 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.Amount (big.Int) (struct)
+
+	{
+
+		if err := t.Amount.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.Amount: %w", err)
+		}
+
+	}
+	return nil
+}
+
+var lengthBufOnTokenReceivedParams = []byte{132}
+
+func (t *OnTokenReceivedParams) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufOnTokenReceivedParams); err != nil {
+		return err
+	}
+
+	// t.Operator (address.Address) (struct)
+	if err := t.Operator.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.From (address.Address) (struct)
+	if err := t.From.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.TokenID (big.Int) (struct)
+	if err := t.TokenID.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.Value (big.Int) (struct)
+	if err := t.Value.MarshalCBOR(w); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (t *OnTokenReceivedParams) UnmarshalCBOR(r io.Reader) error {
+	*t = OnTokenReceivedParams{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 4 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.Operator (address.Address) (struct)
+
+	{
+
+		if err := t.Operator.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.Operator: %w", err)
+		}
+
+	}
+	// t.From (address.Address) (struct)
+
+	{
+
+		if err := t.From.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.From: %w", err)
+		}
+
+	}
+	// t.TokenID (big.Int) (struct)
+
+	{
+
+		if err := t.TokenID.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.TokenID: %w", err)
+		}
+
+	}
+	// t.Value (big.Int) (struct)
+
+	{
+
+		if err := t.Value.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.Value: %w", err)
+		}
+
+	}
+	return nil
+}
+
+var lengthBufOnBatchTokenReceivedParams = []byte{132}
+
+func (t *OnBatchTokenReceivedParams) MarshalCBOR(w io.Writer) error {
+	if t == nil {
 		_, err := w.Write(cbg.CborNull)
 		return err
 	}
-	if _, err := w.Write(lengthBufChangeURIParams); err != nil {
+	if _, err := w.Write(lengthBufOnBatchTokenReceivedParams); err != nil {
+		return err
+	}
+
+	scratch := make([]byte, 9)
+
+	// t.Operator (address.Address) (struct)
+	if err := t.Operator.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.From (address.Address) (struct)
+	if err := t.From.MarshalCBOR(w); err != nil {
+		return err
+	}
+
+	// t.TokenIDs ([]big.Int) (slice)
+	if len(t.TokenIDs) > cbg.MaxLength {
+		return xerrors.Errorf("Slice value in field t.TokenIDs was too long")
+	}
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajArray, uint64(len(t.TokenIDs))); err != nil {
+		return err
+	}
+	for _, v := range t.TokenIDs {
+		if err := v.MarshalCBOR(w); err != nil {
+			return err
+		}
+	}
+
+	// t.Values ([]big.Int) (slice)
+	if len(t.Values) > cbg.MaxLength {
+		return xerrors.Errorf("Slice value in field t.Values was too long")
+	}
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajArray, uint64(len(t.Values))); err != nil {
+		return err
+	}
+	for _, v := range t.Values {
+		if err := v.MarshalCBOR(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *OnBatchTokenReceivedParams) UnmarshalCBOR(r io.Reader) error {
+	*t = OnBatchTokenReceivedParams{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
 		return err
 	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
 
-	scratch := make([]byte, 9)
+	if extra != 4 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.Operator (address.Address) (struct)
+
+	{
+
+		if err := t.Operator.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.Operator: %w", err)
+		}
 
-	// t.NewURI (string) (string)
-	if len(t.NewURI) > cbg.MaxLength {
-		return xerrors.Errorf("Value in field t.NewURI was too long")
 	}
+	// t.From (address.Address) (struct)
 
-	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajTextString, uint64(len(t.NewURI))); err != nil {
+	{
+
+		if err := t.From.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.From: %w", err)
+		}
+
+	}
+	// t.TokenIDs ([]big.Int) (slice)
+
+	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
 		return err
 	}
-	if _, err := io.WriteString(w, string(t.NewURI)); err != nil {
+
+	if extra > cbg.MaxLength {
+		return fmt.Errorf("t.TokenIDs: array too large (%d)", extra)
+	}
+
+	if maj != cbg.MajArray {
+		return fmt.Errorf("expected cbor array")
+	}
+
+	if extra > 0 {
+		t.TokenIDs = make([]big.Int, extra)
+	}
+
+	for i := 0; i < int(extra); i++ {
+
+		var v big.Int
+		if err := v.UnmarshalCBOR(br); err != nil {
+			return err
+		}
+
+		t.TokenIDs[i] = v
+	}
+
+	// t.Values ([]big.Int) (slice)
+
+	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
 		return err
 	}
 
-	// t.TokenID (big.Int) (struct)
-	if err := t.TokenID.MarshalCBOR(w); err != nil {
+	if extra > cbg.MaxLength {
+		return fmt.Errorf("t.Values: array too large (%d)", extra)
+	}
+
+	if maj != cbg.MajArray {
+		return fmt.Errorf("expected cbor array")
+	}
+
+	if extra > 0 {
+		t.Values = make([]big.Int, extra)
+	}
+
+	for i := 0; i < int(extra); i++ {
+
+		var v big.Int
+		if err := v.UnmarshalCBOR(br); err != nil {
+			return err
+		}
+
+		t.Values[i] = v
+	}
+
+	return nil
+}
+
+var lengthBufGetEventsParams = []byte{129}
+
+func (t *GetEventsParams) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufGetEventsParams); err != nil {
 		return err
 	}
+
+	scratch := make([]byte, 9)
+
+	// t.Epoch (abi.ChainEpoch) (int64)
+	if t.Epoch >= 0 {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajUnsignedInt, uint64(t.Epoch)); err != nil {
+			return err
+		}
+	} else {
+		if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajNegativeInt, uint64(-t.Epoch-1)); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
-func (t *ChangeURIParams) UnmarshalCBOR(r io.Reader) error {
-	*t = ChangeURIParams{}
+func (t *GetEventsParams) UnmarshalCBOR(r io.Reader) error {
+	*t = GetEventsParams{}
 
 	br := cbg.GetPeeker(r)
 	scratch := make([]byte, 8)
@@ -918,20 +3058,74 @@ func (t *ChangeURIParams) UnmarshalCBOR(r io.Reader) error {
 		return fmt.Errorf("cbor input should be of type array")
 	}
 
-	if extra != 2 {
+	if extra != 1 {
 		return fmt.Errorf("cbor input had wrong number of fields")
 	}
 
-	// t.NewURI (string) (string)
-
+	// t.Epoch (abi.ChainEpoch) (int64)
 	{
-		sval, err := cbg.ReadStringBuf(br, scratch)
+		maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+		var extraI int64
 		if err != nil {
 			return err
 		}
+		switch maj {
+		case cbg.MajUnsignedInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 positive overflow")
+			}
+		case cbg.MajNegativeInt:
+			extraI = int64(extra)
+			if extraI < 0 {
+				return fmt.Errorf("int64 negative oveflow")
+			}
+			extraI = -1 - extraI
+		default:
+			return fmt.Errorf("wrong type for int64 field: %d", maj)
+		}
 
-		t.NewURI = string(sval)
+		t.Epoch = abi.ChainEpoch(extraI)
+	}
+	return nil
+}
+
+var lengthBufGetMetadataParams = []byte{129}
+
+func (t *GetMetadataParams) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufGetMetadataParams); err != nil {
+		return err
+	}
+
+	// t.TokenID (big.Int) (struct)
+	if err := t.TokenID.MarshalCBOR(w); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (t *GetMetadataParams) UnmarshalCBOR(r io.Reader) error {
+	*t = GetMetadataParams{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 1 {
+		return fmt.Errorf("cbor input had wrong number of fields")
 	}
+
 	// t.TokenID (big.Int) (struct)
 
 	{
@@ -944,24 +3138,76 @@ func (t *ChangeURIParams) UnmarshalCBOR(r io.Reader) error {
 	return nil
 }
 
-var lengthBufSafeTransferFromParams = []byte{132}
+var lengthBufTransferCreatorParams = []byte{130}
 
-func (t *SafeTransferFromParams) MarshalCBOR(w io.Writer) error {
+func (t *TransferCreatorParams) MarshalCBOR(w io.Writer) error {
 	if t == nil {
 		_, err := w.Write(cbg.CborNull)
 		return err
 	}
-	if _, err := w.Write(lengthBufSafeTransferFromParams); err != nil {
+	if _, err := w.Write(lengthBufTransferCreatorParams); err != nil {
 		return err
 	}
 
-	// t.AddrFrom (address.Address) (struct)
-	if err := t.AddrFrom.MarshalCBOR(w); err != nil {
+	// t.TokenID (big.Int) (struct)
+	if err := t.TokenID.MarshalCBOR(w); err != nil {
 		return err
 	}
 
-	// t.AddrTo (address.Address) (struct)
-	if err := t.AddrTo.MarshalCBOR(w); err != nil {
+	// t.NewCreator (address.Address) (struct)
+	if err := t.NewCreator.MarshalCBOR(w); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (t *TransferCreatorParams) UnmarshalCBOR(r io.Reader) error {
+	*t = TransferCreatorParams{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 2 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
+	// t.TokenID (big.Int) (struct)
+
+	{
+
+		if err := t.TokenID.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.TokenID: %w", err)
+		}
+
+	}
+	// t.NewCreator (address.Address) (struct)
+
+	{
+
+		if err := t.NewCreator.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.NewCreator: %w", err)
+		}
+
+	}
+	return nil
+}
+
+var lengthBufMinterParams = []byte{130}
+
+func (t *MinterParams) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufMinterParams); err != nil {
 		return err
 	}
 
@@ -970,15 +3216,15 @@ func (t *SafeTransferFromParams) MarshalCBOR(w io.Writer) error {
 		return err
 	}
 
-	// t.Value (big.Int) (struct)
-	if err := t.Value.MarshalCBOR(w); err != nil {
+	// t.Minter (address.Address) (struct)
+	if err := t.Minter.MarshalCBOR(w); err != nil {
 		return err
 	}
 	return nil
 }
 
-func (t *SafeTransferFromParams) UnmarshalCBOR(r io.Reader) error {
-	*t = SafeTransferFromParams{}
+func (t *MinterParams) UnmarshalCBOR(r io.Reader) error {
+	*t = MinterParams{}
 
 	br := cbg.GetPeeker(r)
 	scratch := make([]byte, 8)
@@ -991,28 +3237,67 @@ func (t *SafeTransferFromParams) UnmarshalCBOR(r io.Reader) error {
 		return fmt.Errorf("cbor input should be of type array")
 	}
 
-	if extra != 4 {
+	if extra != 2 {
 		return fmt.Errorf("cbor input had wrong number of fields")
 	}
 
-	// t.AddrFrom (address.Address) (struct)
+	// t.TokenID (big.Int) (struct)
 
 	{
 
-		if err := t.AddrFrom.UnmarshalCBOR(br); err != nil {
-			return xerrors.Errorf("unmarshaling t.AddrFrom: %w", err)
+		if err := t.TokenID.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.TokenID: %w", err)
 		}
 
 	}
-	// t.AddrTo (address.Address) (struct)
+	// t.Minter (address.Address) (struct)
 
 	{
 
-		if err := t.AddrTo.UnmarshalCBOR(br); err != nil {
-			return xerrors.Errorf("unmarshaling t.AddrTo: %w", err)
+		if err := t.Minter.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.Minter: %w", err)
 		}
 
 	}
+	return nil
+}
+
+var lengthBufGetCreatorParams = []byte{129}
+
+func (t *GetCreatorParams) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	if _, err := w.Write(lengthBufGetCreatorParams); err != nil {
+		return err
+	}
+
+	// t.TokenID (big.Int) (struct)
+	if err := t.TokenID.MarshalCBOR(w); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (t *GetCreatorParams) UnmarshalCBOR(r io.Reader) error {
+	*t = GetCreatorParams{}
+
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
+
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+
+	if extra != 1 {
+		return fmt.Errorf("cbor input had wrong number of fields")
+	}
+
 	// t.TokenID (big.Int) (struct)
 
 	{
@@ -1021,74 +3306,35 @@ func (t *SafeTransferFromParams) UnmarshalCBOR(r io.Reader) error {
 			return xerrors.Errorf("unmarshaling t.TokenID: %w", err)
 		}
 
-	}
-	// t.Value (big.Int) (struct)
-
-	{
-
-		if err := t.Value.UnmarshalCBOR(br); err != nil {
-			return xerrors.Errorf("unmarshaling t.Value: %w", err)
-		}
-
 	}
 	return nil
 }
 
-var lengthBufSafeBatchTransferFromParams = []byte{132}
+var lengthBufGetCreatorResults = []byte{130}
 
-func (t *SafeBatchTransferFromParams) MarshalCBOR(w io.Writer) error {
+func (t *GetCreatorResults) MarshalCBOR(w io.Writer) error {
 	if t == nil {
 		_, err := w.Write(cbg.CborNull)
 		return err
 	}
-	if _, err := w.Write(lengthBufSafeBatchTransferFromParams); err != nil {
-		return err
-	}
-
-	scratch := make([]byte, 9)
-
-	// t.AddrFrom (address.Address) (struct)
-	if err := t.AddrFrom.MarshalCBOR(w); err != nil {
+	if _, err := w.Write(lengthBufGetCreatorResults); err != nil {
 		return err
 	}
 
-	// t.AddrTo (address.Address) (struct)
-	if err := t.AddrTo.MarshalCBOR(w); err != nil {
-		return err
-	}
-
-	// t.TokenIDs ([]big.Int) (slice)
-	if len(t.TokenIDs) > cbg.MaxLength {
-		return xerrors.Errorf("Slice value in field t.TokenIDs was too long")
-	}
-
-	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajArray, uint64(len(t.TokenIDs))); err != nil {
+	// t.Creator (address.Address) (struct)
+	if err := t.Creator.MarshalCBOR(w); err != nil {
 		return err
 	}
-	for _, v := range t.TokenIDs {
-		if err := v.MarshalCBOR(w); err != nil {
-			return err
-		}
-	}
 
-	// t.Values ([]big.Int) (slice)
-	if len(t.Values) > cbg.MaxLength {
-		return xerrors.Errorf("Slice value in field t.Values was too long")
-	}
-
-	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajArray, uint64(len(t.Values))); err != nil {
+	// t.PendingCreator (address.Address) (struct)
+	if err := t.PendingCreator.MarshalCBOR(w); err != nil {
 		return err
 	}
-	for _, v := range t.Values {
-		if err := v.MarshalCBOR(w); err != nil {
-			return err
-		}
-	}
 	return nil
 }
 
-func (t *SafeBatchTransferFromParams) UnmarshalCBOR(r io.Reader) error {
-	*t = SafeBatchTransferFromParams{}
+func (t *GetCreatorResults) UnmarshalCBOR(r io.Reader) error {
+	*t = GetCreatorResults{}
 
 	br := cbg.GetPeeker(r)
 	scratch := make([]byte, 8)
@@ -1101,114 +3347,120 @@ func (t *SafeBatchTransferFromParams) UnmarshalCBOR(r io.Reader) error {
 		return fmt.Errorf("cbor input should be of type array")
 	}
 
-	if extra != 4 {
+	if extra != 2 {
 		return fmt.Errorf("cbor input had wrong number of fields")
 	}
 
-	// t.AddrFrom (address.Address) (struct)
+	// t.Creator (address.Address) (struct)
 
 	{
 
-		if err := t.AddrFrom.UnmarshalCBOR(br); err != nil {
-			return xerrors.Errorf("unmarshaling t.AddrFrom: %w", err)
+		if err := t.Creator.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.Creator: %w", err)
 		}
 
 	}
-	// t.AddrTo (address.Address) (struct)
+	// t.PendingCreator (address.Address) (struct)
 
 	{
 
-		if err := t.AddrTo.UnmarshalCBOR(br); err != nil {
-			return xerrors.Errorf("unmarshaling t.AddrTo: %w", err)
+		b, err := br.ReadByte()
+		if err != nil {
+			return err
+		}
+		if b != cbg.CborNull[0] {
+			if err := br.UnreadByte(); err != nil {
+				return err
+			}
+			t.PendingCreator = new(address.Address)
+			if err := t.PendingCreator.UnmarshalCBOR(br); err != nil {
+				return xerrors.Errorf("unmarshaling t.PendingCreator pointer: %w", err)
+			}
 		}
 
 	}
-	// t.TokenIDs ([]big.Int) (slice)
+	return nil
+}
 
-	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
-	if err != nil {
-		return err
-	}
+var lengthBufGetMintersParams = []byte{129}
 
-	if extra > cbg.MaxLength {
-		return fmt.Errorf("t.TokenIDs: array too large (%d)", extra)
+func (t *GetMintersParams) MarshalCBOR(w io.Writer) error {
+	if t == nil {
+		_, err := w.Write(cbg.CborNull)
+		return err
 	}
-
-	if maj != cbg.MajArray {
-		return fmt.Errorf("expected cbor array")
+	if _, err := w.Write(lengthBufGetMintersParams); err != nil {
+		return err
 	}
 
-	if extra > 0 {
-		t.TokenIDs = make([]big.Int, extra)
+	// t.TokenID (big.Int) (struct)
+	if err := t.TokenID.MarshalCBOR(w); err != nil {
+		return err
 	}
+	return nil
+}
 
-	for i := 0; i < int(extra); i++ {
-
-		var v big.Int
-		if err := v.UnmarshalCBOR(br); err != nil {
-			return err
-		}
-
-		t.TokenIDs[i] = v
-	}
+func (t *GetMintersParams) UnmarshalCBOR(r io.Reader) error {
+	*t = GetMintersParams{}
 
-	// t.Values ([]big.Int) (slice)
+	br := cbg.GetPeeker(r)
+	scratch := make([]byte, 8)
 
-	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
+	maj, extra, err := cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
 		return err
 	}
-
-	if extra > cbg.MaxLength {
-		return fmt.Errorf("t.Values: array too large (%d)", extra)
-	}
-
 	if maj != cbg.MajArray {
-		return fmt.Errorf("expected cbor array")
+		return fmt.Errorf("cbor input should be of type array")
 	}
 
-	if extra > 0 {
-		t.Values = make([]big.Int, extra)
+	if extra != 1 {
+		return fmt.Errorf("cbor input had wrong number of fields")
 	}
 
-	for i := 0; i < int(extra); i++ {
+	// t.TokenID (big.Int) (struct)
 
-		var v big.Int
-		if err := v.UnmarshalCBOR(br); err != nil {
-			return err
+	{
+
+		if err := t.TokenID.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.TokenID: %w", err)
 		}
 
-		t.Values[i] = v
 	}
-
 	return nil
 }
 
-var lengthBufSetApproveForAllParams = []byte{130}
+var lengthBufGetMintersResults = []byte{129}
 
-func (t *SetApproveForAllParams) MarshalCBOR(w io.Writer) error {
+func (t *GetMintersResults) MarshalCBOR(w io.Writer) error {
 	if t == nil {
 		_, err := w.Write(cbg.CborNull)
 		return err
 	}
-	if _, err := w.Write(lengthBufSetApproveForAllParams); err != nil {
+	if _, err := w.Write(lengthBufGetMintersResults); err != nil {
 		return err
 	}
 
-	// t.AddrTo (address.Address) (struct)
-	if err := t.AddrTo.MarshalCBOR(w); err != nil {
-		return err
+	scratch := make([]byte, 9)
+
+	// t.Minters ([]address.Address) (slice)
+	if len(t.Minters) > cbg.MaxLength {
+		return xerrors.Errorf("Slice value in field t.Minters was too long")
 	}
 
-	// t.Approved (bool) (bool)
-	if err := cbg.WriteBool(w, t.Approved); err != nil {
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajArray, uint64(len(t.Minters))); err != nil {
 		return err
 	}
+	for _, v := range t.Minters {
+		if err := v.MarshalCBOR(w); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
-func (t *SetApproveForAllParams) UnmarshalCBOR(r io.Reader) error {
-	*t = SetApproveForAllParams{}
+func (t *GetMintersResults) UnmarshalCBOR(r io.Reader) error {
+	*t = GetMintersResults{}
 
 	br := cbg.GetPeeker(r)
 	scratch := make([]byte, 8)
@@ -1221,64 +3473,62 @@ func (t *SetApproveForAllParams) UnmarshalCBOR(r io.Reader) error {
 		return fmt.Errorf("cbor input should be of type array")
 	}
 
-	if extra != 2 {
+	if extra != 1 {
 		return fmt.Errorf("cbor input had wrong number of fields")
 	}
 
-	// t.AddrTo (address.Address) (struct)
-
-	{
-
-		if err := t.AddrTo.UnmarshalCBOR(br); err != nil {
-			return xerrors.Errorf("unmarshaling t.AddrTo: %w", err)
-		}
-
-	}
-	// t.Approved (bool) (bool)
+	// t.Minters ([]address.Address) (slice)
 
 	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
 		return err
 	}
-	if maj != cbg.MajOther {
-		return fmt.Errorf("booleans must be major type 7")
+
+	if extra > cbg.MaxLength {
+		return fmt.Errorf("t.Minters: array too large (%d)", extra)
 	}
-	switch extra {
-	case 20:
-		t.Approved = false
-	case 21:
-		t.Approved = true
-	default:
-		return fmt.Errorf("booleans are either major type 7, value 20 or 21 (got %d)", extra)
+
+	if maj != cbg.MajArray {
+		return fmt.Errorf("expected cbor array")
+	}
+
+	if extra > 0 {
+		t.Minters = make([]address.Address, extra)
+	}
+
+	for i := 0; i < int(extra); i++ {
+
+		var v address.Address
+		if err := v.UnmarshalCBOR(br); err != nil {
+			return err
+		}
+
+		t.Minters[i] = v
 	}
+
 	return nil
 }
 
-var lengthBufIsApproveForAllParams = []byte{130}
+var lengthBufTokensOfParams = []byte{129}
 
-func (t *IsApproveForAllParams) MarshalCBOR(w io.Writer) error {
+func (t *TokensOfParams) MarshalCBOR(w io.Writer) error {
 	if t == nil {
 		_, err := w.Write(cbg.CborNull)
 		return err
 	}
-	if _, err := w.Write(lengthBufIsApproveForAllParams); err != nil {
-		return err
-	}
-
-	// t.AddrFrom (address.Address) (struct)
-	if err := t.AddrFrom.MarshalCBOR(w); err != nil {
+	if _, err := w.Write(lengthBufTokensOfParams); err != nil {
 		return err
 	}
 
-	// t.AddrTo (address.Address) (struct)
-	if err := t.AddrTo.MarshalCBOR(w); err != nil {
+	// t.Owner (address.Address) (struct)
+	if err := t.Owner.MarshalCBOR(w); err != nil {
 		return err
 	}
 	return nil
 }
 
-func (t *IsApproveForAllParams) UnmarshalCBOR(r io.Reader) error {
-	*t = IsApproveForAllParams{}
+func (t *TokensOfParams) UnmarshalCBOR(r io.Reader) error {
+	*t = TokensOfParams{}
 
 	br := cbg.GetPeeker(r)
 	scratch := make([]byte, 8)
@@ -1291,51 +3541,53 @@ func (t *IsApproveForAllParams) UnmarshalCBOR(r io.Reader) error {
 		return fmt.Errorf("cbor input should be of type array")
 	}
 
-	if extra != 2 {
+	if extra != 1 {
 		return fmt.Errorf("cbor input had wrong number of fields")
 	}
 
-	// t.AddrFrom (address.Address) (struct)
-
-	{
-
-		if err := t.AddrFrom.UnmarshalCBOR(br); err != nil {
-			return xerrors.Errorf("unmarshaling t.AddrFrom: %w", err)
-		}
-
-	}
-	// t.AddrTo (address.Address) (struct)
+	// t.Owner (address.Address) (struct)
 
 	{
 
-		if err := t.AddrTo.UnmarshalCBOR(br); err != nil {
-			return xerrors.Errorf("unmarshaling t.AddrTo: %w", err)
+		if err := t.Owner.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("unmarshaling t.Owner: %w", err)
 		}
 
 	}
 	return nil
 }
 
-var lengthBufIsApprovedForAllResults = []byte{129}
+var lengthBufTokensOfResults = []byte{129}
 
-func (t *IsApprovedForAllResults) MarshalCBOR(w io.Writer) error {
+func (t *TokensOfResults) MarshalCBOR(w io.Writer) error {
 	if t == nil {
 		_, err := w.Write(cbg.CborNull)
 		return err
 	}
-	if _, err := w.Write(lengthBufIsApprovedForAllResults); err != nil {
+	if _, err := w.Write(lengthBufTokensOfResults); err != nil {
 		return err
 	}
 
-	// t.IsApproved (bool) (bool)
-	if err := cbg.WriteBool(w, t.IsApproved); err != nil {
+	scratch := make([]byte, 9)
+
+	// t.TokenIDs ([]big.Int) (slice)
+	if len(t.TokenIDs) > cbg.MaxLength {
+		return xerrors.Errorf("Slice value in field t.TokenIDs was too long")
+	}
+
+	if err := cbg.WriteMajorTypeHeaderBuf(scratch, w, cbg.MajArray, uint64(len(t.TokenIDs))); err != nil {
 		return err
 	}
+	for _, v := range t.TokenIDs {
+		if err := v.MarshalCBOR(w); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
-func (t *IsApprovedForAllResults) UnmarshalCBOR(r io.Reader) error {
-	*t = IsApprovedForAllResults{}
+func (t *TokensOfResults) UnmarshalCBOR(r io.Reader) error {
+	*t = TokensOfResults{}
 
 	br := cbg.GetPeeker(r)
 	scratch := make([]byte, 8)
@@ -1352,22 +3604,34 @@ func (t *IsApprovedForAllResults) UnmarshalCBOR(r io.Reader) error {
 		return fmt.Errorf("cbor input had wrong number of fields")
 	}
 
-	// t.IsApproved (bool) (bool)
+	// t.TokenIDs ([]big.Int) (slice)
 
 	maj, extra, err = cbg.CborReadHeaderBuf(br, scratch)
 	if err != nil {
 		return err
 	}
-	if maj != cbg.MajOther {
-		return fmt.Errorf("booleans must be major type 7")
+
+	if extra > cbg.MaxLength {
+		return fmt.Errorf("t.TokenIDs: array too large (%d)", extra)
 	}
-	switch extra {
-	case 20:
-		t.IsApproved = false
-	case 21:
-		t.IsApproved = true
-	default:
-		return fmt.Errorf("booleans are either major type 7, value 20 or 21 (got %d)", extra)
+
+	if maj != cbg.MajArray {
+		return fmt.Errorf("expected cbor array")
+	}
+
+	if extra > 0 {
+		t.TokenIDs = make([]big.Int, extra)
+	}
+
+	for i := 0; i < int(extra); i++ {
+
+		var v big.Int
+		if err := v.UnmarshalCBOR(br); err != nil {
+			return err
+		}
+
+		t.TokenIDs[i] = v
 	}
+
 	return nil
 }
@@ -0,0 +1,415 @@
+package token
+
+import (
+	addr "github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/ipfs/go-cid"
+	cbg "github.com/whyrusleeping/cbor-gen"
+
+	"github.com/filecoin-project/specs-actors/v3/actors/builtin"
+	"github.com/filecoin-project/specs-actors/v3/actors/util/adt"
+)
+
+type StateSummary struct {
+	// Total supply of each token, keyed by TokenID.
+	Supplies map[uint64]abi.TokenAmount
+	// Balances of each token, keyed by TokenID then holder.
+	Balances map[uint64]map[addr.Address]abi.TokenAmount
+}
+
+// Checks internal invariants of token state.
+func CheckStateInvariants(st *State, store adt.Store) (*StateSummary, *builtin.MessageAccumulator) {
+	acc := &builtin.MessageAccumulator{}
+	summary := &StateSummary{
+		Supplies: make(map[uint64]abi.TokenAmount),
+		Balances: make(map[uint64]map[addr.Address]abi.TokenAmount),
+	}
+
+	acc.Require(st.Nonce.GreaterThanEqual(big.Zero()), "nonce is negative %v", st.Nonce)
+	if st.Nonce.LessThan(big.Zero()) {
+		return summary, acc
+	}
+	nonce := st.Nonce.Uint64()
+
+	creators := CheckCreators(st, store, nonce, acc)
+	uris := CheckURIs(st, store, nonce, acc)
+	supplies := CheckSupplies(st, store, nonce, acc)
+	metadata := CheckMetadata(st, store, nonce, acc)
+	minted := CheckMinted(st, store, nonce, acc)
+	for tokenID := uint64(1); tokenID <= nonce; tokenID++ {
+		_, found := metadata[tokenID]
+		acc.Require(found, "token %d has no metadata", tokenID)
+	}
+	for tokenID, supply := range supplies {
+		if m, found := metadata[tokenID]; found {
+			supplyCap := m.SupplyCap()
+			acc.Require(supplyCap.IsZero() || supply.LessThanEqual(supplyCap),
+				"supply %v of token %d exceeds max supply %v", supply, tokenID, supplyCap)
+		}
+		m, found := minted[tokenID]
+		acc.Require(found && m.GreaterThanEqual(supply), "supply %v of token %d exceeds total minted %v", supply, tokenID, m)
+	}
+	for tokenID, m := range minted {
+		if md, found := metadata[tokenID]; found && md.NonFungible {
+			acc.Require(m.LessThanEqual(big.NewInt(1)), "non-fungible token %d has minted %v", tokenID, m)
+		}
+	}
+
+	balanceArray, err := adt.AsArray(store, st.Balances, LaneStatesAmtBitwidth)
+	if err != nil {
+		acc.Addf("error loading balances: %v", err)
+		return summary, acc
+	}
+
+	var addrTokenAmountMapCid cbg.CborCid
+	err = balanceArray.ForEach(&addrTokenAmountMapCid, func(i int64) error {
+		tokenID := uint64(i)
+		acc := acc.WithPrefix("token %d: ", tokenID) // Intentional shadow
+		acc.Require(tokenID > 0 && tokenID <= nonce, "token ID is out of range (0, %d]", nonce)
+		_, found := creators[tokenID]
+		acc.Require(found, "token has balances but no creator")
+		_, found = uris[tokenID]
+		acc.Require(found, "token has balances but no URI")
+
+		addrTokenAmountMap, found, err := st.LoadAddrTokenAmountMap(store, balanceArray, big.NewIntUnsigned(tokenID))
+		if err != nil || !found {
+			acc.Addf("error loading balances: %v", err)
+			return nil
+		}
+		balances, supply := CheckTokenBalances(addrTokenAmountMap, store, acc)
+		summary.Balances[tokenID] = balances
+		summary.Supplies[tokenID] = supply
+
+		recorded, found := supplies[tokenID]
+		if !found {
+			recorded = big.Zero()
+		}
+		acc.Require(recorded.Equals(supply), "recorded supply %v does not match sum of balances %v", recorded, supply)
+		return nil
+	})
+	acc.RequireNoError(err, "error iterating balances")
+
+	CheckHoldings(st, store, summary.Balances, acc)
+	CheckAllowances(st, store, nonce, acc)
+	CheckEvents(st, store, nonce, acc)
+	CheckPendingCreators(st, store, creators, acc)
+	CheckMinters(st, store, creators, acc)
+
+	return summary, acc
+}
+
+func CheckCreators(st *State, store adt.Store, nonce uint64, acc *builtin.MessageAccumulator) map[uint64]addr.Address {
+	creators := make(map[uint64]addr.Address)
+	creatorsArray, err := adt.AsArray(store, st.Creators, LaneStatesAmtBitwidth)
+	if err != nil {
+		acc.Addf("error loading creators: %v", err)
+		return creators
+	}
+
+	var creator addr.Address
+	err = creatorsArray.ForEach(&creator, func(i int64) error {
+		acc.Require(uint64(i) > 0 && uint64(i) <= nonce, "creator for token %d is out of range (0, %d]", i, nonce)
+		creators[uint64(i)] = creator
+		return nil
+	})
+	acc.RequireNoError(err, "error iterating creators")
+	return creators
+}
+
+func CheckPendingCreators(st *State, store adt.Store, creators map[uint64]addr.Address, acc *builtin.MessageAccumulator) {
+	pendingCreatorsArray, err := adt.AsArray(store, st.PendingCreators, LaneStatesAmtBitwidth)
+	if err != nil {
+		acc.Addf("error loading pending creators: %v", err)
+		return
+	}
+
+	var pending addr.Address
+	err = pendingCreatorsArray.ForEach(&pending, func(i int64) error {
+		creator, found := creators[uint64(i)]
+		acc.Require(found, "pending creator for token %d has no current creator", i)
+		acc.Require(pending.Protocol() == addr.ID, "pending creator %v for token %d is not an ID address", pending, i)
+		acc.Require(pending != creator, "pending creator for token %d is the current creator %v", i, creator)
+		return nil
+	})
+	acc.RequireNoError(err, "error iterating pending creators")
+}
+
+func CheckMinters(st *State, store adt.Store, creators map[uint64]addr.Address, acc *builtin.MessageAccumulator) {
+	mintersArray, err := adt.AsArray(store, st.Minters, LaneStatesAmtBitwidth)
+	if err != nil {
+		acc.Addf("error loading minters: %v", err)
+		return
+	}
+
+	var mintersRoot cbg.CborCid
+	err = mintersArray.ForEach(&mintersRoot, func(i int64) error {
+		_, found := creators[uint64(i)]
+		acc.Require(found, "minters for token %d have no creator", i)
+
+		minters, err := adt.AsSet(store, cid.Cid(mintersRoot), builtin.DefaultHamtBitwidth)
+		if err != nil {
+			acc.Addf("error loading minters for token %d: %v", i, err)
+			return nil
+		}
+		count := 0
+		err = minters.ForEach(func(k string) error {
+			minter, err := addr.NewFromBytes([]byte(k))
+			if err != nil {
+				return err
+			}
+			acc.Require(minter.Protocol() == addr.ID, "minter %v for token %d is not an ID address", minter, i)
+			count++
+			return nil
+		})
+		acc.RequireNoError(err, "error iterating minters for token %d", i)
+		acc.Require(count > 0, "minters for token %d are empty", i)
+		return nil
+	})
+	acc.RequireNoError(err, "error iterating minters")
+}
+
+func CheckURIs(st *State, store adt.Store, nonce uint64, acc *builtin.MessageAccumulator) map[uint64]string {
+	uris := make(map[uint64]string)
+	urisArray, err := adt.AsArray(store, st.URIs, LaneStatesAmtBitwidth)
+	if err != nil {
+		acc.Addf("error loading URIs: %v", err)
+		return uris
+	}
+
+	var uri TokenURI
+	err = urisArray.ForEach(&uri, func(i int64) error {
+		acc.Require(uint64(i) > 0 && uint64(i) <= nonce, "URI for token %d is out of range (0, %d]", i, nonce)
+		uris[uint64(i)] = uri.TokenURI
+		return nil
+	})
+	acc.RequireNoError(err, "error iterating URIs")
+	return uris
+}
+
+func CheckMetadata(st *State, store adt.Store, nonce uint64, acc *builtin.MessageAccumulator) map[uint64]TokenMetadata {
+	metadata := make(map[uint64]TokenMetadata)
+	metadataArray, err := adt.AsArray(store, st.Metadata, LaneStatesAmtBitwidth)
+	if err != nil {
+		acc.Addf("error loading metadata: %v", err)
+		return metadata
+	}
+
+	var m TokenMetadata
+	err = metadataArray.ForEach(&m, func(i int64) error {
+		acc.Require(uint64(i) > 0 && uint64(i) <= nonce, "metadata for token %d is out of range (0, %d]", i, nonce)
+		acc.Require(m.MaxSupply.GreaterThanEqual(big.Zero()), "max supply for token %d is negative %v", i, m.MaxSupply)
+		if m.NonFungible {
+			acc.Require(m.Decimals == 0, "non-fungible token %d has %d decimals", i, m.Decimals)
+			acc.Require(m.MaxSupply.LessThanEqual(big.NewInt(1)), "non-fungible token %d has max supply %v", i, m.MaxSupply)
+		}
+		metadata[uint64(i)] = m
+		return nil
+	})
+	acc.RequireNoError(err, "error iterating metadata")
+	return metadata
+}
+
+func CheckSupplies(st *State, store adt.Store, nonce uint64, acc *builtin.MessageAccumulator) map[uint64]abi.TokenAmount {
+	supplies := make(map[uint64]abi.TokenAmount)
+	suppliesArray, err := adt.AsArray(store, st.Supplies, LaneStatesAmtBitwidth)
+	if err != nil {
+		acc.Addf("error loading supplies: %v", err)
+		return supplies
+	}
+
+	var supply abi.TokenAmount
+	err = suppliesArray.ForEach(&supply, func(i int64) error {
+		acc.Require(uint64(i) > 0 && uint64(i) <= nonce, "supply for token %d is out of range (0, %d]", i, nonce)
+		acc.Require(supply.GreaterThanEqual(big.Zero()), "supply for token %d is negative %v", i, supply)
+		supplies[uint64(i)] = supply
+		return nil
+	})
+	acc.RequireNoError(err, "error iterating supplies")
+	return supplies
+}
+
+func CheckMinted(st *State, store adt.Store, nonce uint64, acc *builtin.MessageAccumulator) map[uint64]abi.TokenAmount {
+	minted := make(map[uint64]abi.TokenAmount)
+	mintedArray, err := adt.AsArray(store, st.Minted, LaneStatesAmtBitwidth)
+	if err != nil {
+		acc.Addf("error loading minted amounts: %v", err)
+		return minted
+	}
+
+	var amount abi.TokenAmount
+	err = mintedArray.ForEach(&amount, func(i int64) error {
+		acc.Require(uint64(i) > 0 && uint64(i) <= nonce, "minted amount for token %d is out of range (0, %d]", i, nonce)
+		acc.Require(amount.GreaterThanEqual(big.Zero()), "minted amount for token %d is negative %v", i, amount)
+		minted[uint64(i)] = amount
+		return nil
+	})
+	acc.RequireNoError(err, "error iterating minted amounts")
+	return minted
+}
+
+// Checks that the holder index lists exactly the tokens of which each holder has a positive balance.
+func CheckHoldings(st *State, store adt.Store, balances map[uint64]map[addr.Address]abi.TokenAmount, acc *builtin.MessageAccumulator) {
+	holdingsMap, err := adt.AsMap(store, st.Holdings, builtin.DefaultHamtBitwidth)
+	if err != nil {
+		acc.Addf("error loading holdings: %v", err)
+		return
+	}
+
+	indexed := 0
+	var tokensRoot cbg.CborCid
+	err = holdingsMap.ForEach(&tokensRoot, func(holderKey string) error {
+		holder, err := addr.NewFromBytes([]byte(holderKey))
+		if err != nil {
+			return err
+		}
+		tokensMap, err := adt.AsMap(store, cid.Cid(tokensRoot), builtin.DefaultHamtBitwidth)
+		if err != nil {
+			acc.Addf("error loading holdings of %v: %v", holder, err)
+			return nil
+		}
+		return tokensMap.ForEach(nil, func(tokenKey string) error {
+			tokenID, err := abi.ParseUIntKey(tokenKey)
+			if err != nil {
+				return err
+			}
+			balance, found := balances[tokenID][holder]
+			acc.Require(found && balance.GreaterThan(big.Zero()), "holding of %v in token %d has no positive balance", holder, tokenID)
+			indexed++
+			return nil
+		})
+	})
+	acc.RequireNoError(err, "error iterating holdings")
+
+	positive := 0
+	for _, holders := range balances {
+		for _, balance := range holders {
+			if balance.GreaterThan(big.Zero()) {
+				positive++
+			}
+		}
+	}
+	acc.Require(indexed == positive, "holder index has %d entries but there are %d positive balances", indexed, positive)
+}
+
+func CheckAllowances(st *State, store adt.Store, nonce uint64, acc *builtin.MessageAccumulator) {
+	allowancesMap, err := adt.AsMap(store, st.Allowances, builtin.DefaultHamtBitwidth)
+	if err != nil {
+		acc.Addf("error loading allowances: %v", err)
+		return
+	}
+
+	var operatorsRoot cbg.CborCid
+	err = allowancesMap.ForEach(&operatorsRoot, func(ownerKey string) error {
+		owner, err := addr.NewFromBytes([]byte(ownerKey))
+		if err != nil {
+			return err
+		}
+		acc.Require(owner.Protocol() == addr.ID, "allowance owner %v is not an ID address", owner)
+
+		operatorsMap, err := adt.AsMap(store, cid.Cid(operatorsRoot), builtin.DefaultHamtBitwidth)
+		if err != nil {
+			acc.Addf("error loading allowances of %v: %v", owner, err)
+			return nil
+		}
+		var tokensRoot cbg.CborCid
+		return operatorsMap.ForEach(&tokensRoot, func(operatorKey string) error {
+			operator, err := addr.NewFromBytes([]byte(operatorKey))
+			if err != nil {
+				return err
+			}
+			acc.Require(operator.Protocol() == addr.ID, "allowance operator %v is not an ID address", operator)
+
+			tokensMap, err := adt.AsMap(store, cid.Cid(tokensRoot), builtin.DefaultHamtBitwidth)
+			if err != nil {
+				acc.Addf("error loading allowances of %v for %v: %v", owner, operator, err)
+				return nil
+			}
+			var amount abi.TokenAmount
+			return tokensMap.ForEach(&amount, func(tokenKey string) error {
+				tokenID, err := abi.ParseUIntKey(tokenKey)
+				if err != nil {
+					return err
+				}
+				acc.Require(tokenID <= nonce, "allowance of %v for %v on token %d is out of range [0, %d]", owner, operator, tokenID, nonce)
+				acc.Require(amount.GreaterThan(big.Zero()), "allowance of %v for %v on token %d is not positive %v", owner, operator, tokenID, amount)
+				return nil
+			})
+		})
+	})
+	acc.RequireNoError(err, "error iterating allowances")
+}
+
+func CheckEvents(st *State, store adt.Store, nonce uint64, acc *builtin.MessageAccumulator) {
+	eventsArray, err := adt.AsArray(store, st.Events, LaneStatesAmtBitwidth)
+	if err != nil {
+		acc.Addf("error loading events: %v", err)
+		return
+	}
+
+	var epochRoot cbg.CborCid
+	err = eventsArray.ForEach(&epochRoot, func(epoch int64) error {
+		epochArray, err := adt.AsArray(store, cid.Cid(epochRoot), LaneStatesAmtBitwidth)
+		if err != nil {
+			acc.Addf("error loading events for epoch %d: %v", epoch, err)
+			return nil
+		}
+		acc.Require(epochArray.Length() > 0, "events for epoch %d are empty", epoch)
+
+		var event TokenEvent
+		err = epochArray.ForEach(&event, func(i int64) error {
+			switch event.Kind {
+			case EventKindTransfer:
+				if event.Transfer == nil || event.URIChange != nil {
+					acc.Addf("transfer event %d at epoch %d does not hold only a transfer", i, epoch)
+					return nil
+				}
+				transfer := event.Transfer
+				acc.Require(transfer.TokenID.GreaterThan(big.Zero()) && transfer.TokenID.LessThanEqual(big.NewIntUnsigned(nonce)),
+					"transfer event at epoch %d has token ID %v out of range (0, %d]", epoch, transfer.TokenID, nonce)
+				acc.Require(transfer.Value.GreaterThanEqual(big.Zero()),
+					"transfer event at epoch %d has negative value %v", epoch, transfer.Value)
+			case EventKindURIChange:
+				if event.URIChange == nil || event.Transfer != nil {
+					acc.Addf("URI event %d at epoch %d does not hold only a URI change", i, epoch)
+					return nil
+				}
+				change := event.URIChange
+				acc.Require(change.TokenID.GreaterThan(big.Zero()) && change.TokenID.LessThanEqual(big.NewIntUnsigned(nonce)),
+					"URI event at epoch %d has token ID %v out of range (0, %d]", epoch, change.TokenID, nonce)
+			default:
+				acc.Addf("event %d at epoch %d has unknown kind %d", i, epoch, event.Kind)
+			}
+			return nil
+		})
+		acc.RequireNoError(err, "error iterating events for epoch %d", epoch)
+		return nil
+	})
+	acc.RequireNoError(err, "error iterating events")
+}
+
+func CheckTokenBalances(addrTokenAmountMap *AddrTokenAmountMap, store adt.Store, acc *builtin.MessageAccumulator) (map[addr.Address]abi.TokenAmount, abi.TokenAmount) {
+	balances := make(map[addr.Address]abi.TokenAmount)
+	supply := big.Zero()
+	balanceMap, err := adt.AsMap(store, addrTokenAmountMap.AddrTokenAmountMap, builtin.DefaultHamtBitwidth)
+	if err != nil {
+		acc.Addf("error loading balance map: %v", err)
+		return balances, supply
+	}
+
+	var amount abi.TokenAmount
+	err = balanceMap.ForEach(&amount, func(key string) error {
+		holder, err := addr.NewFromBytes([]byte(key))
+		if err != nil {
+			return err
+		}
+		acc.Require(holder.Protocol() == addr.ID, "holder %v is not an ID address", holder)
+		acc.Require(amount.GreaterThanEqual(big.Zero()), "balance of %v is negative %v", holder, amount)
+
+		balances[holder] = amount
+		supply = big.Add(supply, amount)
+		return nil
+	})
+	acc.RequireNoError(err, "error iterating balance map")
+	return balances, supply
+}
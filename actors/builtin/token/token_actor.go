@@ -1,6 +1,8 @@
 package token
 
 import (
+	"sort"
+
 	addr "github.com/filecoin-project/go-address"
 	"github.com/filecoin-project/go-state-types/abi"
 	"github.com/filecoin-project/go-state-types/big"
@@ -29,6 +31,21 @@ func (a Actor) Exports() []interface{} {
 		9:								a.SafeBatchTransferFrom,
 		10:								a.SetApproveForAll,
 		11:								a.IsApproveForAll,
+		12:								a.TotalSupply,
+		13:								a.TotalSupplyBatch,
+		14:								a.Burn,
+		15:								a.BurnBatch,
+		16:								a.Approve,
+		17:								a.Allowance,
+		18:								a.GetEvents,
+		19:								a.GetMetadata,
+		20:								a.TransferCreator,
+		21:								a.AddMinter,
+		22:								a.RemoveMinter,
+		23:								a.GetCreator,
+		24:								a.GetMinters,
+		25:								a.TokensOf,
+		26:								a.CreateWithMetadata,
 	}
 }
 
@@ -65,14 +82,52 @@ type CreateTokenParams struct {
 // GasOnTokenCreate is amount of extra gas charged for Token Create
 const GasOnTokenCreate = 888_888_888
 
+// Creates a fungible token with an uncapped supply and no name, symbol or decimals.
 func (a Actor) Create(rt Runtime, params *CreateTokenParams) *abi.EmptyValue {
 	rt.ValidateImmediateCallerAcceptAny()
 
+	createToken(rt, &CreateWithMetadataParams{
+		ValueInit: params.ValueInit,
+		TokenURI:  params.TokenURI,
+		Metadata:  TokenMetadata{MaxSupply: big.Zero()},
+	})
+	return nil
+}
+
+type CreateWithMetadataParams struct {
+	ValueInit abi.TokenAmount
+	TokenURI  string
+	Metadata  TokenMetadata
+}
+
+// Creates a token described by the given metadata, which cannot be changed afterwards.
+func (a Actor) CreateWithMetadata(rt Runtime, params *CreateWithMetadataParams) *abi.EmptyValue {
+	rt.ValidateImmediateCallerAcceptAny()
+
+	createToken(rt, params)
+	return nil
+}
+
+func createToken(rt Runtime, params *CreateWithMetadataParams) {
 	tokenOperator := rt.Caller()
 
 	if params.ValueInit.LessThan(big.Zero()) {
 		rt.Abortf(exitcode.ErrIllegalArgument, "Illegal token amount : %v", params.ValueInit)
 	}
+	if params.Metadata.MaxSupply.LessThan(big.Zero()) {
+		rt.Abortf(exitcode.ErrIllegalArgument, "Illegal max supply : %v", params.Metadata.MaxSupply)
+	}
+	if params.Metadata.NonFungible {
+		if params.Metadata.Decimals != 0 {
+			rt.Abortf(exitcode.ErrIllegalArgument, "non-fungible token cannot have %d decimals", params.Metadata.Decimals)
+		}
+		if params.Metadata.MaxSupply.GreaterThan(big.NewInt(1)) {
+			rt.Abortf(exitcode.ErrIllegalArgument, "non-fungible token cannot have max supply %v", params.Metadata.MaxSupply)
+		}
+	}
+	if supplyCap := params.Metadata.SupplyCap(); !supplyCap.IsZero() && params.ValueInit.GreaterThan(supplyCap) {
+		rt.Abortf(exitcode.ErrIllegalArgument, "initial value %v exceeds max supply %v", params.ValueInit, supplyCap)
+	}
 
 	store := adt.AsStore(rt)
 
@@ -97,6 +152,14 @@ func (a Actor) Create(rt Runtime, params *CreateTokenParams) *abi.EmptyValue {
 		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to flush urisArray")
 		st.URIs = ura
 
+		metadataArray, err := adt.AsArray(store, st.Metadata, LaneStatesAmtBitwidth)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load metadataArray")
+		err = st.setTokenMetadata(metadataArray, &params.Metadata, st.Nonce)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to set token metadata")
+		mta, err := metadataArray.Root()
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to flush metadataArray")
+		st.Metadata = mta
+
 		balanceMap, err:= adt.StoreEmptyMap(adt.AsStore(rt), builtin.DefaultHamtBitwidth)
 		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to create state")
 		balancesMap, err := adt.AsMap(store, balanceMap, builtin.DefaultHamtBitwidth)
@@ -106,6 +169,14 @@ func (a Actor) Create(rt Runtime, params *CreateTokenParams) *abi.EmptyValue {
 		blm, err := balancesMap.Root()
 		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to flush balanceMap")
 
+		holdingsMap, err := adt.AsMap(store, st.Holdings, builtin.DefaultHamtBitwidth)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load holdingsMap")
+		err = st.updateHolding(store, holdingsMap, tokenOperator, st.Nonce, params.ValueInit)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to update holdings")
+		hdm, err := holdingsMap.Root()
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to flush holdingsMap")
+		st.Holdings = hdm
+
 		balanceArray, err := adt.AsArray(store, st.Balances, LaneStatesAmtBitwidth)
 		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load balanceArray")
 		var addrTokenAmountMap AddrTokenAmountMap
@@ -116,6 +187,22 @@ func (a Actor) Create(rt Runtime, params *CreateTokenParams) *abi.EmptyValue {
 		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to flush balanceArray")
 		st.Balances = bla
 
+		suppliesArray, err := adt.AsArray(store, st.Supplies, LaneStatesAmtBitwidth)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load suppliesArray")
+		_, err = st.updateTokenSupply(suppliesArray, st.Nonce, params.ValueInit)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to set token supply")
+		spa, err := suppliesArray.Root()
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to flush suppliesArray")
+		st.Supplies = spa
+
+		mintedArray, err := adt.AsArray(store, st.Minted, LaneStatesAmtBitwidth)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load mintedArray")
+		_, err = st.addTokenMinted(mintedArray, st.Nonce, params.ValueInit)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to set token minted amount")
+		mda, err := mintedArray.Root()
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to flush mintedArray")
+		st.Minted = mda
+
 		apMap, err := adt.StoreEmptyMap(adt.AsStore(rt), builtin.DefaultHamtBitwidth)
 		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to create state")
 
@@ -129,10 +216,20 @@ func (a Actor) Create(rt Runtime, params *CreateTokenParams) *abi.EmptyValue {
 		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to flush isAllApproveMap")
 		st.Approves = iam
 
+		recordEvents(rt, &st, transferEvent(TransferEvent{
+			Operator: tokenOperator,
+			From:     rt.Receiver(),
+			To:       tokenOperator,
+			TokenID:  st.Nonce,
+			Value:    params.ValueInit,
+		}), uriChangeEvent(URIEvent{
+			Operator: tokenOperator,
+			TokenID:  st.Nonce,
+			URI:      params.TokenURI,
+		}))
+
 		rt.ChargeGas("OnTokenCreate", GasOnTokenCreate, 0)
 	})
-
-	return nil
 }
 
 type MintBatchTokenParams struct {
@@ -169,9 +266,34 @@ func (a Actor) MintBatch(rt Runtime, params *MintBatchTokenParams) *abi.EmptyVal
 	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load creatorsArray")
 	creatorAddress, found, err := st.GetCreatorAddress(tokenCreatorsArray, params.TokenID)
 	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to get creators by tokenID : %v", params.TokenID)
-	if !found || creatorAddress != rt.Caller() {
+	if !found {
 		rt.Abortf(exitcode.ErrIllegalArgument, "The caller %v is not the creator for token with tokenID : %v", rt.Caller(), params.TokenID)
 	}
+	if creatorAddress != rt.Caller() {
+		mintersArray, err := adt.AsArray(store, st.Minters, LaneStatesAmtBitwidth)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load mintersArray")
+		minters, err := st.LoadMinters(store, mintersArray, params.TokenID)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load minters for tokenID : %v", params.TokenID)
+		isMinter, err := minters.Has(abi.AddrKey(rt.Caller()))
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to check minter %v", rt.Caller())
+		if !isMinter {
+			rt.Abortf(exitcode.ErrIllegalArgument, "The caller %v is not the creator for token with tokenID : %v nor one of its minters", rt.Caller(), params.TokenID)
+		}
+	}
+
+	metadataArray, err := adt.AsArray(store, st.Metadata, LaneStatesAmtBitwidth)
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load metadataArray")
+	metadata, found, err := st.LoadTokenMetadata(metadataArray, params.TokenID)
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load metadata for tokenID : %v", params.TokenID)
+	if !found {
+		rt.Abortf(exitcode.ErrIllegalState, "no metadata for token ID (%v)", params.TokenID)
+	}
+
+	// Balances and holdings are keyed by the recipients' ID addresses.
+	addrTos := make([]addr.Address, len(params.AddrTos))
+	for idx := range params.AddrTos {
+		addrTos[idx] = resolveHolder(rt, params.AddrTos[idx])
+	}
 
 	rt.StateTransaction(&st, func() {
 		balanceArray, err := adt.AsArray(store, st.Balances, LaneStatesAmtBitwidth)
@@ -186,21 +308,25 @@ func (a Actor) MintBatch(rt Runtime, params *MintBatchTokenParams) *abi.EmptyVal
 		}
 		tokenAmountMap, err := adt.AsMap(store, addrTokenAmountMap.AddrTokenAmountMap, builtin.DefaultHamtBitwidth)
 		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load balanceMap")
+		holdingsMap, err := adt.AsMap(store, st.Holdings, builtin.DefaultHamtBitwidth)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load holdingsMap")
 		for idx, _ := range params.AddrTos {
-			tokenAmount, found, err := st.LoadAddrTokenAmount(tokenAmountMap, params.AddrTos[idx])
-			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load addrTokenAmount for %v - %v", params.TokenID, params.AddrTos[idx])
+			tokenAmount, found, err := st.LoadAddrTokenAmount(tokenAmountMap, addrTos[idx])
+			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load addrTokenAmount for %v - %v", params.TokenID, addrTos[idx])
 			tokenAmount = big.Add(tokenAmount, params.Values[idx])
-			err = st.putAddrTokenAmount(tokenAmountMap, params.AddrTos[idx], tokenAmount)
-			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to put addrTokenAmount for %v - %v", params.TokenID, params.AddrTos[idx])
+			err = st.putAddrTokenAmount(tokenAmountMap, addrTos[idx], tokenAmount)
+			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to put addrTokenAmount for %v - %v", params.TokenID, addrTos[idx])
+			err = st.updateHolding(store, holdingsMap, addrTos[idx], params.TokenID, tokenAmount)
+			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to update holdings of %v", addrTos[idx])
 
-			_, found, err = st.LoadAddrApproveMap(store, isAllApproveMap, params.AddrTos[idx])
-			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load addrApproveMap for %v", params.AddrTos[idx])
+			_, found, err = st.LoadAddrApproveMap(store, isAllApproveMap, addrTos[idx])
+			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load addrApproveMap for %v", addrTos[idx])
 			if !found {
 				apMap, err := adt.StoreEmptyMap(adt.AsStore(rt), builtin.DefaultHamtBitwidth)
 				builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to create state")
 				var addrApproveMap AddrApproveMap
 				addrApproveMap.AddrApproveMap = apMap
-				err = st.putAddrApproveMap(store, isAllApproveMap, params.AddrTos[idx], &addrApproveMap)
+				err = st.putAddrApproveMap(store, isAllApproveMap, addrTos[idx], &addrApproveMap)
 				builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to put isAllApproveMap")
 			}
 		}
@@ -218,6 +344,45 @@ func (a Actor) MintBatch(rt Runtime, params *MintBatchTokenParams) *abi.EmptyVal
 		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to flush isAllApproveMap")
 		st.Approves = iam
 
+		hdm, err := holdingsMap.Root()
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to flush holdingsMap")
+		st.Holdings = hdm
+
+		suppliesArray, err := adt.AsArray(store, st.Supplies, LaneStatesAmtBitwidth)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load suppliesArray")
+		supply, err := st.updateTokenSupply(suppliesArray, params.TokenID, big.Sum(params.Values...))
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to update token supply")
+		if !metadata.MaxSupply.IsZero() && supply.GreaterThan(metadata.MaxSupply) {
+			rt.Abortf(exitcode.ErrIllegalArgument, "supply %v of token %v would exceed max supply %v", supply, params.TokenID, metadata.MaxSupply)
+		}
+		spa, err := suppliesArray.Root()
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to flush suppliesArray")
+		st.Supplies = spa
+
+		// A non-fungible token is capped on everything ever minted, so burning it does not allow minting it again.
+		mintedArray, err := adt.AsArray(store, st.Minted, LaneStatesAmtBitwidth)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load mintedArray")
+		minted, err := st.addTokenMinted(mintedArray, params.TokenID, big.Sum(params.Values...))
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to update token minted amount")
+		if metadata.NonFungible && minted.GreaterThan(metadata.SupplyCap()) {
+			rt.Abortf(exitcode.ErrIllegalArgument, "total minted %v of non-fungible token %v would exceed its supply of %v", minted, params.TokenID, metadata.SupplyCap())
+		}
+		mda, err := mintedArray.Root()
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to flush mintedArray")
+		st.Minted = mda
+
+		events := make([]TokenEvent, len(params.AddrTos))
+		for idx := range params.AddrTos {
+			events[idx] = transferEvent(TransferEvent{
+				Operator: rt.Caller(),
+				From:     rt.Receiver(),
+				To:       addrTos[idx],
+				TokenID:  params.TokenID,
+				Value:    params.Values[idx],
+			})
+		}
+		recordEvents(rt, &st, events...)
+
 		rt.ChargeGas("OnTokenCreate", GasOnTokenCreate, 0)
 	})
 
@@ -259,7 +424,7 @@ func (a Actor) BalanceOf(rt Runtime, params *BalanceOfParams) *BalanceOfResults
 	tokenAmountMap, err := adt.AsMap(store, addrTokenAmountMap.AddrTokenAmountMap, builtin.DefaultHamtBitwidth)
 	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load balanceMap")
 
-	addrTokenAmount, found, err := st.LoadAddrTokenAmount(tokenAmountMap, params.AddrOwner)
+	addrTokenAmount, found, err := st.LoadAddrTokenAmount(tokenAmountMap, resolveHolder(rt, params.AddrOwner))
 	if !found {
 		return &BalanceOfResults{Balance: big.Zero()}
 	}
@@ -311,7 +476,7 @@ func (a Actor) BalanceOfBatch(rt Runtime, params *BalanceOfBatchParams) *Balance
 		tokenAmountMap, err := adt.AsMap(store, addrTokenAmountMap.AddrTokenAmountMap, builtin.DefaultHamtBitwidth)
 		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load balanceMap")
 
-		addrTokenAmount, found, err := st.LoadAddrTokenAmount(tokenAmountMap, params.AddrOwners[idx])
+		addrTokenAmount, found, err := st.LoadAddrTokenAmount(tokenAmountMap, resolveHolder(rt, params.AddrOwners[idx]))
 		if !found {
 			tokenAmounts = append(tokenAmounts, big.Zero())
 			continue
@@ -322,6 +487,34 @@ func (a Actor) BalanceOfBatch(rt Runtime, params *BalanceOfBatchParams) *Balance
 	return &BalanceOfBatchResults{Balances: tokenAmounts}
 }
 
+type TokensOfParams struct {
+	Owner addr.Address
+}
+
+type TokensOfResults struct {
+	TokenIDs []big.Int
+}
+
+// TokensOf lists, in ascending order, the tokens of which Owner holds a positive balance.
+func (a Actor) TokensOf(rt Runtime, params *TokensOfParams) *TokensOfResults {
+	rt.ValidateImmediateCallerAcceptAny()
+
+	var st State
+	rt.StateReadonly(&st)
+
+	tokenIDs := []big.Int{}
+	err := st.ForEachTokenOf(adt.AsStore(rt), resolveHolder(rt, params.Owner), func(tokenID big.Int, _ abi.TokenAmount) error {
+		tokenIDs = append(tokenIDs, tokenID)
+		return nil
+	})
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to iterate tokens of %v", params.Owner)
+	sort.Slice(tokenIDs, func(i, j int) bool {
+		return tokenIDs[i].LessThan(tokenIDs[j])
+	})
+
+	return &TokensOfResults{TokenIDs: tokenIDs}
+}
+
 type GetURIParams struct {
 	TokenID		big.Int
 }
@@ -350,6 +543,195 @@ func (a Actor) GetURI(rt Runtime, params *GetURIParams) *TokenURI {
 	return tokenURI
 }
 
+type TransferCreatorParams struct {
+	TokenID    big.Int
+	NewCreator addr.Address
+}
+
+// TransferCreator proposes or confirms a new creator for a token. The current creator proposes
+// NewCreator, which takes over once it calls TransferCreator with the same parameters.
+// Proposing the current creator cancels a pending proposal.
+func (a Actor) TransferCreator(rt Runtime, params *TransferCreatorParams) *abi.EmptyValue {
+	if params.NewCreator.Empty() {
+		rt.Abortf(exitcode.ErrIllegalArgument, "empty address : %v", params.NewCreator)
+	}
+	newCreator, ok := rt.ResolveAddress(params.NewCreator)
+	if !ok {
+		rt.Abortf(exitcode.ErrIllegalArgument, "unable to resolve address %v", params.NewCreator)
+	}
+
+	store := adt.AsStore(rt)
+	var st State
+	rt.StateTransaction(&st, func() {
+		creatorsArray, err := adt.AsArray(store, st.Creators, LaneStatesAmtBitwidth)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load creatorsArray")
+		creator, found, err := st.GetCreatorAddress(creatorsArray, params.TokenID)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to get creators by tokenID : %v", params.TokenID)
+		if !found {
+			rt.Abortf(exitcode.ErrNotFound, "no creator for token ID (%v)", params.TokenID)
+		}
+
+		pendingCreatorsArray, err := adt.AsArray(store, st.PendingCreators, LaneStatesAmtBitwidth)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load pendingCreatorsArray")
+		pending, hasPending, err := st.GetPendingCreator(pendingCreatorsArray, params.TokenID)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to get pending creator by tokenID : %v", params.TokenID)
+
+		if rt.Caller() == creator || !hasPending {
+			// Propose new creator.
+			rt.ValidateImmediateCallerIs(creator)
+			if newCreator == creator {
+				err = st.clearPendingCreator(pendingCreatorsArray, params.TokenID)
+			} else {
+				err = st.setPendingCreator(pendingCreatorsArray, newCreator, params.TokenID)
+			}
+			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to update pending creator")
+		} else {
+			// Confirm the proposal.
+			// This validates that the new creator can in fact send messages from the proposed address.
+			rt.ValidateImmediateCallerIs(pending)
+			if newCreator != pending {
+				rt.Abortf(exitcode.ErrIllegalArgument, "expected confirmation of %v, got %v", pending, newCreator)
+			}
+			err = st.setCreatorAddress(creatorsArray, newCreator, params.TokenID)
+			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to set token creator")
+			err = st.clearPendingCreator(pendingCreatorsArray, params.TokenID)
+			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to clear pending creator")
+
+			cta, err := creatorsArray.Root()
+			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to flush creatorsArray")
+			st.Creators = cta
+		}
+
+		pca, err := pendingCreatorsArray.Root()
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to flush pendingCreatorsArray")
+		st.PendingCreators = pca
+	})
+
+	return nil
+}
+
+type MinterParams struct {
+	TokenID big.Int
+	Minter  addr.Address
+}
+
+// AddMinter allows Minter to mint TokenID. Only the creator of the token may delegate minting.
+func (a Actor) AddMinter(rt Runtime, params *MinterParams) *abi.EmptyValue {
+	updateMinters(rt, params, func(minters *adt.Set, minter addr.Address) error {
+		return minters.Put(abi.AddrKey(minter))
+	})
+	return nil
+}
+
+// RemoveMinter revokes the permission of Minter to mint TokenID.
+func (a Actor) RemoveMinter(rt Runtime, params *MinterParams) *abi.EmptyValue {
+	updateMinters(rt, params, func(minters *adt.Set, minter addr.Address) error {
+		found, err := minters.TryDelete(abi.AddrKey(minter))
+		if err != nil {
+			return err
+		}
+		if !found {
+			rt.Abortf(exitcode.ErrNotFound, "%v is not a minter of token ID (%v)", minter, params.TokenID)
+		}
+		return nil
+	})
+	return nil
+}
+
+type GetCreatorParams struct {
+	TokenID big.Int
+}
+
+type GetCreatorResults struct {
+	Creator        addr.Address
+	PendingCreator *addr.Address // Proposed new creator awaiting confirmation, if any.
+}
+
+func (a Actor) GetCreator(rt Runtime, params *GetCreatorParams) *GetCreatorResults {
+	rt.ValidateImmediateCallerAcceptAny()
+
+	store := adt.AsStore(rt)
+	var st State
+	rt.StateReadonly(&st)
+
+	creatorsArray, err := adt.AsArray(store, st.Creators, LaneStatesAmtBitwidth)
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load creatorsArray")
+	creator, found, err := st.GetCreatorAddress(creatorsArray, params.TokenID)
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to get creators by tokenID : %v", params.TokenID)
+	if !found {
+		rt.Abortf(exitcode.ErrNotFound, "no creator for token ID (%v)", params.TokenID)
+	}
+
+	pendingCreatorsArray, err := adt.AsArray(store, st.PendingCreators, LaneStatesAmtBitwidth)
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load pendingCreatorsArray")
+	pending, hasPending, err := st.GetPendingCreator(pendingCreatorsArray, params.TokenID)
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to get pending creator by tokenID : %v", params.TokenID)
+
+	ret := &GetCreatorResults{Creator: creator}
+	if hasPending {
+		ret.PendingCreator = &pending
+	}
+	return ret
+}
+
+type GetMintersParams struct {
+	TokenID big.Int
+}
+
+type GetMintersResults struct {
+	Minters []addr.Address
+}
+
+func (a Actor) GetMinters(rt Runtime, params *GetMintersParams) *GetMintersResults {
+	rt.ValidateImmediateCallerAcceptAny()
+
+	store := adt.AsStore(rt)
+	var st State
+	rt.StateReadonly(&st)
+
+	mintersArray, err := adt.AsArray(store, st.Minters, LaneStatesAmtBitwidth)
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load mintersArray")
+	minters, err := st.LoadMinters(store, mintersArray, params.TokenID)
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load minters for tokenID : %v", params.TokenID)
+
+	ret := &GetMintersResults{Minters: []addr.Address{}}
+	err = minters.ForEach(func(k string) error {
+		minter, err := addr.NewFromBytes([]byte(k))
+		if err != nil {
+			return err
+		}
+		ret.Minters = append(ret.Minters, minter)
+		return nil
+	})
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to iterate minters for tokenID : %v", params.TokenID)
+	return ret
+}
+
+type GetMetadataParams struct {
+	TokenID big.Int
+}
+
+func (a Actor) GetMetadata(rt Runtime, params *GetMetadataParams) *TokenMetadata {
+	rt.ValidateImmediateCallerAcceptAny()
+
+	store := adt.AsStore(rt)
+	var st State
+	rt.StateReadonly(&st)
+
+	if params.TokenID.GreaterThan(st.Nonce) {
+		rt.Abortf(exitcode.ErrIllegalArgument, "Invalid token ID (%v) greater than actual maxID (%v)", params.TokenID, st.Nonce)
+	}
+
+	metadataArray, err := adt.AsArray(store, st.Metadata, LaneStatesAmtBitwidth)
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load metadataArray")
+	metadata, found, err := st.LoadTokenMetadata(metadataArray, params.TokenID)
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load metadata for tokenID : %v", params.TokenID)
+	if !found {
+		rt.Abortf(exitcode.ErrNotFound, "no metadata for token ID (%v)", params.TokenID)
+	}
+	return metadata
+}
+
 type ChangeURIParams struct {
 	NewURI 		string
 	TokenID 	big.Int
@@ -393,6 +775,12 @@ func (a Actor) ChangeURI(rt Runtime, params *ChangeURIParams) *abi.EmptyValue {
 		ura, err := urisArray.Root()
 		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to flush urisArray")
 		st.URIs = ura
+
+		recordEvents(rt, &st, uriChangeEvent(URIEvent{
+			Operator: tokenOperator,
+			TokenID:  params.TokenID,
+			URI:      params.NewURI,
+		}))
 	})
 
 	return nil
@@ -412,7 +800,10 @@ func (a Actor) SafeTransferFrom(rt Runtime, params *SafeTransferFromParams) *abi
 		rt.Abortf(exitcode.ErrIllegalArgument, "empty address : %v , %v", params.AddrFrom, params.AddrTo)
 	}
 
-	if params.AddrFrom == params.AddrTo {
+	// Balances, holdings and approvals are keyed by the holders' ID addresses.
+	addrFrom := resolveHolder(rt, params.AddrFrom)
+	addrTo := resolveHolder(rt, params.AddrTo)
+	if addrFrom == addrTo {
 		rt.Abortf(exitcode.ErrIllegalArgument, "cant not be the same address : %v , %v", params.AddrFrom, params.AddrTo)
 	}
 
@@ -432,20 +823,8 @@ func (a Actor) SafeTransferFrom(rt Runtime, params *SafeTransferFromParams) *abi
 	isAllApproveMap, err := adt.AsMap(store, st.Approves, builtin.DefaultHamtBitwidth)
 	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load isAllApproveMap")
 
-	if params.AddrFrom != tokenOperator {
-		addrApproveMap, found, err := st.LoadAddrApproveMap(store, isAllApproveMap, params.AddrFrom)
-		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load addrApproveMap for %v", params.AddrFrom)
-		if !found {
-			rt.Abortf(exitcode.ErrIllegalArgument, "The caller does not have permission")
-		}
-		approveMap, err := adt.AsMap(store, addrApproveMap.AddrApproveMap, builtin.DefaultHamtBitwidth)
-		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load approveMap")
-		res, found, err := st.LoadAddrApprove(approveMap, tokenOperator)
-		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load approveMap")
-		if !found || !res {
-			rt.Abortf(exitcode.ErrIllegalArgument, "The caller does not have permission")
-		}
-	}
+	// Operators not approved for all of the holder's tokens draw down their per-token allowance.
+	useAllowance := addrFrom != tokenOperator && !isApprovedForAll(rt, &st, store, addrFrom, tokenOperator)
 
 	balanceArray, err := adt.AsArray(store, st.Balances, LaneStatesAmtBitwidth)
 	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load balanceArray")
@@ -457,30 +836,44 @@ func (a Actor) SafeTransferFrom(rt Runtime, params *SafeTransferFromParams) *abi
 
 	addrTokenAmount, err := adt.AsMap(store, addrTokenAmountMap.AddrTokenAmountMap, builtin.DefaultHamtBitwidth)
 	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load balanceMap")
-	tokenAmountFrom, found, err := st.LoadAddrTokenAmount(addrTokenAmount, params.AddrFrom)
+	tokenAmountFrom, found, err := st.LoadAddrTokenAmount(addrTokenAmount, addrFrom)
 	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load balanceMap")
 	if ((addrTokenAmountMap == nil || !found) && params.Value.GreaterThan(big.Zero())) || params.Value.GreaterThan(tokenAmountFrom) {
 		rt.Abortf(exitcode.ErrIllegalArgument, "The balance is not enough for transfer")
 	}
 
 	rt.StateTransaction(&st, func() {
+		if useAllowance {
+			spendAllowance(rt, &st, store, addrFrom, tokenOperator, params.TokenID, params.Value)
+		}
+
 		tokenAmountFrom = big.Sub(tokenAmountFrom, params.Value)
-		err = st.putAddrTokenAmount(addrTokenAmount, params.AddrFrom, tokenAmountFrom)
+		err = st.putAddrTokenAmount(addrTokenAmount, addrFrom, tokenAmountFrom)
 		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to put balanceMap")
 
-		tokenAmountTo, found, err := st.LoadAddrTokenAmount(addrTokenAmount, params.AddrTo)
+		tokenAmountTo, found, err := st.LoadAddrTokenAmount(addrTokenAmount, addrTo)
 		tokenAmountTo = big.Add(tokenAmountTo, params.Value)
-		err = st.putAddrTokenAmount(addrTokenAmount, params.AddrTo, tokenAmountTo)
+		err = st.putAddrTokenAmount(addrTokenAmount, addrTo, tokenAmountTo)
 		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to put balanceMap")
 
-		_, found, err = st.LoadAddrApproveMap(store, isAllApproveMap, params.AddrTo)
-		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load addrApproveMap for %v", params.AddrTo)
+		holdingsMap, err := adt.AsMap(store, st.Holdings, builtin.DefaultHamtBitwidth)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load holdingsMap")
+		err = st.updateHolding(store, holdingsMap, addrFrom, params.TokenID, tokenAmountFrom)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to update holdings of %v", addrFrom)
+		err = st.updateHolding(store, holdingsMap, addrTo, params.TokenID, tokenAmountTo)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to update holdings of %v", addrTo)
+		hdm, err := holdingsMap.Root()
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to flush holdingsMap")
+		st.Holdings = hdm
+
+		_, found, err = st.LoadAddrApproveMap(store, isAllApproveMap, addrTo)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load addrApproveMap for %v", addrTo)
 		if !found {
 			apMap, err := adt.StoreEmptyMap(adt.AsStore(rt), builtin.DefaultHamtBitwidth)
 			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to create state")
 			var addrApproveMapTmp AddrApproveMap
 			addrApproveMapTmp.AddrApproveMap = apMap
-			err = st.putAddrApproveMap(store, isAllApproveMap, params.AddrTo, &addrApproveMapTmp)
+			err = st.putAddrApproveMap(store, isAllApproveMap, addrTo, &addrApproveMapTmp)
 			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to put isAllApproveMap")
 		}
 
@@ -497,8 +890,26 @@ func (a Actor) SafeTransferFrom(rt Runtime, params *SafeTransferFromParams) *abi
 		iam, err := isAllApproveMap.Root()
 		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to flush isAllApproveMap")
 		st.Approves = iam
+
+		recordEvents(rt, &st, transferEvent(TransferEvent{
+			Operator: tokenOperator,
+			From:     addrFrom,
+			To:       addrTo,
+			TokenID:  params.TokenID,
+			Value:    params.Value,
+		}))
 	})
 
+	if receiver, ok := tokenReceiver(rt, addrTo); ok {
+		code := rt.Send(receiver, builtin.MethodsTokenReceiver.OnTokenReceived, &OnTokenReceivedParams{
+			Operator: tokenOperator,
+			From:     addrFrom,
+			TokenID:  params.TokenID,
+			Value:    params.Value,
+		}, big.Zero(), &builtin.Discard{})
+		builtin.RequireSuccess(rt, code, "receiver %v rejected token transfer", addrTo)
+	}
+
 	return nil
 }
 
@@ -516,7 +927,10 @@ func (a Actor) SafeBatchTransferFrom(rt Runtime, params *SafeBatchTransferFromPa
 		rt.Abortf(exitcode.ErrIllegalArgument, "empty address : %v , %v", params.AddrFrom, params.AddrTo)
 	}
 
-	if params.AddrFrom == params.AddrTo {
+	// Balances, holdings and approvals are keyed by the holders' ID addresses.
+	addrFrom := resolveHolder(rt, params.AddrFrom)
+	addrTo := resolveHolder(rt, params.AddrTo)
+	if addrFrom == addrTo {
 		rt.Abortf(exitcode.ErrIllegalArgument, "cant not be the same address : %v , %v", params.AddrFrom, params.AddrTo)
 	}
 
@@ -532,20 +946,8 @@ func (a Actor) SafeBatchTransferFrom(rt Runtime, params *SafeBatchTransferFromPa
 	isAllApproveMap, err := adt.AsMap(store, st.Approves, builtin.DefaultHamtBitwidth)
 	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load isAllApproveMap")
 
-	if params.AddrFrom != tokenOperator {
-		addrApproveMap, found, err := st.LoadAddrApproveMap(store, isAllApproveMap, params.AddrFrom)
-		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load addrApproveMap for %v", params.AddrFrom)
-		if !found {
-			rt.Abortf(exitcode.ErrIllegalArgument, "The caller does not have permission")
-		}
-		approveMap, err := adt.AsMap(store, addrApproveMap.AddrApproveMap, builtin.DefaultHamtBitwidth)
-		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load approveMap")
-		res, found, err := st.LoadAddrApprove(approveMap, tokenOperator)
-		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load approveMap")
-		if !found || !res {
-			rt.Abortf(exitcode.ErrIllegalArgument, "The caller does not have permission")
-		}
-	}
+	// Operators not approved for all of the holder's tokens draw down their per-token allowance.
+	useAllowance := addrFrom != tokenOperator && !isApprovedForAll(rt, &st, store, addrFrom, tokenOperator)
 
 	var addrTokenAmountMaps []*AddrTokenAmountMap
 	var addrTokenAmounts	[]*adt.Map
@@ -571,13 +973,13 @@ func (a Actor) SafeBatchTransferFrom(rt Runtime, params *SafeBatchTransferFromPa
 
 		addrTokenAmount, err := adt.AsMap(store, addrTokenAmountMap.AddrTokenAmountMap, builtin.DefaultHamtBitwidth)
 		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load balanceMap")
-		tokenAmountFrom, found, err := st.LoadAddrTokenAmount(addrTokenAmount, params.AddrFrom)
+		tokenAmountFrom, found, err := st.LoadAddrTokenAmount(addrTokenAmount, addrFrom)
 		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load balanceMap")
 		if ((addrTokenAmountMap == nil || !found) && params.Values[idx].GreaterThan(big.Zero())) || params.Values[idx].GreaterThan(tokenAmountFrom) {
 			rt.Abortf(exitcode.ErrIllegalArgument, "The %vth balance is not enough for transfer : %v-%v", idx + 1, tokenAmountFrom, params.Values[idx])
 		}
 
-		tokenAmountTo, found, err := st.LoadAddrTokenAmount(addrTokenAmount, params.AddrTo)
+		tokenAmountTo, found, err := st.LoadAddrTokenAmount(addrTokenAmount, addrTo)
 		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load balanceMap")
 
 		addrTokenAmountMaps = append(addrTokenAmountMaps, addrTokenAmountMap)
@@ -590,10 +992,10 @@ func (a Actor) SafeBatchTransferFrom(rt Runtime, params *SafeBatchTransferFromPa
 	for idx, _ := range params.TokenIDs {
 		rt.StateTransaction(&st, func() {
 
-			err = st.putAddrTokenAmount(addrTokenAmounts[idx], params.AddrFrom, tokenAmountFroms[idx])
+			err = st.putAddrTokenAmount(addrTokenAmounts[idx], addrFrom, tokenAmountFroms[idx])
 			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to put balanceMap")
 
-			err = st.putAddrTokenAmount(addrTokenAmounts[idx], params.AddrTo, tokenAmountTos[idx])
+			err = st.putAddrTokenAmount(addrTokenAmounts[idx], addrTo, tokenAmountTos[idx])
 			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to put balanceMap")
 
 			ata, err := addrTokenAmounts[idx].Root()
@@ -608,26 +1010,65 @@ func (a Actor) SafeBatchTransferFrom(rt Runtime, params *SafeBatchTransferFromPa
 	}
 
 	rt.StateTransaction(&st, func() {
+		if useAllowance {
+			for idx := range params.TokenIDs {
+				spendAllowance(rt, &st, store, addrFrom, tokenOperator, params.TokenIDs[idx], params.Values[idx])
+			}
+		}
+
+		holdingsMap, err := adt.AsMap(store, st.Holdings, builtin.DefaultHamtBitwidth)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load holdingsMap")
+		for idx := range params.TokenIDs {
+			err = st.updateHolding(store, holdingsMap, addrFrom, params.TokenIDs[idx], tokenAmountFroms[idx])
+			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to update holdings of %v", addrFrom)
+			err = st.updateHolding(store, holdingsMap, addrTo, params.TokenIDs[idx], tokenAmountTos[idx])
+			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to update holdings of %v", addrTo)
+		}
+		hdm, err := holdingsMap.Root()
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to flush holdingsMap")
+		st.Holdings = hdm
 
 		bla, err := balanceArray.Root()
 		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to flush balanceArray")
 		st.Balances = bla
 
-		_, found, err := st.LoadAddrApproveMap(store, isAllApproveMap, params.AddrTo)
-		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load addrApproveMap for %v", params.AddrTo)
+		_, found, err := st.LoadAddrApproveMap(store, isAllApproveMap, addrTo)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load addrApproveMap for %v", addrTo)
 		if !found {
 			apMap, err := adt.StoreEmptyMap(adt.AsStore(rt), builtin.DefaultHamtBitwidth)
 			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to create state")
 			var addrApproveMapTmp AddrApproveMap
 			addrApproveMapTmp.AddrApproveMap = apMap
-			err = st.putAddrApproveMap(store, isAllApproveMap, params.AddrTo, &addrApproveMapTmp)
+			err = st.putAddrApproveMap(store, isAllApproveMap, addrTo, &addrApproveMapTmp)
 			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to put isAllApproveMap")
 		}
 		iam, err := isAllApproveMap.Root()
 		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to flush isAllApproveMap")
 		st.Approves = iam
+
+		events := make([]TokenEvent, len(params.TokenIDs))
+		for idx := range params.TokenIDs {
+			events[idx] = transferEvent(TransferEvent{
+				Operator: tokenOperator,
+				From:     addrFrom,
+				To:       addrTo,
+				TokenID:  params.TokenIDs[idx],
+				Value:    params.Values[idx],
+			})
+		}
+		recordEvents(rt, &st, events...)
 	})
 
+	if receiver, ok := tokenReceiver(rt, addrTo); ok {
+		code := rt.Send(receiver, builtin.MethodsTokenReceiver.OnBatchTokenReceived, &OnBatchTokenReceivedParams{
+			Operator: tokenOperator,
+			From:     addrFrom,
+			TokenIDs: params.TokenIDs,
+			Values:   params.Values,
+		}, big.Zero(), &builtin.Discard{})
+		builtin.RequireSuccess(rt, code, "receiver %v rejected batch token transfer", addrTo)
+	}
+
 	return nil
 }
 
@@ -739,4 +1180,471 @@ func (a Actor) IsApproveForAll(rt Runtime, params *IsApproveForAllParams) *IsApp
 	return &IsApprovedForAllResults{res}
 }
 
+type TotalSupplyParams struct {
+	TokenID big.Int
+}
+
+type TotalSupplyResults struct {
+	Supply abi.TokenAmount
+}
+
+func (a Actor) TotalSupply(rt Runtime, params *TotalSupplyParams) *TotalSupplyResults {
+	rt.ValidateImmediateCallerAcceptAny()
+
+	store := adt.AsStore(rt)
+	var st State
+	rt.StateReadonly(&st)
+
+	if params.TokenID.GreaterThan(st.Nonce) {
+		rt.Abortf(exitcode.ErrIllegalArgument, "Invalid token ID (%v) greater than actual maxID (%v)", params.TokenID, st.Nonce)
+	}
+
+	suppliesArray, err := adt.AsArray(store, st.Supplies, LaneStatesAmtBitwidth)
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load suppliesArray")
+	supply, _, err := st.LoadTokenSupply(suppliesArray, params.TokenID)
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load supply for %v", params.TokenID)
+
+	return &TotalSupplyResults{Supply: supply}
+}
+
+type TotalSupplyBatchParams struct {
+	TokenIDs []big.Int
+}
+
+type TotalSupplyBatchResults struct {
+	Supplies []abi.TokenAmount
+}
+
+func (a Actor) TotalSupplyBatch(rt Runtime, params *TotalSupplyBatchParams) *TotalSupplyBatchResults {
+	rt.ValidateImmediateCallerAcceptAny()
+
+	store := adt.AsStore(rt)
+	var st State
+	rt.StateReadonly(&st)
+
+	for _, tokenID := range params.TokenIDs {
+		if tokenID.GreaterThan(st.Nonce) {
+			rt.Abortf(exitcode.ErrIllegalArgument, "Invalid token ID (%v) greater than actual maxID (%v)", tokenID, st.Nonce)
+		}
+	}
+
+	suppliesArray, err := adt.AsArray(store, st.Supplies, LaneStatesAmtBitwidth)
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load suppliesArray")
+
+	supplies := make([]abi.TokenAmount, 0, len(params.TokenIDs))
+	for _, tokenID := range params.TokenIDs {
+		supply, _, err := st.LoadTokenSupply(suppliesArray, tokenID)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load supply for %v", tokenID)
+		supplies = append(supplies, supply)
+	}
+
+	return &TotalSupplyBatchResults{Supplies: supplies}
+}
+
+type BurnParams struct {
+	AddrFrom addr.Address
+	TokenID  big.Int
+	Amount   abi.TokenAmount
+}
+
+// Burn destroys tokens held by AddrFrom. The caller must be the holder or an operator
+// approved by the holder through SetApproveForAll.
+func (a Actor) Burn(rt Runtime, params *BurnParams) *abi.EmptyValue {
+	rt.ValidateImmediateCallerAcceptAny()
+
+	if params.AddrFrom.Empty() {
+		rt.Abortf(exitcode.ErrIllegalArgument, "empty address : %v", params.AddrFrom)
+	}
+	if params.Amount.LessThan(big.Zero()) {
+		rt.Abortf(exitcode.ErrIllegalArgument, "Illegal token amount : %v", params.Amount)
+	}
+
+	store := adt.AsStore(rt)
+	var st State
+	rt.StateReadonly(&st)
+
+	if params.TokenID.GreaterThan(st.Nonce) {
+		rt.Abortf(exitcode.ErrIllegalArgument, "Invalid token ID (%v) greater than actual maxID (%v)", params.TokenID, st.Nonce)
+	}
+	addrFrom := resolveHolder(rt, params.AddrFrom)
+	requireApprovedForAll(rt, &st, store, addrFrom)
+
+	rt.StateTransaction(&st, func() {
+		balanceArray, err := adt.AsArray(store, st.Balances, LaneStatesAmtBitwidth)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load balanceArray")
+		suppliesArray, err := adt.AsArray(store, st.Supplies, LaneStatesAmtBitwidth)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load suppliesArray")
+		holdingsMap, err := adt.AsMap(store, st.Holdings, builtin.DefaultHamtBitwidth)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load holdingsMap")
+
+		burnTokens(rt, &st, store, balanceArray, suppliesArray, holdingsMap, addrFrom, params.TokenID, params.Amount)
+
+		bla, err := balanceArray.Root()
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to flush balanceArray")
+		st.Balances = bla
+		spa, err := suppliesArray.Root()
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to flush suppliesArray")
+		st.Supplies = spa
+		hdm, err := holdingsMap.Root()
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to flush holdingsMap")
+		st.Holdings = hdm
+
+		recordEvents(rt, &st, transferEvent(TransferEvent{
+			Operator: rt.Caller(),
+			From:     addrFrom,
+			To:       rt.Receiver(),
+			TokenID:  params.TokenID,
+			Value:    params.Amount,
+		}))
+	})
+
+	return nil
+}
+
+type BurnBatchParams struct {
+	AddrFrom addr.Address
+	TokenIDs []big.Int
+	Amounts  []abi.TokenAmount
+}
+
+func (a Actor) BurnBatch(rt Runtime, params *BurnBatchParams) *abi.EmptyValue {
+	rt.ValidateImmediateCallerAcceptAny()
+
+	if params.AddrFrom.Empty() {
+		rt.Abortf(exitcode.ErrIllegalArgument, "empty address : %v", params.AddrFrom)
+	}
+	if len(params.TokenIDs) != len(params.Amounts) {
+		rt.Abortf(exitcode.ErrIllegalArgument, "The length of the tokenIDs array does not match the burn amount array")
+	}
+
+	store := adt.AsStore(rt)
+	var st State
+	rt.StateReadonly(&st)
+
+	for idx := range params.TokenIDs {
+		if params.TokenIDs[idx].GreaterThan(st.Nonce) {
+			rt.Abortf(exitcode.ErrIllegalArgument, "Invalid token ID (%v) greater than actual maxID (%v)", params.TokenIDs[idx], st.Nonce)
+		}
+		if params.Amounts[idx].LessThan(big.Zero()) {
+			rt.Abortf(exitcode.ErrIllegalArgument, "Illegal token amount : %v", params.Amounts[idx])
+		}
+	}
+	addrFrom := resolveHolder(rt, params.AddrFrom)
+	requireApprovedForAll(rt, &st, store, addrFrom)
+
+	rt.StateTransaction(&st, func() {
+		balanceArray, err := adt.AsArray(store, st.Balances, LaneStatesAmtBitwidth)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load balanceArray")
+		suppliesArray, err := adt.AsArray(store, st.Supplies, LaneStatesAmtBitwidth)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load suppliesArray")
+		holdingsMap, err := adt.AsMap(store, st.Holdings, builtin.DefaultHamtBitwidth)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load holdingsMap")
+
+		events := make([]TokenEvent, len(params.TokenIDs))
+		for idx := range params.TokenIDs {
+			burnTokens(rt, &st, store, balanceArray, suppliesArray, holdingsMap, addrFrom, params.TokenIDs[idx], params.Amounts[idx])
+			events[idx] = transferEvent(TransferEvent{
+				Operator: rt.Caller(),
+				From:     addrFrom,
+				To:       rt.Receiver(),
+				TokenID:  params.TokenIDs[idx],
+				Value:    params.Amounts[idx],
+			})
+		}
+
+		bla, err := balanceArray.Root()
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to flush balanceArray")
+		st.Balances = bla
+		spa, err := suppliesArray.Root()
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to flush suppliesArray")
+		st.Supplies = spa
+		hdm, err := holdingsMap.Root()
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to flush holdingsMap")
+		st.Holdings = hdm
+
+		recordEvents(rt, &st, events...)
+	})
+
+	return nil
+}
+
+type ApproveParams struct {
+	Operator addr.Address
+	TokenID  big.Int
+	Amount   abi.TokenAmount
+}
+
+// Approve sets the amount of TokenID that Operator may transfer on behalf of the caller,
+// replacing any previous allowance. A zero amount revokes the allowance.
+func (a Actor) Approve(rt Runtime, params *ApproveParams) *abi.EmptyValue {
+	rt.ValidateImmediateCallerAcceptAny()
+
+	if params.Operator.Empty() {
+		rt.Abortf(exitcode.ErrIllegalArgument, "empty address : %v", params.Operator)
+	}
+	if params.Amount.LessThan(big.Zero()) {
+		rt.Abortf(exitcode.ErrIllegalArgument, "Illegal token amount : %v", params.Amount)
+	}
+
+	// Allowances are spent by the operator's ID address, so they are keyed by it.
+	operator, ok := rt.ResolveAddress(params.Operator)
+	if !ok {
+		rt.Abortf(exitcode.ErrIllegalArgument, "unable to resolve address %v", params.Operator)
+	}
+	tokenOperator := rt.Caller()
+	if tokenOperator == operator {
+		rt.Abortf(exitcode.ErrIllegalArgument, "target address cant be self: %v", params.Operator)
+	}
 
+	store := adt.AsStore(rt)
+	var st State
+	rt.StateReadonly(&st)
+
+	if params.TokenID.GreaterThan(st.Nonce) {
+		rt.Abortf(exitcode.ErrIllegalArgument, "Invalid token ID (%v) greater than actual maxID (%v)", params.TokenID, st.Nonce)
+	}
+
+	rt.StateTransaction(&st, func() {
+		allowancesMap, err := adt.AsMap(store, st.Allowances, builtin.DefaultHamtBitwidth)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load allowancesMap")
+		err = st.setAllowance(store, allowancesMap, tokenOperator, operator, params.TokenID, params.Amount)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to set allowance")
+		alm, err := allowancesMap.Root()
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to flush allowancesMap")
+		st.Allowances = alm
+	})
+
+	return nil
+}
+
+type AllowanceParams struct {
+	Owner    addr.Address
+	Operator addr.Address
+	TokenID  big.Int
+}
+
+type AllowanceResults struct {
+	Amount abi.TokenAmount
+}
+
+func (a Actor) Allowance(rt Runtime, params *AllowanceParams) *AllowanceResults {
+	rt.ValidateImmediateCallerAcceptAny()
+
+	if params.Owner.Empty() || params.Operator.Empty() {
+		rt.Abortf(exitcode.ErrIllegalArgument, "empty address : %v , %v", params.Owner, params.Operator)
+	}
+
+	owner, ownerOk := rt.ResolveAddress(params.Owner)
+	operator, operatorOk := rt.ResolveAddress(params.Operator)
+	if !ownerOk || !operatorOk {
+		return &AllowanceResults{Amount: big.Zero()}
+	}
+
+	store := adt.AsStore(rt)
+	var st State
+	rt.StateReadonly(&st)
+
+	allowancesMap, err := adt.AsMap(store, st.Allowances, builtin.DefaultHamtBitwidth)
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load allowancesMap")
+	amount, err := st.LoadAllowance(store, allowancesMap, owner, operator, params.TokenID)
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load allowance")
+
+	return &AllowanceResults{Amount: amount}
+}
+
+// Parameters of GetEvents, selecting the epoch whose events are returned.
+type GetEventsParams struct {
+	Epoch abi.ChainEpoch
+}
+
+// GetEvents returns the transfers, mints, burns and URI changes recorded at an epoch
+// within the last EventRetentionEpochs epochs, in the order they occurred.
+func (a Actor) GetEvents(rt Runtime, params *GetEventsParams) *EpochEvents {
+	rt.ValidateImmediateCallerAcceptAny()
+
+	if params.Epoch < 0 {
+		rt.Abortf(exitcode.ErrIllegalArgument, "negative epoch %d", params.Epoch)
+	}
+	currEpoch := rt.CurrEpoch()
+	if params.Epoch > currEpoch || params.Epoch < currEpoch-EventRetentionEpochs {
+		rt.Abortf(exitcode.ErrIllegalArgument, "epoch %d is outside the event retention window [%d, %d]",
+			params.Epoch, currEpoch-EventRetentionEpochs, currEpoch)
+	}
+
+	var st State
+	rt.StateReadonly(&st)
+
+	events, found, err := st.LoadEvents(adt.AsStore(rt), params.Epoch)
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load events for epoch %d", params.Epoch)
+	if !found {
+		return &EpochEvents{Events: []TokenEvent{}}
+	}
+	return events
+}
+
+// Parameters of the OnTokenReceived hook, sent to a non-account actor credited by SafeTransferFrom.
+type OnTokenReceivedParams struct {
+	Operator addr.Address
+	From     addr.Address
+	TokenID  big.Int
+	Value    abi.TokenAmount
+}
+
+// Parameters of the OnBatchTokenReceived hook, sent to a non-account actor credited by SafeBatchTransferFrom.
+type OnBatchTokenReceivedParams struct {
+	Operator addr.Address
+	From     addr.Address
+	TokenIDs []big.Int
+	Values   []abi.TokenAmount
+}
+
+// Returns the ID address of the recipient if it is an existing actor that must accept tokens through
+// a receiver hook. Account actors, and addresses with no actor yet, are credited without notification.
+func tokenReceiver(rt Runtime, addrTo addr.Address) (addr.Address, bool) {
+	resolved, ok := rt.ResolveAddress(addrTo)
+	if !ok {
+		return addr.Undef, false
+	}
+	code, ok := rt.GetActorCodeCID(resolved)
+	if !ok || code.Equals(builtin.AccountActorCodeID) {
+		return addr.Undef, false
+	}
+	return resolved, true
+}
+
+// Applies update to the minters of a token on behalf of its creator.
+func updateMinters(rt Runtime, params *MinterParams, update func(minters *adt.Set, minter addr.Address) error) {
+	rt.ValidateImmediateCallerAcceptAny()
+
+	if params.Minter.Empty() {
+		rt.Abortf(exitcode.ErrIllegalArgument, "empty address : %v", params.Minter)
+	}
+	// Minting is authorized by the caller's ID address, so minters are keyed by it.
+	minter, ok := rt.ResolveAddress(params.Minter)
+	if !ok {
+		rt.Abortf(exitcode.ErrIllegalArgument, "unable to resolve address %v", params.Minter)
+	}
+
+	store := adt.AsStore(rt)
+	var st State
+	rt.StateTransaction(&st, func() {
+		creatorsArray, err := adt.AsArray(store, st.Creators, LaneStatesAmtBitwidth)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load creatorsArray")
+		creator, found, err := st.GetCreatorAddress(creatorsArray, params.TokenID)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to get creators by tokenID : %v", params.TokenID)
+		if !found || creator != rt.Caller() {
+			rt.Abortf(exitcode.ErrIllegalArgument, "The caller %v is not the creator for token with tokenID : %v", rt.Caller(), params.TokenID)
+		}
+
+		mintersArray, err := adt.AsArray(store, st.Minters, LaneStatesAmtBitwidth)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load mintersArray")
+		minters, err := st.LoadMinters(store, mintersArray, params.TokenID)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load minters for tokenID : %v", params.TokenID)
+		err = update(minters, minter)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to update minters for tokenID : %v", params.TokenID)
+		err = st.putMinters(store, mintersArray, params.TokenID, minters)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to put minters for tokenID : %v", params.TokenID)
+		mna, err := mintersArray.Root()
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to flush mintersArray")
+		st.Minters = mna
+	})
+}
+
+// Records the events of a message. Methods call this once, with all the events the message emits.
+func recordEvents(rt Runtime, st *State, events ...TokenEvent) {
+	err := st.recordEvents(adt.AsStore(rt), rt.CurrEpoch(), events)
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to record token events")
+}
+
+// Aborts unless the caller is addrFrom or has been approved by addrFrom through SetApproveForAll.
+func requireApprovedForAll(rt Runtime, st *State, store adt.Store, addrFrom addr.Address) {
+	tokenOperator := rt.Caller()
+	owner := resolveHolder(rt, addrFrom)
+	if owner != tokenOperator && !isApprovedForAll(rt, st, store, owner, tokenOperator) {
+		rt.Abortf(exitcode.ErrIllegalArgument, "The caller does not have permission")
+	}
+}
+
+func isApprovedForAll(rt Runtime, st *State, store adt.Store, addrFrom addr.Address, tokenOperator addr.Address) bool {
+	isAllApproveMap, err := adt.AsMap(store, st.Approves, builtin.DefaultHamtBitwidth)
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load isAllApproveMap")
+	addrApproveMap, found, err := st.LoadAddrApproveMap(store, isAllApproveMap, addrFrom)
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load addrApproveMap for %v", addrFrom)
+	if !found {
+		return false
+	}
+	approveMap, err := adt.AsMap(store, addrApproveMap.AddrApproveMap, builtin.DefaultHamtBitwidth)
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load approveMap")
+	res, found, err := st.LoadAddrApprove(approveMap, tokenOperator)
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load approveMap")
+	return found && res
+}
+
+// Returns the ID address of a holder, by which its balances, holdings, approvals and allowances are keyed.
+// An address that does not resolve is returned unchanged.
+func resolveHolder(rt Runtime, holder addr.Address) addr.Address {
+	if resolved, ok := rt.ResolveAddress(holder); ok {
+		return resolved
+	}
+	return holder
+}
+
+// Deducts amount from the allowance of tokenOperator over addrFrom's tokenID, aborting if it is insufficient.
+func spendAllowance(rt Runtime, st *State, store adt.Store, addrFrom addr.Address, tokenOperator addr.Address, tokenID big.Int, amount abi.TokenAmount) {
+	allowancesMap, err := adt.AsMap(store, st.Allowances, builtin.DefaultHamtBitwidth)
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load allowancesMap")
+	allowance, err := st.LoadAllowance(store, allowancesMap, addrFrom, tokenOperator, tokenID)
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load allowance")
+	if allowance.IsZero() {
+		rt.Abortf(exitcode.ErrIllegalArgument, "The caller does not have permission")
+	}
+	if amount.GreaterThan(allowance) {
+		rt.Abortf(exitcode.ErrIllegalArgument, "The allowance is not enough for transfer : %v-%v", allowance, amount)
+	}
+
+	err = st.setAllowance(store, allowancesMap, addrFrom, tokenOperator, tokenID, big.Sub(allowance, amount))
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to update allowance")
+	alm, err := allowancesMap.Root()
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to flush allowancesMap")
+	st.Allowances = alm
+}
+
+// Removes amount of tokenID from addrFrom's balance and the token's supply.
+// Balance entries that reach zero are removed from the token's balance map.
+func burnTokens(rt Runtime, st *State, store adt.Store, balanceArray, suppliesArray *adt.Array, holdingsMap *adt.Map, addrFrom addr.Address, tokenID big.Int, amount abi.TokenAmount) {
+	addrTokenAmountMap, found, err := st.LoadAddrTokenAmountMap(store, balanceArray, tokenID)
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load addrTokenAmountMap for %v", tokenID)
+	if !found {
+		rt.Abortf(exitcode.ErrIllegalArgument, "The balance is not enough for burn : %v-%v", big.Zero(), amount)
+	}
+
+	tokenAmountMap, err := adt.AsMap(store, addrTokenAmountMap.AddrTokenAmountMap, builtin.DefaultHamtBitwidth)
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load balanceMap")
+	tokenAmount, found, err := st.LoadAddrTokenAmount(tokenAmountMap, addrFrom)
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to load addrTokenAmount for %v - %v", tokenID, addrFrom)
+	if amount.GreaterThan(tokenAmount) {
+		rt.Abortf(exitcode.ErrIllegalArgument, "The balance is not enough for burn : %v-%v", tokenAmount, amount)
+	}
+
+	tokenAmount = big.Sub(tokenAmount, amount)
+	if tokenAmount.IsZero() {
+		if found {
+			err = st.removeAddrTokenAmount(tokenAmountMap, addrFrom)
+			builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to remove addrTokenAmount for %v - %v", tokenID, addrFrom)
+		}
+	} else {
+		err = st.putAddrTokenAmount(tokenAmountMap, addrFrom, tokenAmount)
+		builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to put addrTokenAmount for %v - %v", tokenID, addrFrom)
+	}
+
+	tam, err := tokenAmountMap.Root()
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to flush tokenAmountMap")
+	addrTokenAmountMap.AddrTokenAmountMap = tam
+	err = st.putAddrTokenAmountMap(store, balanceArray, tokenID, addrTokenAmountMap)
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to put balanceArray")
+	err = st.updateHolding(store, holdingsMap, addrFrom, tokenID, tokenAmount)
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to update holdings of %v", addrFrom)
+
+	_, err = st.updateTokenSupply(suppliesArray, tokenID, amount.Neg())
+	builtin.RequireNoErr(rt, err, exitcode.ErrIllegalState, "failed to update token supply")
+}
@@ -1,6 +1,8 @@
 package token
 
 import (
+	"errors"
+
 	addr "github.com/filecoin-project/go-address"
 	"github.com/filecoin-project/go-state-types/abi"
 	"github.com/filecoin-project/go-state-types/big"
@@ -17,12 +19,39 @@ type State struct {
 	Creators 		cid.Cid    // array, AMT[TokenID]addr.address
 	Balances 		cid.Cid    // array, AMT[TokenID]TokenAmountInAddressCid
 	Approves		cid.Cid    // Map, HAMT[address]ApproveTargetAddressCid
+	Supplies		cid.Cid    // array, AMT[TokenID]TokenAmount
+	Allowances		cid.Cid    // Map, HAMT[owner]HAMT[operator]HAMT[TokenID]TokenAmount
+	Events			cid.Cid    // array, AMT[ChainEpoch]AMT[index]TokenEvent, the events of each epoch in the order they occurred
+	Metadata		cid.Cid    // array, AMT[TokenID]TokenMetadata
+	PendingCreators	cid.Cid    // array, AMT[TokenID]addr.Address
+	Minters			cid.Cid    // array, AMT[TokenID]HAMT[address]EmptyValue
+	Holdings		cid.Cid    // Map, HAMT[address]HAMT[TokenID]EmptyValue, the tokens of which each holder ID address holds a positive balance
+	Minted			cid.Cid    // array, AMT[TokenID]TokenAmount, the total ever minted of each token, which burning does not reduce
 }
 
 type TokenURI struct {
 	TokenURI string
 }
 
+// Immutable description of a token, fixed when it is created.
+type TokenMetadata struct {
+	Name     string
+	Symbol   string
+	Decimals uint64
+	// Non-fungible tokens have a supply of at most one.
+	NonFungible bool
+	// Upper bound on the total supply. Zero means the supply is uncapped.
+	MaxSupply abi.TokenAmount
+}
+
+// Returns the maximum total supply of the token, or zero if it is uncapped.
+func (m *TokenMetadata) SupplyCap() abi.TokenAmount {
+	if m.NonFungible {
+		return big.NewInt(1)
+	}
+	return m.MaxSupply
+}
+
 type AddrTokenAmountMap struct {
 	AddrTokenAmountMap 	cid.Cid 	// Map, HAMT[address]tokenAmount
 }
@@ -31,8 +60,57 @@ type AddrApproveMap struct {
 	AddrApproveMap 		cid.Cid   	// Map, HAMT[Address]index
 }
 
+// A movement of tokens. Mints are recorded as transfers from the token actor, and burns as transfers to it.
+type TransferEvent struct {
+	Operator addr.Address
+	From     addr.Address
+	To       addr.Address
+	TokenID  big.Int
+	Value    abi.TokenAmount
+}
+
+// A change to the URI of a token, including the URI set when the token is created.
+type URIEvent struct {
+	Operator addr.Address
+	TokenID  big.Int
+	URI      string
+}
+
+// EventKind identifies the payload of an entry in the event log.
+type EventKind uint64
+
+const (
+	// A movement of tokens, including mints and burns.
+	EventKindTransfer EventKind = iota
+	// A change to the URI of a token.
+	EventKindURIChange
+)
+
+// An entry of the event log. Only the payload selected by Kind is set.
+type TokenEvent struct {
+	Kind      EventKind
+	Transfer  *TransferEvent
+	URIChange *URIEvent
+}
+
+func transferEvent(e TransferEvent) TokenEvent {
+	return TokenEvent{Kind: EventKindTransfer, Transfer: &e}
+}
+
+func uriChangeEvent(e URIEvent) TokenEvent {
+	return TokenEvent{Kind: EventKindURIChange, URIChange: &e}
+}
+
+// Events emitted by the token actor in a single epoch, in the order they occurred.
+type EpochEvents struct {
+	Events []TokenEvent
+}
+
 const LaneStatesAmtBitwidth = 3
 
+// Number of epochs for which events are retained in state before being pruned.
+const EventRetentionEpochs = builtin.EpochsInDay
+
 func ConstructState(store adt.Store) (*State, error) {
 	emptyArrayCid, err := adt.StoreEmptyArray(store, LaneStatesAmtBitwidth)
 	if err != nil {
@@ -50,6 +128,14 @@ func ConstructState(store adt.Store) (*State, error) {
 		Creators: emptyArrayCid,
 		Balances: emptyArrayCid,
 		Approves: emptyMapCid,
+		Supplies: emptyArrayCid,
+		Allowances: emptyMapCid,
+		Events: emptyArrayCid,
+		Metadata: emptyArrayCid,
+		PendingCreators: emptyArrayCid,
+		Minters: emptyArrayCid,
+		Holdings: emptyMapCid,
+		Minted: emptyArrayCid,
 	}, nil
 }
 
@@ -74,6 +160,61 @@ func (s *State) setCreatorAddress(creatorsArray *adt.Array, creator addr.Address
 	return nil
 }
 
+// Returns the address proposed to take over as creator of a token, if any.
+func (s *State) GetPendingCreator(pendingCreatorsArray *adt.Array, tokenID big.Int) (addr.Address, bool, error) {
+	var pending addr.Address
+	found, err := pendingCreatorsArray.Get(tokenID.Uint64(), &pending)
+	if err != nil {
+		return addr.Address{}, false, xerrors.Errorf("failed to get pending creator for tokenID: %v, err: %w", tokenID, err)
+	}
+	return pending, found, nil
+}
+
+func (s *State) setPendingCreator(pendingCreatorsArray *adt.Array, pending addr.Address, tokenID big.Int) error {
+	if err := pendingCreatorsArray.Set(tokenID.Uint64(), &pending); err != nil {
+		return xerrors.Errorf("failed to put pending creator for tokenID: %v, err: %w", tokenID, err)
+	}
+	return nil
+}
+
+func (s *State) clearPendingCreator(pendingCreatorsArray *adt.Array, tokenID big.Int) error {
+	if _, err := pendingCreatorsArray.TryDelete(tokenID.Uint64()); err != nil {
+		return xerrors.Errorf("failed to delete pending creator for tokenID: %v, err: %w", tokenID, err)
+	}
+	return nil
+}
+
+// Loads the set of addresses the creator of a token has delegated minting to, or an empty set if there are none.
+func (s *State) LoadMinters(store adt.Store, mintersArray *adt.Array, tokenID big.Int) (*adt.Set, error) {
+	var root cbg.CborCid
+	found, err := mintersArray.Get(tokenID.Uint64(), &root)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to get minters for tokenID: %v, err: %w", tokenID, err)
+	}
+	if !found {
+		return adt.MakeEmptySet(store, builtin.DefaultHamtBitwidth)
+	}
+	return adt.AsSet(store, cid.Cid(root), builtin.DefaultHamtBitwidth)
+}
+
+// Stores the minters of a token, removing the entry if there are none.
+func (s *State) putMinters(store adt.Store, mintersArray *adt.Array, tokenID big.Int, minters *adt.Set) error {
+	root, err := minters.Root()
+	if err != nil {
+		return xerrors.Errorf("failed to flush minters for tokenID: %v, err: %w", tokenID, err)
+	}
+	emptyRoot, err := adt.StoreEmptyMap(store, builtin.DefaultHamtBitwidth)
+	if err != nil {
+		return err
+	}
+	if root.Equals(emptyRoot) {
+		_, err = mintersArray.TryDelete(tokenID.Uint64())
+		return err
+	}
+	rootCborCid := cbg.CborCid(root)
+	return mintersArray.Set(tokenID.Uint64(), &rootCborCid)
+}
+
 func (s *State) LoadTokenURI(urisArray *adt.Array, tokenID big.Int) (*TokenURI, bool, error) {
 	var tokenURI TokenURI
 	found, err := urisArray.Get(tokenID.Uint64(), &tokenURI)
@@ -95,6 +236,77 @@ func (s *State) setTokenURI(urisArray *adt.Array, tokenUri string, tokenID big.I
 	return nil
 }
 
+func (s *State) LoadTokenMetadata(metadataArray *adt.Array, tokenID big.Int) (*TokenMetadata, bool, error) {
+	var metadata TokenMetadata
+	found, err := metadataArray.Get(tokenID.Uint64(), &metadata)
+	if err != nil {
+		return nil, false, xerrors.Errorf("failed to get metadata for tokenID: %v, err: %w", tokenID, err)
+	}
+	if !found {
+		return nil, false, nil
+	}
+	return &metadata, true, nil
+}
+
+func (s *State) setTokenMetadata(metadataArray *adt.Array, metadata *TokenMetadata, tokenID big.Int) error {
+	if err := metadataArray.Set(tokenID.Uint64(), metadata); err != nil {
+		return xerrors.Errorf("failed to put metadata for tokenID: %v, err: %w", tokenID, err)
+	}
+	return nil
+}
+
+func (s *State) LoadTokenSupply(suppliesArray *adt.Array, tokenID big.Int) (abi.TokenAmount, bool, error) {
+	var supply abi.TokenAmount
+	found, err := suppliesArray.Get(tokenID.Uint64(), &supply)
+	if err != nil {
+		return big.Zero(), found, xerrors.Errorf("failed to get supply for tokenID: %v, err: %w", tokenID, err)
+	}
+	if !found {
+		return big.Zero(), found, nil
+	}
+	return supply, found, nil
+}
+
+func (s *State) updateTokenSupply(suppliesArray *adt.Array, tokenID big.Int, amountDelta abi.TokenAmount) (abi.TokenAmount, error) {
+	supply, _, err := s.LoadTokenSupply(suppliesArray, tokenID)
+	if err != nil {
+		return big.Zero(), err
+	}
+	newSupply := big.Add(supply, amountDelta)
+	if newSupply.LessThan(big.Zero()) {
+		return supply, xerrors.Errorf("supply of tokenID: %v cannot be negative %s", tokenID, newSupply)
+	}
+	if err := suppliesArray.Set(tokenID.Uint64(), &newSupply); err != nil {
+		return supply, xerrors.Errorf("failed to put supply for tokenID: %v, err: %w", tokenID, err)
+	}
+	return newSupply, nil
+}
+
+func (s *State) LoadTokenMinted(mintedArray *adt.Array, tokenID big.Int) (abi.TokenAmount, bool, error) {
+	var minted abi.TokenAmount
+	found, err := mintedArray.Get(tokenID.Uint64(), &minted)
+	if err != nil {
+		return big.Zero(), found, xerrors.Errorf("failed to get minted amount for tokenID: %v, err: %w", tokenID, err)
+	}
+	if !found {
+		return big.Zero(), found, nil
+	}
+	return minted, found, nil
+}
+
+// Adds a newly minted amount to the total ever minted of tokenID, returning the new total.
+func (s *State) addTokenMinted(mintedArray *adt.Array, tokenID big.Int, amount abi.TokenAmount) (abi.TokenAmount, error) {
+	minted, _, err := s.LoadTokenMinted(mintedArray, tokenID)
+	if err != nil {
+		return big.Zero(), err
+	}
+	newMinted := big.Add(minted, amount)
+	if err := mintedArray.Set(tokenID.Uint64(), &newMinted); err != nil {
+		return minted, xerrors.Errorf("failed to put minted amount for tokenID: %v, err: %w", tokenID, err)
+	}
+	return newMinted, nil
+}
+
 func (s *State) LoadAddrTokenAmountMap(store adt.Store, balanceArray *adt.Array, tokenID big.Int) (*AddrTokenAmountMap, bool, error) {
 
 	var addrTokenAmountMapCborCid cbg.CborCid
@@ -146,6 +358,13 @@ func (s *State) putAddrTokenAmount(balanceMap *adt.Map, tokenOperator addr.Addre
 	return nil
 }
 
+func (s *State) removeAddrTokenAmount(balanceMap *adt.Map, tokenOperator addr.Address) error {
+	if err := balanceMap.Delete(abi.AddrKey(tokenOperator)); err != nil {
+		return xerrors.Errorf("failed to remove AddrTokenAmount for %v: %w", tokenOperator, err)
+	}
+	return nil
+}
+
 func (s *State) LoadAddrApproveMap(store adt.Store, isAllApproveMap *adt.Map, tokenOperator addr.Address) (*AddrApproveMap, bool, error) {
 
 	var addrApproveMapCborCid cbg.CborCid
@@ -187,7 +406,7 @@ func (s *State) LoadAddrApprove(approveMap *adt.Map, tokenOperator addr.Address)
 		isApprove = big.Zero()
 	}
 
-	return isApprove != big.Zero(), found, err
+	return !isApprove.IsZero(), found, err
 }
 
 func (s *State) putAddrApprove(approveMap *adt.Map, tokenOperator addr.Address, isApprove bool) error {
@@ -200,3 +419,254 @@ func (s *State) putAddrApprove(approveMap *adt.Map, tokenOperator addr.Address,
 	}
 	return nil
 }
+
+// LoadAllowance returns the amount of tokenID that operator may still transfer on behalf of owner.
+func (s *State) LoadAllowance(store adt.Store, allowancesMap *adt.Map, owner, operator addr.Address, tokenID big.Int) (abi.TokenAmount, error) {
+	operatorsMap, found, err := loadNestedMap(store, allowancesMap, abi.AddrKey(owner))
+	if err != nil {
+		return big.Zero(), xerrors.Errorf("failed to load allowances of %v: %w", owner, err)
+	}
+	if !found {
+		return big.Zero(), nil
+	}
+	tokensMap, found, err := loadNestedMap(store, operatorsMap, abi.AddrKey(operator))
+	if err != nil {
+		return big.Zero(), xerrors.Errorf("failed to load allowances of %v for %v: %w", owner, operator, err)
+	}
+	if !found {
+		return big.Zero(), nil
+	}
+	var amount abi.TokenAmount
+	found, err = tokensMap.Get(abi.UIntKey(tokenID.Uint64()), &amount)
+	if err != nil {
+		return big.Zero(), xerrors.Errorf("failed to get allowance of %v for %v on tokenID: %v, err: %w", owner, operator, tokenID, err)
+	}
+	if !found {
+		return big.Zero(), nil
+	}
+	return amount, nil
+}
+
+// Sets the allowance of operator over owner's tokenID. A zero amount removes the entry,
+// along with any nested map left empty by the removal.
+func (s *State) setAllowance(store adt.Store, allowancesMap *adt.Map, owner, operator addr.Address, tokenID big.Int, amount abi.TokenAmount) error {
+	operatorsMap, _, err := loadNestedMap(store, allowancesMap, abi.AddrKey(owner))
+	if err != nil {
+		return xerrors.Errorf("failed to load allowances of %v: %w", owner, err)
+	}
+	tokensMap, _, err := loadNestedMap(store, operatorsMap, abi.AddrKey(operator))
+	if err != nil {
+		return xerrors.Errorf("failed to load allowances of %v for %v: %w", owner, operator, err)
+	}
+
+	if amount.IsZero() {
+		if _, err = tokensMap.TryDelete(abi.UIntKey(tokenID.Uint64())); err != nil {
+			return xerrors.Errorf("failed to remove allowance on tokenID: %v, err: %w", tokenID, err)
+		}
+	} else if err = tokensMap.Put(abi.UIntKey(tokenID.Uint64()), &amount); err != nil {
+		return xerrors.Errorf("failed to put allowance on tokenID: %v, err: %w", tokenID, err)
+	}
+
+	if err = putNestedMap(store, operatorsMap, abi.AddrKey(operator), tokensMap); err != nil {
+		return xerrors.Errorf("failed to put allowances of %v for %v: %w", owner, operator, err)
+	}
+	if err = putNestedMap(store, allowancesMap, abi.AddrKey(owner), operatorsMap); err != nil {
+		return xerrors.Errorf("failed to put allowances of %v: %w", owner, err)
+	}
+	return nil
+}
+
+// Records in the holder index whether holder has a positive balance of tokenID.
+func (s *State) updateHolding(store adt.Store, holdingsMap *adt.Map, holder addr.Address, tokenID big.Int, balance abi.TokenAmount) error {
+	tokensMap, _, err := loadNestedMap(store, holdingsMap, abi.AddrKey(holder))
+	if err != nil {
+		return xerrors.Errorf("failed to load holdings of %v: %w", holder, err)
+	}
+	if balance.GreaterThan(big.Zero()) {
+		err = tokensMap.Put(abi.UIntKey(tokenID.Uint64()), nil)
+	} else {
+		_, err = tokensMap.TryDelete(abi.UIntKey(tokenID.Uint64()))
+	}
+	if err != nil {
+		return xerrors.Errorf("failed to update holding of %v in token %v: %w", holder, tokenID, err)
+	}
+	return putNestedMap(store, holdingsMap, abi.AddrKey(holder), tokensMap)
+}
+
+// Iterates the tokens of which holder has a positive balance, in no particular order.
+func (s *State) ForEachTokenOf(store adt.Store, holder addr.Address, cb func(tokenID big.Int, balance abi.TokenAmount) error) error {
+	holdingsMap, err := adt.AsMap(store, s.Holdings, builtin.DefaultHamtBitwidth)
+	if err != nil {
+		return xerrors.Errorf("failed to load holdings: %w", err)
+	}
+	tokensMap, found, err := loadNestedMap(store, holdingsMap, abi.AddrKey(holder))
+	if err != nil {
+		return xerrors.Errorf("failed to load holdings of %v: %w", holder, err)
+	}
+	if !found {
+		return nil
+	}
+	balanceArray, err := adt.AsArray(store, s.Balances, LaneStatesAmtBitwidth)
+	if err != nil {
+		return xerrors.Errorf("failed to load balances: %w", err)
+	}
+
+	return tokensMap.ForEach(nil, func(k string) error {
+		id, err := abi.ParseUIntKey(k)
+		if err != nil {
+			return err
+		}
+		tokenID := big.NewIntUnsigned(id)
+		addrTokenAmountMap, found, err := s.LoadAddrTokenAmountMap(store, balanceArray, tokenID)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return xerrors.Errorf("no balances for held token %v", tokenID)
+		}
+		balanceMap, err := adt.AsMap(store, addrTokenAmountMap.AddrTokenAmountMap, builtin.DefaultHamtBitwidth)
+		if err != nil {
+			return err
+		}
+		balance, _, err := s.LoadAddrTokenAmount(balanceMap, holder)
+		if err != nil {
+			return err
+		}
+		return cb(tokenID, balance)
+	})
+}
+
+// Loads the HAMT whose root is stored under key in parent, or an empty HAMT if there is none.
+func loadNestedMap(store adt.Store, parent *adt.Map, key abi.Keyer) (*adt.Map, bool, error) {
+	var root cbg.CborCid
+	found, err := parent.Get(key, &root)
+	if err != nil {
+		return nil, false, err
+	}
+	if !found {
+		m, err := adt.MakeEmptyMap(store, builtin.DefaultHamtBitwidth)
+		return m, false, err
+	}
+	m, err := adt.AsMap(store, cid.Cid(root), builtin.DefaultHamtBitwidth)
+	return m, true, err
+}
+
+// Stores the root of child under key in parent, or removes key if child is empty.
+func putNestedMap(store adt.Store, parent *adt.Map, key abi.Keyer, child *adt.Map) error {
+	root, err := child.Root()
+	if err != nil {
+		return err
+	}
+	emptyRoot, err := adt.StoreEmptyMap(store, builtin.DefaultHamtBitwidth)
+	if err != nil {
+		return err
+	}
+	if root.Equals(emptyRoot) {
+		_, err = parent.TryDelete(key)
+		return err
+	}
+	rootCborCid := cbg.CborCid(root)
+	return parent.Put(key, &rootCborCid)
+}
+
+// Loads the events emitted at an epoch, in the order they occurred.
+// Epochs outside the retention window may have been pruned.
+func (s *State) LoadEvents(store adt.Store, epoch abi.ChainEpoch) (*EpochEvents, bool, error) {
+	if epoch < 0 {
+		return nil, false, xerrors.Errorf("negative epoch %d", epoch)
+	}
+	eventsArray, err := adt.AsArray(store, s.Events, LaneStatesAmtBitwidth)
+	if err != nil {
+		return nil, false, xerrors.Errorf("failed to load events: %w", err)
+	}
+	var root cbg.CborCid
+	found, err := eventsArray.Get(uint64(epoch), &root)
+	if err != nil {
+		return nil, false, xerrors.Errorf("failed to get events for epoch %d: %w", epoch, err)
+	}
+	if !found {
+		return nil, false, nil
+	}
+	epochArray, err := adt.AsArray(store, cid.Cid(root), LaneStatesAmtBitwidth)
+	if err != nil {
+		return nil, false, xerrors.Errorf("failed to load events for epoch %d: %w", epoch, err)
+	}
+
+	events := EpochEvents{Events: make([]TokenEvent, 0, epochArray.Length())}
+	var event TokenEvent
+	if err = epochArray.ForEach(&event, func(int64) error {
+		events.Events = append(events.Events, event)
+		return nil
+	}); err != nil {
+		return nil, false, xerrors.Errorf("failed to iterate events for epoch %d: %w", epoch, err)
+	}
+	return &events, true, nil
+}
+
+// Appends events emitted at epoch to the log, pruning epochs that have fallen out of the retention window.
+// Each epoch's events are held in their own array, so appending does not rewrite the events already recorded.
+func (s *State) recordEvents(store adt.Store, epoch abi.ChainEpoch, events []TokenEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+	if epoch < 0 {
+		return xerrors.Errorf("negative epoch %d", epoch)
+	}
+
+	eventsArray, err := adt.AsArray(store, s.Events, LaneStatesAmtBitwidth)
+	if err != nil {
+		return xerrors.Errorf("failed to load events: %w", err)
+	}
+
+	var root cbg.CborCid
+	found, err := eventsArray.Get(uint64(epoch), &root)
+	if err != nil {
+		return xerrors.Errorf("failed to get events for epoch %d: %w", epoch, err)
+	}
+	var epochArray *adt.Array
+	if found {
+		epochArray, err = adt.AsArray(store, cid.Cid(root), LaneStatesAmtBitwidth)
+	} else {
+		epochArray, err = adt.MakeEmptyArray(store, LaneStatesAmtBitwidth)
+	}
+	if err != nil {
+		return xerrors.Errorf("failed to load events for epoch %d: %w", epoch, err)
+	}
+	for i := range events {
+		if err := epochArray.AppendContinuous(&events[i]); err != nil {
+			return xerrors.Errorf("failed to append event for epoch %d: %w", epoch, err)
+		}
+	}
+	epochRoot, err := epochArray.Root()
+	if err != nil {
+		return xerrors.Errorf("failed to flush events for epoch %d: %w", epoch, err)
+	}
+	root = cbg.CborCid(epochRoot)
+	if err := eventsArray.Set(uint64(epoch), &root); err != nil {
+		return xerrors.Errorf("failed to set events for epoch %d: %w", epoch, err)
+	}
+
+	// Epochs are visited in ascending order, so iteration stops at the first retained one.
+	cutoff := epoch - EventRetentionEpochs
+	var expired []uint64
+	stopErr := errors.New("stop")
+	var ignored cbg.Deferred
+	err = eventsArray.ForEach(&ignored, func(i int64) error {
+		if abi.ChainEpoch(i) >= cutoff {
+			return stopErr
+		}
+		expired = append(expired, uint64(i))
+		return nil
+	})
+	if err != nil && err != stopErr {
+		return xerrors.Errorf("failed to iterate events: %w", err)
+	}
+	if err := eventsArray.BatchDelete(expired, true); err != nil {
+		return xerrors.Errorf("failed to prune expired events: %w", err)
+	}
+
+	if s.Events, err = eventsArray.Root(); err != nil {
+		return xerrors.Errorf("failed to flush events: %w", err)
+	}
+	return nil
+}
@@ -11,6 +11,8 @@ import (
 	"github.com/filecoin-project/specs-actors/v3/support/mock"
 	tutil "github.com/filecoin-project/specs-actors/v3/support/testing"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"strings"
 	"testing"
 )
 
@@ -281,7 +283,10 @@ func TestToken(t *testing.T) {
 		assert.Nil(t, err)
 		assert.Equal(t, big.NewInt(12), addrTokenAmountTo2)
 
-
+		// transfers move balances without changing supply
+		summary := actor.checkState(rt)
+		assert.Equal(t, big.NewInt(208), summary.Supplies[1])
+		assert.Equal(t, big.NewInt(20), summary.Supplies[2])
 	})
 
 	t.Run("Approve-Transfer", func(t *testing.T) {
@@ -326,8 +331,719 @@ func TestToken(t *testing.T) {
 		assert.Nil(t, err)
 		assert.Equal(t, big.NewInt(5), addrTokenAmountTo)
 	})
+
+	t.Run("Revoke-Approve", func(t *testing.T) {
+		rt := mock.NewBuilder(builtin.TokenActorAddr).
+			WithCaller(builtin.SystemActorAddr, builtin.SystemActorCodeID).
+			Build(t)
+
+		actor.constructAndVerify(rt, &abi.EmptyValue{})
+
+		actor.createAndVerify(rt, transferFrom, big.NewInt(10), "token 1")
+		actor.setApproveForAllAndVerify(rt, transferFrom, tokenOpreratorsMintBatch[0], true)
+		actor.setApproveForAllAndVerify(rt, transferFrom, tokenOpreratorsMintBatch[0], false)
+		st := getState(rt)
+
+		// A revoked approval remains in the map as a zero value, which must read as not approved.
+		isAllApproveMap, err := adt.AsMap(rt.AdtStore(), st.Approves, builtin.DefaultHamtBitwidth)
+		assert.Nil(t, err)
+		addrApproveMap, found, err := st.LoadAddrApproveMap(rt.AdtStore(), isAllApproveMap, transferFrom)
+		assert.True(t, found)
+		assert.Nil(t, err)
+		apMap, err := adt.AsMap(rt.AdtStore(), addrApproveMap.AddrApproveMap, builtin.DefaultHamtBitwidth)
+		assert.Nil(t, err)
+		isApproved, found, err := st.LoadAddrApprove(apMap, tokenOpreratorsMintBatch[0])
+		assert.True(t, found)
+		assert.Nil(t, err)
+		assert.False(t, isApproved)
+
+		isApproved, found, err = st.LoadAddrApprove(apMap, tokenOpreratorsMintBatch[1])
+		assert.False(t, found)
+		assert.Nil(t, err)
+		assert.False(t, isApproved)
+	})
+}
+
+func TestTotalSupply(t *testing.T) {
+	actor := tokenHarness{token.Actor{}, t}
+	admin := tutil.NewIDAddr(t, 101)
+	holders := []addr.Address{tutil.NewIDAddr(t, 102), tutil.NewIDAddr(t, 103)}
+
+	t.Run("create and mint update supply", func(t *testing.T) {
+		rt := mock.NewBuilder(builtin.TokenActorAddr).
+			WithCaller(builtin.SystemActorAddr, builtin.SystemActorCodeID).
+			Build(t)
+		actor.constructAndVerify(rt, &abi.EmptyValue{})
+
+		actor.createAndVerify(rt, admin, big.NewInt(10), "token 1")
+		assert.Equal(t, big.NewInt(10), actor.totalSupply(rt, big.NewInt(1)))
+
+		actor.mintBatchAndVerify(rt, admin, big.NewInt(1), holders, []abi.TokenAmount{big.NewInt(3), big.NewInt(4)})
+		assert.Equal(t, big.NewInt(17), actor.totalSupply(rt, big.NewInt(1)))
+
+		actor.createAndVerify(rt, admin, big.NewInt(0), "token 2")
+		actor.safeTransferFromAndVerify(rt, admin, admin, holders[0], big.NewInt(1), big.NewInt(5))
+		supplies := actor.totalSupplyBatch(rt, []big.Int{big.NewInt(1), big.NewInt(2)})
+		assert.Equal(t, []abi.TokenAmount{big.NewInt(17), big.NewInt(0)}, supplies)
+		actor.checkState(rt)
+	})
+
+	t.Run("rejects unknown token ID", func(t *testing.T) {
+		rt := mock.NewBuilder(builtin.TokenActorAddr).
+			WithCaller(builtin.SystemActorAddr, builtin.SystemActorCodeID).
+			Build(t)
+		actor.constructAndVerify(rt, &abi.EmptyValue{})
+		actor.createAndVerify(rt, admin, big.NewInt(10), "token 1")
+
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "Invalid token ID (2) greater than actual maxID (1)", func() {
+			actor.totalSupply(rt, big.NewInt(2))
+		})
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "Invalid token ID (2) greater than actual maxID (1)", func() {
+			actor.totalSupplyBatch(rt, []big.Int{big.NewInt(1), big.NewInt(2)})
+		})
+	})
+}
+
+func TestBurn(t *testing.T) {
+	actor := tokenHarness{token.Actor{}, t}
+	admin := tutil.NewIDAddr(t, 101)
+	holder := tutil.NewIDAddr(t, 102)
+	operator := tutil.NewIDAddr(t, 103)
+
+	setup := func(t *testing.T) *mock.Runtime {
+		rt := mock.NewBuilder(builtin.TokenActorAddr).
+			WithCaller(builtin.SystemActorAddr, builtin.SystemActorCodeID).
+			Build(t)
+		actor.constructAndVerify(rt, &abi.EmptyValue{})
+		actor.createAndVerify(rt, admin, big.NewInt(10), "token 1")
+		actor.createAndVerify(rt, admin, big.NewInt(20), "token 2")
+		actor.safeTransferFromAndVerify(rt, admin, admin, holder, big.NewInt(1), big.NewInt(4))
+		return rt
+	}
+
+	t.Run("holder burns own balance", func(t *testing.T) {
+		rt := setup(t)
+		actor.burnAndVerify(rt, admin, admin, big.NewInt(1), big.NewInt(2))
+		assert.Equal(t, big.NewInt(4), actor.balanceOf(rt, admin, big.NewInt(1)))
+		assert.Equal(t, big.NewInt(8), actor.totalSupply(rt, big.NewInt(1)))
+		actor.checkState(rt)
+	})
+
+	t.Run("burning entire balance removes the entry", func(t *testing.T) {
+		rt := setup(t)
+		actor.burnAndVerify(rt, holder, holder, big.NewInt(1), big.NewInt(4))
+		assert.Equal(t, big.NewInt(6), actor.totalSupply(rt, big.NewInt(1)))
+
+		st := getState(rt)
+		balanceArray, err := adt.AsArray(rt.AdtStore(), st.Balances, token.LaneStatesAmtBitwidth)
+		assert.Nil(t, err)
+		addrTokenAmountMap, found, err := st.LoadAddrTokenAmountMap(rt.AdtStore(), balanceArray, big.NewInt(1))
+		assert.True(t, found)
+		assert.Nil(t, err)
+		ataMap, err := adt.AsMap(rt.AdtStore(), addrTokenAmountMap.AddrTokenAmountMap, builtin.DefaultHamtBitwidth)
+		assert.Nil(t, err)
+		_, found, err = st.LoadAddrTokenAmount(ataMap, holder)
+		assert.Nil(t, err)
+		assert.False(t, found)
+		actor.checkState(rt)
+	})
+
+	t.Run("approved operator burns for holder", func(t *testing.T) {
+		rt := setup(t)
+		actor.setApproveForAllAndVerify(rt, admin, operator, true)
+		actor.burnBatchAndVerify(rt, operator, admin, []big.Int{big.NewInt(1), big.NewInt(2)}, []abi.TokenAmount{big.NewInt(6), big.NewInt(5)})
+		assert.Equal(t, []abi.TokenAmount{big.NewInt(4), big.NewInt(15)}, actor.totalSupplyBatch(rt, []big.Int{big.NewInt(1), big.NewInt(2)}))
+		assert.Equal(t, big.NewInt(0), actor.balanceOf(rt, admin, big.NewInt(1)))
+		assert.Equal(t, big.NewInt(15), actor.balanceOf(rt, admin, big.NewInt(2)))
+		actor.checkState(rt)
+	})
+
+	t.Run("unapproved or revoked operator cannot burn", func(t *testing.T) {
+		rt := setup(t)
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "The caller does not have permission", func() {
+			actor.burnAndVerify(rt, operator, admin, big.NewInt(1), big.NewInt(1))
+		})
+
+		actor.setApproveForAllAndVerify(rt, admin, operator, true)
+		actor.setApproveForAllAndVerify(rt, admin, operator, false)
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "The caller does not have permission", func() {
+			actor.burnAndVerify(rt, operator, admin, big.NewInt(1), big.NewInt(1))
+		})
+	})
+
+	t.Run("cannot burn more than balance", func(t *testing.T) {
+		rt := setup(t)
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "The balance is not enough for burn : 4-5", func() {
+			actor.burnAndVerify(rt, holder, holder, big.NewInt(1), big.NewInt(5))
+		})
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "The balance is not enough for burn : 0-1", func() {
+			actor.burnBatchAndVerify(rt, holder, holder, []big.Int{big.NewInt(1), big.NewInt(2)}, []abi.TokenAmount{big.NewInt(1), big.NewInt(1)})
+		})
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "Illegal token amount : -1", func() {
+			actor.burnAndVerify(rt, holder, holder, big.NewInt(1), big.NewInt(-1))
+		})
+	})
+}
+
+func TestAllowance(t *testing.T) {
+	actor := tokenHarness{token.Actor{}, t}
+	owner := tutil.NewIDAddr(t, 101)
+	dex := tutil.NewIDAddr(t, 102)
+	receiver := tutil.NewIDAddr(t, 103)
+
+	setup := func(t *testing.T) *mock.Runtime {
+		rt := mock.NewBuilder(builtin.TokenActorAddr).
+			WithCaller(builtin.SystemActorAddr, builtin.SystemActorCodeID).
+			Build(t)
+		actor.constructAndVerify(rt, &abi.EmptyValue{})
+		actor.createAndVerify(rt, owner, big.NewInt(100), "token 1")
+		actor.createAndVerify(rt, owner, big.NewInt(100), "token 2")
+		return rt
+	}
+
+	t.Run("transfer draws down allowance", func(t *testing.T) {
+		rt := setup(t)
+		actor.approveAndVerify(rt, owner, dex, big.NewInt(1), big.NewInt(30))
+		assert.Equal(t, big.NewInt(30), actor.allowance(rt, owner, dex, big.NewInt(1)))
+		assert.Equal(t, big.NewInt(0), actor.allowance(rt, owner, dex, big.NewInt(2)))
+
+		actor.safeTransferFromAndVerify(rt, dex, owner, receiver, big.NewInt(1), big.NewInt(10))
+		assert.Equal(t, big.NewInt(20), actor.allowance(rt, owner, dex, big.NewInt(1)))
+		assert.Equal(t, big.NewInt(10), actor.balanceOf(rt, receiver, big.NewInt(1)))
+
+		actor.approveAndVerify(rt, owner, dex, big.NewInt(2), big.NewInt(15))
+		actor.safeBatchTransferFromAndVerify(rt, dex, owner, receiver, []big.Int{big.NewInt(1), big.NewInt(2)}, []abi.TokenAmount{big.NewInt(20), big.NewInt(15)})
+		assert.Equal(t, big.NewInt(0), actor.allowance(rt, owner, dex, big.NewInt(1)))
+		assert.Equal(t, big.NewInt(0), actor.allowance(rt, owner, dex, big.NewInt(2)))
+		assert.Equal(t, big.NewInt(30), actor.balanceOf(rt, receiver, big.NewInt(1)))
+		assert.Equal(t, big.NewInt(15), actor.balanceOf(rt, receiver, big.NewInt(2)))
+
+		// exhausted allowances are removed from state
+		st := getState(rt)
+		allowancesMap, err := adt.AsMap(rt.AdtStore(), st.Allowances, builtin.DefaultHamtBitwidth)
+		assert.Nil(t, err)
+		keys, err := allowancesMap.CollectKeys()
+		assert.Nil(t, err)
+		assert.Equal(t, 0, len(keys))
+		actor.checkState(rt)
+	})
+
+	t.Run("allowance is scoped to token and amount", func(t *testing.T) {
+		rt := setup(t)
+		actor.approveAndVerify(rt, owner, dex, big.NewInt(1), big.NewInt(30))
+
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "The caller does not have permission", func() {
+			actor.safeTransferFromAndVerify(rt, dex, owner, receiver, big.NewInt(2), big.NewInt(1))
+		})
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "The allowance is not enough for transfer : 30-31", func() {
+			actor.safeTransferFromAndVerify(rt, dex, owner, receiver, big.NewInt(1), big.NewInt(31))
+		})
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "The caller does not have permission", func() {
+			actor.safeBatchTransferFromAndVerify(rt, dex, owner, receiver, []big.Int{big.NewInt(1), big.NewInt(2)}, []abi.TokenAmount{big.NewInt(20), big.NewInt(1)})
+		})
+		assert.Equal(t, big.NewInt(30), actor.allowance(rt, owner, dex, big.NewInt(1)))
+	})
+
+	t.Run("approve replaces and revokes", func(t *testing.T) {
+		rt := setup(t)
+		actor.approveAndVerify(rt, owner, dex, big.NewInt(1), big.NewInt(30))
+		actor.approveAndVerify(rt, owner, dex, big.NewInt(1), big.NewInt(5))
+		assert.Equal(t, big.NewInt(5), actor.allowance(rt, owner, dex, big.NewInt(1)))
+
+		actor.approveAndVerify(rt, owner, dex, big.NewInt(1), big.NewInt(0))
+		assert.Equal(t, big.NewInt(0), actor.allowance(rt, owner, dex, big.NewInt(1)))
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "The caller does not have permission", func() {
+			actor.safeTransferFromAndVerify(rt, dex, owner, receiver, big.NewInt(1), big.NewInt(1))
+		})
+		actor.checkState(rt)
+	})
+
+	t.Run("allowance is drawn through the holder's robust address", func(t *testing.T) {
+		rt := setup(t)
+		robust := tutil.NewBLSAddr(t, 1)
+		rt.AddIDAddress(robust, owner)
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "cant not be the same address", func() {
+			actor.safeTransferFromAndVerify(rt, owner, owner, robust, big.NewInt(1), big.NewInt(40))
+		})
+
+		actor.approveAndVerify(rt, owner, dex, big.NewInt(1), big.NewInt(30))
+		actor.safeTransferFromAndVerify(rt, dex, robust, receiver, big.NewInt(1), big.NewInt(10))
+		actor.safeBatchTransferFromAndVerify(rt, dex, robust, receiver, []big.Int{big.NewInt(1)}, []abi.TokenAmount{big.NewInt(5)})
+		assert.Equal(t, big.NewInt(15), actor.allowance(rt, robust, dex, big.NewInt(1)))
+		assert.Equal(t, big.NewInt(15), actor.allowance(rt, owner, dex, big.NewInt(1)))
+		assert.Equal(t, big.NewInt(15), actor.balanceOf(rt, receiver, big.NewInt(1)))
+
+		// The holder moves its own tokens without drawing on any allowance.
+		actor.safeTransferFromAndVerify(rt, owner, robust, receiver, big.NewInt(1), big.NewInt(5))
+		assert.Equal(t, big.NewInt(15), actor.allowance(rt, owner, dex, big.NewInt(1)))
+		assert.Equal(t, big.NewInt(80), actor.balanceOf(rt, owner, big.NewInt(1)))
+		actor.checkState(rt)
+	})
+
+	t.Run("approve for all is not limited by allowance", func(t *testing.T) {
+		rt := setup(t)
+		actor.approveAndVerify(rt, owner, dex, big.NewInt(1), big.NewInt(5))
+		actor.setApproveForAllAndVerify(rt, owner, dex, true)
+		actor.safeTransferFromAndVerify(rt, dex, owner, receiver, big.NewInt(1), big.NewInt(50))
+		assert.Equal(t, big.NewInt(5), actor.allowance(rt, owner, dex, big.NewInt(1)))
+	})
+
+	t.Run("approve validation", func(t *testing.T) {
+		rt := setup(t)
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "target address cant be self", func() {
+			actor.approveAndVerify(rt, owner, owner, big.NewInt(1), big.NewInt(5))
+		})
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "Illegal token amount : -1", func() {
+			actor.approveAndVerify(rt, owner, dex, big.NewInt(1), big.NewInt(-1))
+		})
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "Invalid token ID (3) greater than actual maxID (2)", func() {
+			actor.approveAndVerify(rt, owner, dex, big.NewInt(3), big.NewInt(5))
+		})
+	})
+}
+
+func TestReceiverHooks(t *testing.T) {
+	actor := tokenHarness{token.Actor{}, t}
+	owner := tutil.NewIDAddr(t, 101)
+	account := tutil.NewIDAddr(t, 102)
+	msig := tutil.NewIDAddr(t, 103)
+	tokenIDs := []big.Int{big.NewInt(1), big.NewInt(2)}
+
+	setup := func(t *testing.T) *mock.Runtime {
+		rt := mock.NewBuilder(builtin.TokenActorAddr).
+			WithCaller(builtin.SystemActorAddr, builtin.SystemActorCodeID).
+			WithActorType(account, builtin.AccountActorCodeID).
+			WithActorType(msig, builtin.MultisigActorCodeID).
+			Build(t)
+		actor.constructAndVerify(rt, &abi.EmptyValue{})
+		actor.createAndVerify(rt, owner, big.NewInt(100), "token 1")
+		actor.createAndVerify(rt, owner, big.NewInt(100), "token 2")
+		return rt
+	}
+
+	t.Run("account recipient is not notified", func(t *testing.T) {
+		rt := setup(t)
+		actor.safeTransferFromAndVerify(rt, owner, owner, account, big.NewInt(1), big.NewInt(10))
+		actor.safeBatchTransferFromAndVerify(rt, owner, owner, account, tokenIDs, []abi.TokenAmount{big.NewInt(1), big.NewInt(2)})
+		assert.Equal(t, big.NewInt(11), actor.balanceOf(rt, account, big.NewInt(1)))
+	})
+
+	t.Run("actor recipient accepts transfer", func(t *testing.T) {
+		rt := setup(t)
+		rt.ExpectSend(msig, builtin.MethodsTokenReceiver.OnTokenReceived, &token.OnTokenReceivedParams{
+			Operator: owner,
+			From:     owner,
+			TokenID:  big.NewInt(1),
+			Value:    big.NewInt(10),
+		}, big.Zero(), nil, exitcode.Ok)
+		actor.safeTransferFromAndVerify(rt, owner, owner, msig, big.NewInt(1), big.NewInt(10))
+
+		values := []abi.TokenAmount{big.NewInt(1), big.NewInt(2)}
+		rt.ExpectSend(msig, builtin.MethodsTokenReceiver.OnBatchTokenReceived, &token.OnBatchTokenReceivedParams{
+			Operator: owner,
+			From:     owner,
+			TokenIDs: tokenIDs,
+			Values:   values,
+		}, big.Zero(), nil, exitcode.Ok)
+		actor.safeBatchTransferFromAndVerify(rt, owner, owner, msig, tokenIDs, values)
+
+		assert.Equal(t, big.NewInt(11), actor.balanceOf(rt, msig, big.NewInt(1)))
+		assert.Equal(t, big.NewInt(2), actor.balanceOf(rt, msig, big.NewInt(2)))
+		actor.checkState(rt)
+	})
+
+	t.Run("actor recipient rejects transfer", func(t *testing.T) {
+		rt := setup(t)
+		rt.ExpectSend(msig, builtin.MethodsTokenReceiver.OnTokenReceived, &token.OnTokenReceivedParams{
+			Operator: owner,
+			From:     owner,
+			TokenID:  big.NewInt(1),
+			Value:    big.NewInt(10),
+		}, big.Zero(), nil, exitcode.SysErrInvalidMethod)
+		rt.ExpectAbortContainsMessage(exitcode.SysErrInvalidMethod, "rejected token transfer", func() {
+			actor.safeTransferFromAndVerify(rt, owner, owner, msig, big.NewInt(1), big.NewInt(10))
+		})
+
+		values := []abi.TokenAmount{big.NewInt(1), big.NewInt(2)}
+		rt.ExpectSend(msig, builtin.MethodsTokenReceiver.OnBatchTokenReceived, &token.OnBatchTokenReceivedParams{
+			Operator: owner,
+			From:     owner,
+			TokenIDs: tokenIDs,
+			Values:   values,
+		}, big.Zero(), nil, exitcode.ErrForbidden)
+		rt.ExpectAbortContainsMessage(exitcode.ErrForbidden, "rejected batch token transfer", func() {
+			actor.safeBatchTransferFromAndVerify(rt, owner, owner, msig, tokenIDs, values)
+		})
+	})
+}
+
+func TestEvents(t *testing.T) {
+	actor := tokenHarness{token.Actor{}, t}
+	creator := tutil.NewIDAddr(t, 101)
+	holder := tutil.NewIDAddr(t, 102)
+	operator := tutil.NewIDAddr(t, 103)
+	startEpoch := abi.ChainEpoch(10)
+
+	rt := mock.NewBuilder(builtin.TokenActorAddr).
+		WithCaller(builtin.SystemActorAddr, builtin.SystemActorCodeID).
+		WithEpoch(startEpoch).
+		Build(t)
+	actor.constructAndVerify(rt, &abi.EmptyValue{})
+
+	actor.createAndVerify(rt, creator, big.NewInt(100), "token 1")
+	actor.mintBatchAndVerify(rt, creator, big.NewInt(1), []addr.Address{holder}, []abi.TokenAmount{big.NewInt(50)})
+	actor.setApproveForAllAndVerify(rt, holder, operator, true)
+	actor.safeTransferFromAndVerify(rt, operator, holder, creator, big.NewInt(1), big.NewInt(20))
+	actor.burnAndVerify(rt, creator, creator, big.NewInt(1), big.NewInt(5))
+	actor.ChangeURIAndVerify(rt, creator, "token 1 v2", big.NewInt(1))
+
+	// events are returned in the order they occurred, whatever their kind
+	events := actor.getEvents(rt, startEpoch)
+	assert.Equal(t, []token.TokenEvent{
+		transferEvent(creator, builtin.TokenActorAddr, creator, big.NewInt(1), big.NewInt(100)),
+		uriChangeEvent(creator, big.NewInt(1), "token 1"),
+		transferEvent(creator, builtin.TokenActorAddr, holder, big.NewInt(1), big.NewInt(50)),
+		transferEvent(operator, holder, creator, big.NewInt(1), big.NewInt(20)),
+		transferEvent(creator, creator, builtin.TokenActorAddr, big.NewInt(1), big.NewInt(5)),
+		uriChangeEvent(creator, big.NewInt(1), "token 1 v2"),
+	}, events.Events)
+
+	// an epoch without events is empty
+	rt.SetEpoch(startEpoch + 1)
+	events = actor.getEvents(rt, startEpoch+1)
+	assert.Empty(t, events.Events)
+
+	rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "negative epoch -1", func() {
+		actor.getEvents(rt, -1)
+	})
+
+	// events are pruned once they fall out of the retention window
+	nextEpoch := startEpoch + token.EventRetentionEpochs + 1
+	rt.SetEpoch(nextEpoch)
+	actor.safeBatchTransferFromAndVerify(rt, creator, creator, holder, []big.Int{big.NewInt(1)}, []abi.TokenAmount{big.NewInt(3)})
+	events = actor.getEvents(rt, nextEpoch)
+	assert.Equal(t, []token.TokenEvent{
+		transferEvent(creator, creator, holder, big.NewInt(1), big.NewInt(3)),
+	}, events.Events)
+
+	st := getState(rt)
+	_, found, err := st.LoadEvents(rt.AdtStore(), startEpoch)
+	assert.NoError(t, err)
+	assert.False(t, found)
+
+	rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "outside the event retention window", func() {
+		actor.getEvents(rt, startEpoch)
+	})
+	rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "outside the event retention window", func() {
+		actor.getEvents(rt, nextEpoch+1)
+	})
+	actor.checkState(rt)
+}
+
+func TestMetadata(t *testing.T) {
+	actor := tokenHarness{token.Actor{}, t}
+	creator := tutil.NewIDAddr(t, 101)
+	holder := tutil.NewIDAddr(t, 102)
+
+	setup := func(t *testing.T) *mock.Runtime {
+		rt := mock.NewBuilder(builtin.TokenActorAddr).
+			WithCaller(builtin.SystemActorAddr, builtin.SystemActorCodeID).
+			Build(t)
+		actor.constructAndVerify(rt, &abi.EmptyValue{})
+		return rt
+	}
+
+	t.Run("metadata is recorded at create", func(t *testing.T) {
+		rt := setup(t)
+		metadata := token.TokenMetadata{Name: "Filestar Gold", Symbol: "FSG", Decimals: 18, MaxSupply: big.NewInt(1000)}
+		actor.createWithMetadataAndVerify(rt, creator, big.NewInt(100), "gold", metadata)
+		assert.Equal(t, metadata, *actor.getMetadata(rt, big.NewInt(1)))
+
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "Invalid token ID (2) greater than actual maxID (1)", func() {
+			actor.getMetadata(rt, big.NewInt(2))
+		})
+		actor.checkState(rt)
+	})
+
+	t.Run("mint is limited by max supply", func(t *testing.T) {
+		rt := setup(t)
+		actor.createWithMetadataAndVerify(rt, creator, big.NewInt(100), "gold", token.TokenMetadata{MaxSupply: big.NewInt(150)})
+		actor.mintBatchAndVerify(rt, creator, big.NewInt(1), []addr.Address{holder}, []abi.TokenAmount{big.NewInt(50)})
+		assert.Equal(t, big.NewInt(150), actor.totalSupply(rt, big.NewInt(1)))
+
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "supply 151 of token 1 would exceed max supply 150", func() {
+			actor.mintBatchAndVerify(rt, creator, big.NewInt(1), []addr.Address{holder}, []abi.TokenAmount{big.NewInt(1)})
+		})
+
+		// burning frees room under the cap
+		actor.burnAndVerify(rt, holder, holder, big.NewInt(1), big.NewInt(10))
+		actor.mintBatchAndVerify(rt, creator, big.NewInt(1), []addr.Address{holder}, []abi.TokenAmount{big.NewInt(10)})
+		actor.checkState(rt)
+	})
+
+	t.Run("uncapped token can be minted freely", func(t *testing.T) {
+		rt := setup(t)
+		actor.createAndVerify(rt, creator, big.NewInt(100), "plain")
+		assert.Equal(t, token.TokenMetadata{MaxSupply: big.Zero()}, *actor.getMetadata(rt, big.NewInt(1)))
+		actor.mintBatchAndVerify(rt, creator, big.NewInt(1), []addr.Address{holder}, []abi.TokenAmount{big.NewInt(1_000_000)})
+		assert.Equal(t, big.NewInt(1_000_100), actor.totalSupply(rt, big.NewInt(1)))
+		actor.checkState(rt)
+	})
+
+	t.Run("non-fungible token has a supply of one", func(t *testing.T) {
+		rt := setup(t)
+		actor.createWithMetadataAndVerify(rt, creator, big.NewInt(1), "art", token.TokenMetadata{Name: "Art", NonFungible: true, MaxSupply: big.Zero()})
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "total minted 2 of non-fungible token 1 would exceed its supply of 1", func() {
+			actor.mintBatchAndVerify(rt, creator, big.NewInt(1), []addr.Address{holder}, []abi.TokenAmount{big.NewInt(1)})
+		})
+
+		actor.createWithMetadataAndVerify(rt, creator, big.NewInt(0), "art 2", token.TokenMetadata{NonFungible: true, MaxSupply: big.NewInt(1)})
+		actor.mintBatchAndVerify(rt, creator, big.NewInt(2), []addr.Address{holder}, []abi.TokenAmount{big.NewInt(1)})
+		assert.Equal(t, big.NewInt(1), actor.balanceOf(rt, holder, big.NewInt(2)))
+		actor.checkState(rt)
+	})
+
+	t.Run("burnt non-fungible token cannot be minted again", func(t *testing.T) {
+		rt := setup(t)
+		actor.createWithMetadataAndVerify(rt, creator, big.NewInt(1), "art", token.TokenMetadata{NonFungible: true, MaxSupply: big.Zero()})
+		actor.burnAndVerify(rt, creator, creator, big.NewInt(1), big.NewInt(1))
+		assert.Equal(t, big.Zero(), actor.totalSupply(rt, big.NewInt(1)))
+
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "total minted 2 of non-fungible token 1 would exceed its supply of 1", func() {
+			actor.mintBatchAndVerify(rt, creator, big.NewInt(1), []addr.Address{holder}, []abi.TokenAmount{big.NewInt(1)})
+		})
+		actor.checkState(rt)
+	})
+
+	t.Run("invalid metadata is rejected", func(t *testing.T) {
+		rt := setup(t)
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "Illegal max supply : -1", func() {
+			actor.createWithMetadataAndVerify(rt, creator, big.NewInt(1), "bad", token.TokenMetadata{MaxSupply: big.NewInt(-1)})
+		})
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "initial value 11 exceeds max supply 10", func() {
+			actor.createWithMetadataAndVerify(rt, creator, big.NewInt(11), "bad", token.TokenMetadata{MaxSupply: big.NewInt(10)})
+		})
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "initial value 2 exceeds max supply 1", func() {
+			actor.createWithMetadataAndVerify(rt, creator, big.NewInt(2), "bad", token.TokenMetadata{NonFungible: true, MaxSupply: big.Zero()})
+		})
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "non-fungible token cannot have 18 decimals", func() {
+			actor.createWithMetadataAndVerify(rt, creator, big.NewInt(1), "bad", token.TokenMetadata{NonFungible: true, Decimals: 18, MaxSupply: big.Zero()})
+		})
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "non-fungible token cannot have max supply 5", func() {
+			actor.createWithMetadataAndVerify(rt, creator, big.NewInt(1), "bad", token.TokenMetadata{NonFungible: true, MaxSupply: big.NewInt(5)})
+		})
+	})
+}
+
+func TestTransferCreator(t *testing.T) {
+	actor := tokenHarness{token.Actor{}, t}
+	creator := tutil.NewIDAddr(t, 101)
+	newCreator := tutil.NewIDAddr(t, 102)
+	other := tutil.NewIDAddr(t, 103)
+	tokenID := big.NewInt(1)
+
+	setup := func(t *testing.T) *mock.Runtime {
+		rt := mock.NewBuilder(builtin.TokenActorAddr).
+			WithCaller(builtin.SystemActorAddr, builtin.SystemActorCodeID).
+			Build(t)
+		actor.constructAndVerify(rt, &abi.EmptyValue{})
+		actor.createAndVerify(rt, creator, big.NewInt(100), "token 1")
+		return rt
+	}
+
+	t.Run("proposed creator takes over on confirmation", func(t *testing.T) {
+		rt := setup(t)
+		actor.transferCreator(rt, creator, tokenID, newCreator)
+		ret := actor.getCreator(rt, tokenID)
+		assert.Equal(t, creator, ret.Creator)
+		assert.Equal(t, &newCreator, ret.PendingCreator)
+
+		// the current creator keeps minting rights until the new creator confirms
+		actor.mintBatchAndVerify(rt, creator, tokenID, []addr.Address{other}, []abi.TokenAmount{big.NewInt(1)})
+
+		actor.transferCreator(rt, newCreator, tokenID, newCreator)
+		ret = actor.getCreator(rt, tokenID)
+		assert.Equal(t, newCreator, ret.Creator)
+		assert.Nil(t, ret.PendingCreator)
+
+		actor.mintBatchAndVerify(rt, newCreator, tokenID, []addr.Address{other}, []abi.TokenAmount{big.NewInt(1)})
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "is not the creator for token with tokenID : 1", func() {
+			actor.mintBatchAndVerify(rt, creator, tokenID, []addr.Address{other}, []abi.TokenAmount{big.NewInt(1)})
+		})
+		actor.checkState(rt)
+	})
+
+	t.Run("creator may replace or cancel proposal", func(t *testing.T) {
+		rt := setup(t)
+		actor.transferCreator(rt, creator, tokenID, newCreator)
+		actor.transferCreator(rt, creator, tokenID, other)
+		assert.Equal(t, &other, actor.getCreator(rt, tokenID).PendingCreator)
+
+		actor.transferCreator(rt, creator, tokenID, creator)
+		assert.Nil(t, actor.getCreator(rt, tokenID).PendingCreator)
+		actor.checkState(rt)
+	})
+
+	t.Run("only creator may propose", func(t *testing.T) {
+		rt := setup(t)
+		rt.ExpectAbort(exitcode.SysErrForbidden, func() {
+			actor.transferCreator(rt, other, tokenID, other)
+		})
+	})
+
+	t.Run("only proposed creator may confirm", func(t *testing.T) {
+		rt := setup(t)
+		actor.transferCreator(rt, creator, tokenID, newCreator)
+		rt.ExpectAbort(exitcode.SysErrForbidden, func() {
+			actor.transferCreator(rt, other, tokenID, newCreator)
+		})
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "expected confirmation of", func() {
+			actor.transferCreator(rt, newCreator, tokenID, other)
+		})
+		assert.Equal(t, creator, actor.getCreator(rt, tokenID).Creator)
+	})
+
+	t.Run("unknown token", func(t *testing.T) {
+		rt := setup(t)
+		rt.ExpectAbortContainsMessage(exitcode.ErrNotFound, "no creator for token ID (2)", func() {
+			actor.transferCreator(rt, creator, big.NewInt(2), newCreator)
+		})
+	})
+}
+
+func TestMinters(t *testing.T) {
+	actor := tokenHarness{token.Actor{}, t}
+	creator := tutil.NewIDAddr(t, 101)
+	minter := tutil.NewIDAddr(t, 102)
+	holder := tutil.NewIDAddr(t, 103)
+	tokenID := big.NewInt(1)
+
+	setup := func(t *testing.T) *mock.Runtime {
+		rt := mock.NewBuilder(builtin.TokenActorAddr).
+			WithCaller(builtin.SystemActorAddr, builtin.SystemActorCodeID).
+			Build(t)
+		actor.constructAndVerify(rt, &abi.EmptyValue{})
+		actor.createAndVerify(rt, creator, big.NewInt(100), "token 1")
+		actor.createAndVerify(rt, creator, big.NewInt(100), "token 2")
+		return rt
+	}
+
+	t.Run("delegated minter can mint", func(t *testing.T) {
+		rt := setup(t)
+		actor.addMinter(rt, creator, tokenID, minter)
+		assert.Equal(t, []addr.Address{minter}, actor.getMinters(rt, tokenID))
+		assert.Empty(t, actor.getMinters(rt, big.NewInt(2)))
+
+		actor.mintBatchAndVerify(rt, minter, tokenID, []addr.Address{holder}, []abi.TokenAmount{big.NewInt(10)})
+		assert.Equal(t, big.NewInt(10), actor.balanceOf(rt, holder, tokenID))
+
+		// delegation is per token
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "nor one of its minters", func() {
+			actor.mintBatchAndVerify(rt, minter, big.NewInt(2), []addr.Address{holder}, []abi.TokenAmount{big.NewInt(10)})
+		})
+		actor.checkState(rt)
+	})
+
+	t.Run("removed minter cannot mint", func(t *testing.T) {
+		rt := setup(t)
+		actor.addMinter(rt, creator, tokenID, minter)
+		actor.removeMinter(rt, creator, tokenID, minter)
+		assert.Empty(t, actor.getMinters(rt, tokenID))
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "nor one of its minters", func() {
+			actor.mintBatchAndVerify(rt, minter, tokenID, []addr.Address{holder}, []abi.TokenAmount{big.NewInt(10)})
+		})
+		rt.ExpectAbortContainsMessage(exitcode.ErrNotFound, "is not a minter of token ID (1)", func() {
+			actor.removeMinter(rt, creator, tokenID, minter)
+		})
+		actor.checkState(rt)
+	})
+
+	t.Run("only creator manages minters", func(t *testing.T) {
+		rt := setup(t)
+		actor.addMinter(rt, creator, tokenID, minter)
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "is not the creator for token with tokenID : 1", func() {
+			actor.addMinter(rt, minter, tokenID, holder)
+		})
+		rt.ExpectAbortContainsMessage(exitcode.ErrIllegalArgument, "is not the creator for token with tokenID : 1", func() {
+			actor.removeMinter(rt, minter, tokenID, minter)
+		})
+	})
 }
 
+func TestTokensOf(t *testing.T) {
+	actor := tokenHarness{token.Actor{}, t}
+	creator := tutil.NewIDAddr(t, 101)
+	alice := tutil.NewIDAddr(t, 102)
+	bob := tutil.NewIDAddr(t, 103)
+
+	rt := mock.NewBuilder(builtin.TokenActorAddr).
+		WithCaller(builtin.SystemActorAddr, builtin.SystemActorCodeID).
+		Build(t)
+	actor.constructAndVerify(rt, &abi.EmptyValue{})
+
+	actor.createAndVerify(rt, creator, big.NewInt(100), "token 1")
+	actor.createAndVerify(rt, creator, big.NewInt(0), "token 2")
+	actor.createAndVerify(rt, creator, big.NewInt(100), "token 3")
+	assert.Equal(t, []big.Int{big.NewInt(1), big.NewInt(3)}, actor.tokensOf(rt, creator))
+	assert.Empty(t, actor.tokensOf(rt, alice))
+
+	actor.mintBatchAndVerify(rt, creator, big.NewInt(2), []addr.Address{alice}, []abi.TokenAmount{big.NewInt(5)})
+	actor.safeTransferFromAndVerify(rt, creator, creator, alice, big.NewInt(3), big.NewInt(100))
+	assert.Equal(t, []big.Int{big.NewInt(1)}, actor.tokensOf(rt, creator))
+	assert.Equal(t, []big.Int{big.NewInt(2), big.NewInt(3)}, actor.tokensOf(rt, alice))
+
+	actor.safeBatchTransferFromAndVerify(rt, alice, alice, bob, []big.Int{big.NewInt(2), big.NewInt(3)}, []abi.TokenAmount{big.NewInt(5), big.NewInt(1)})
+	assert.Equal(t, []big.Int{big.NewInt(3)}, actor.tokensOf(rt, alice))
+	assert.Equal(t, []big.Int{big.NewInt(2), big.NewInt(3)}, actor.tokensOf(rt, bob))
+
+	actor.burnAndVerify(rt, bob, bob, big.NewInt(2), big.NewInt(5))
+	actor.burnBatchAndVerify(rt, creator, creator, []big.Int{big.NewInt(1)}, []abi.TokenAmount{big.NewInt(100)})
+	assert.Equal(t, []big.Int{big.NewInt(3)}, actor.tokensOf(rt, bob))
+	assert.Empty(t, actor.tokensOf(rt, creator))
+
+	// holdings are indexed by ID address, whichever address the tokens were sent to
+	carol := tutil.NewIDAddr(t, 104)
+	carolRobust := tutil.NewBLSAddr(t, 1)
+	rt.AddIDAddress(carolRobust, carol)
+	actor.safeTransferFromAndVerify(rt, bob, bob, carolRobust, big.NewInt(3), big.NewInt(1))
+	assert.Equal(t, []big.Int{big.NewInt(3)}, actor.tokensOf(rt, carol))
+	assert.Equal(t, []big.Int{big.NewInt(3)}, actor.tokensOf(rt, carolRobust))
+	assert.Equal(t, big.NewInt(1), actor.balanceOf(rt, carol, big.NewInt(3)))
+
+	// the state iterator reports balances alongside token IDs
+	st := getState(rt)
+	holdings := map[uint64]abi.TokenAmount{}
+	err := st.ForEachTokenOf(rt.AdtStore(), alice, func(tokenID big.Int, balance abi.TokenAmount) error {
+		holdings[tokenID.Uint64()] = balance
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[uint64]abi.TokenAmount{3: big.NewInt(99)}, holdings)
+	actor.checkState(rt)
+}
+
+func TestCheckStateInvariants(t *testing.T) {
+	actor := tokenHarness{token.Actor{}, t}
+	admin := tutil.NewIDAddr(t, 101)
+	holder := tutil.NewIDAddr(t, 102)
+	rt := mock.NewBuilder(builtin.TokenActorAddr).
+		WithCaller(builtin.SystemActorAddr, builtin.SystemActorCodeID).
+		Build(t)
+
+	actor.constructAndVerify(rt, &abi.EmptyValue{})
+	actor.createAndVerify(rt, admin, big.NewInt(10), "token 1")
+	actor.mintBatchAndVerify(rt, admin, big.NewInt(1), []addr.Address{holder}, []abi.TokenAmount{big.NewInt(5)})
+	summary := actor.checkState(rt)
+	assert.Equal(t, big.NewInt(15), summary.Supplies[1])
+	assert.Equal(t, big.NewInt(5), summary.Balances[1][holder])
+
+	// balances keyed by a non-ID address are reported
+	robust := tutil.NewBLSAddr(t, 1)
+	actor.mintBatchAndVerify(rt, admin, big.NewInt(1), []addr.Address{robust}, []abi.TokenAmount{big.NewInt(1)})
+	_, msgs := token.CheckStateInvariants(getState(rt), rt.AdtStore())
+	assert.Equal(t, 1, len(msgs.Messages()))
+	assert.Contains(t, msgs.Messages()[0], "is not an ID address")
+}
 
 type tokenHarness struct {
 	token.Actor
@@ -351,6 +1067,21 @@ func (h *tokenHarness) createAndVerify(rt *mock.Runtime, tokenOprerator addr.Add
 	rt.Verify()
 }
 
+func (h *tokenHarness) createWithMetadataAndVerify(rt *mock.Runtime, tokenOprerator addr.Address, amount abi.TokenAmount, uri string, metadata token.TokenMetadata) {
+	rt.ExpectValidateCallerAny()
+	rt.SetCaller(tokenOprerator, builtin.AccountActorCodeID)
+	ret := rt.Call(h.Actor.CreateWithMetadata, &token.CreateWithMetadataParams{ValueInit: amount, TokenURI: uri, Metadata: metadata})
+	assert.Nil(h.t, ret)
+	rt.Verify()
+}
+
+func (h *tokenHarness) getMetadata(rt *mock.Runtime, tokenID big.Int) *token.TokenMetadata {
+	rt.ExpectValidateCallerAny()
+	ret := rt.Call(h.Actor.GetMetadata, &token.GetMetadataParams{TokenID: tokenID})
+	rt.Verify()
+	return ret.(*token.TokenMetadata)
+}
+
 func (h *tokenHarness) mintBatchAndVerify(rt *mock.Runtime, addrCall addr.Address, tokenID big.Int, addrTos []addr.Address, values []abi.TokenAmount) {
 	rt.ExpectValidateCallerAny()
 	rt.SetCaller(addrCall, builtin.AccountActorCodeID)
@@ -412,6 +1143,163 @@ func (h *tokenHarness) setApproveForAllAndVerify(rt *mock.Runtime, addrCall addr
 	rt.Verify()
 }
 
+func (h *tokenHarness) balanceOf(rt *mock.Runtime, owner addr.Address, tokenID big.Int) abi.TokenAmount {
+	rt.ExpectValidateCallerAny()
+	ret := rt.Call(h.Actor.BalanceOf, &token.BalanceOfParams{AddrOwner: owner, TokenID: tokenID})
+	rt.Verify()
+	return ret.(*token.BalanceOfResults).Balance
+}
+
+func (h *tokenHarness) burnAndVerify(rt *mock.Runtime, addrCall addr.Address, addrFrom addr.Address, tokenID big.Int, amount abi.TokenAmount) {
+	rt.ExpectValidateCallerAny()
+	rt.SetCaller(addrCall, builtin.AccountActorCodeID)
+	ret := rt.Call(h.Actor.Burn, &token.BurnParams{
+		AddrFrom: addrFrom,
+		TokenID:  tokenID,
+		Amount:   amount,
+	})
+	assert.Nil(h.t, ret)
+	rt.Verify()
+}
+
+func (h *tokenHarness) burnBatchAndVerify(rt *mock.Runtime, addrCall addr.Address, addrFrom addr.Address, tokenIDs []big.Int, amounts []abi.TokenAmount) {
+	rt.ExpectValidateCallerAny()
+	rt.SetCaller(addrCall, builtin.AccountActorCodeID)
+	ret := rt.Call(h.Actor.BurnBatch, &token.BurnBatchParams{
+		AddrFrom: addrFrom,
+		TokenIDs: tokenIDs,
+		Amounts:  amounts,
+	})
+	assert.Nil(h.t, ret)
+	rt.Verify()
+}
+
+func (h *tokenHarness) approveAndVerify(rt *mock.Runtime, addrCall addr.Address, operator addr.Address, tokenID big.Int, amount abi.TokenAmount) {
+	rt.ExpectValidateCallerAny()
+	rt.SetCaller(addrCall, builtin.AccountActorCodeID)
+	ret := rt.Call(h.Actor.Approve, &token.ApproveParams{
+		Operator: operator,
+		TokenID:  tokenID,
+		Amount:   amount,
+	})
+	assert.Nil(h.t, ret)
+	rt.Verify()
+}
+
+func (h *tokenHarness) allowance(rt *mock.Runtime, owner addr.Address, operator addr.Address, tokenID big.Int) abi.TokenAmount {
+	rt.ExpectValidateCallerAny()
+	ret := rt.Call(h.Actor.Allowance, &token.AllowanceParams{Owner: owner, Operator: operator, TokenID: tokenID})
+	rt.Verify()
+	return ret.(*token.AllowanceResults).Amount
+}
+
+func (h *tokenHarness) getEvents(rt *mock.Runtime, epoch abi.ChainEpoch) *token.EpochEvents {
+	rt.ExpectValidateCallerAny()
+	ret := rt.Call(h.Actor.GetEvents, &token.GetEventsParams{Epoch: epoch})
+	rt.Verify()
+	return ret.(*token.EpochEvents)
+}
+
+func (h *tokenHarness) transferCreator(rt *mock.Runtime, addrCall addr.Address, tokenID big.Int, newCreator addr.Address) {
+	st := getState(rt)
+	creatorsArray, err := adt.AsArray(rt.AdtStore(), st.Creators, token.LaneStatesAmtBitwidth)
+	require.NoError(h.t, err)
+	creator, found, err := st.GetCreatorAddress(creatorsArray, tokenID)
+	require.NoError(h.t, err)
+	if found {
+		pendingCreatorsArray, err := adt.AsArray(rt.AdtStore(), st.PendingCreators, token.LaneStatesAmtBitwidth)
+		require.NoError(h.t, err)
+		pending, hasPending, err := st.GetPendingCreator(pendingCreatorsArray, tokenID)
+		require.NoError(h.t, err)
+		if addrCall == creator || !hasPending {
+			rt.ExpectValidateCallerAddr(creator)
+		} else {
+			rt.ExpectValidateCallerAddr(pending)
+		}
+	}
+
+	rt.SetCaller(addrCall, builtin.AccountActorCodeID)
+	ret := rt.Call(h.Actor.TransferCreator, &token.TransferCreatorParams{TokenID: tokenID, NewCreator: newCreator})
+	assert.Nil(h.t, ret)
+	rt.Verify()
+}
+
+func (h *tokenHarness) getCreator(rt *mock.Runtime, tokenID big.Int) *token.GetCreatorResults {
+	rt.ExpectValidateCallerAny()
+	ret := rt.Call(h.Actor.GetCreator, &token.GetCreatorParams{TokenID: tokenID})
+	rt.Verify()
+	return ret.(*token.GetCreatorResults)
+}
+
+func (h *tokenHarness) addMinter(rt *mock.Runtime, addrCall addr.Address, tokenID big.Int, minter addr.Address) {
+	rt.ExpectValidateCallerAny()
+	rt.SetCaller(addrCall, builtin.AccountActorCodeID)
+	ret := rt.Call(h.Actor.AddMinter, &token.MinterParams{TokenID: tokenID, Minter: minter})
+	assert.Nil(h.t, ret)
+	rt.Verify()
+}
+
+func (h *tokenHarness) removeMinter(rt *mock.Runtime, addrCall addr.Address, tokenID big.Int, minter addr.Address) {
+	rt.ExpectValidateCallerAny()
+	rt.SetCaller(addrCall, builtin.AccountActorCodeID)
+	ret := rt.Call(h.Actor.RemoveMinter, &token.MinterParams{TokenID: tokenID, Minter: minter})
+	assert.Nil(h.t, ret)
+	rt.Verify()
+}
+
+func (h *tokenHarness) getMinters(rt *mock.Runtime, tokenID big.Int) []addr.Address {
+	rt.ExpectValidateCallerAny()
+	ret := rt.Call(h.Actor.GetMinters, &token.GetMintersParams{TokenID: tokenID})
+	rt.Verify()
+	return ret.(*token.GetMintersResults).Minters
+}
+
+func (h *tokenHarness) tokensOf(rt *mock.Runtime, owner addr.Address) []big.Int {
+	rt.ExpectValidateCallerAny()
+	ret := rt.Call(h.Actor.TokensOf, &token.TokensOfParams{Owner: owner})
+	rt.Verify()
+	return ret.(*token.TokensOfResults).TokenIDs
+}
+
+func (h *tokenHarness) totalSupply(rt *mock.Runtime, tokenID big.Int) abi.TokenAmount {
+	rt.ExpectValidateCallerAny()
+	ret := rt.Call(h.Actor.TotalSupply, &token.TotalSupplyParams{TokenID: tokenID})
+	rt.Verify()
+	return ret.(*token.TotalSupplyResults).Supply
+}
+
+func (h *tokenHarness) totalSupplyBatch(rt *mock.Runtime, tokenIDs []big.Int) []abi.TokenAmount {
+	rt.ExpectValidateCallerAny()
+	ret := rt.Call(h.Actor.TotalSupplyBatch, &token.TotalSupplyBatchParams{TokenIDs: tokenIDs})
+	rt.Verify()
+	return ret.(*token.TotalSupplyBatchResults).Supplies
+}
+
+func (h *tokenHarness) checkState(rt *mock.Runtime) *token.StateSummary {
+	st := getState(rt)
+	summary, msgs := token.CheckStateInvariants(st, rt.AdtStore())
+	assert.True(h.t, msgs.IsEmpty(), strings.Join(msgs.Messages(), "\n"))
+	return summary
+}
+
+func transferEvent(operator, from, to addr.Address, tokenID big.Int, value abi.TokenAmount) token.TokenEvent {
+	return token.TokenEvent{Kind: token.EventKindTransfer, Transfer: &token.TransferEvent{
+		Operator: operator,
+		From:     from,
+		To:       to,
+		TokenID:  tokenID,
+		Value:    value,
+	}}
+}
+
+func uriChangeEvent(operator addr.Address, tokenID big.Int, uri string) token.TokenEvent {
+	return token.TokenEvent{Kind: token.EventKindURIChange, URIChange: &token.URIEvent{
+		Operator: operator,
+		TokenID:  tokenID,
+		URI:      uri,
+	}}
+}
+
 func getState(rt *mock.Runtime) *token.State {
 	var st token.State
 	rt.GetState(&st)
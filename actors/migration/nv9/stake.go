@@ -2,12 +2,36 @@ package nv9
 
 import (
 	"context"
+	"fmt"
+	"strings"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/rt"
 	stake2 "github.com/filecoin-project/specs-actors/v2/actors/builtin/stake"
+	adt2 "github.com/filecoin-project/specs-actors/v2/actors/util/adt"
 	cid "github.com/ipfs/go-cid"
 	cbor "github.com/ipfs/go-ipld-cbor"
+	cbg "github.com/whyrusleeping/cbor-gen"
+	"golang.org/x/xerrors"
 
 	builtin3 "github.com/filecoin-project/specs-actors/v3/actors/builtin"
 	stake3 "github.com/filecoin-project/specs-actors/v3/actors/builtin/stake"
+	adt3 "github.com/filecoin-project/specs-actors/v3/actors/util/adt"
+)
+
+// StakeVerification selects how the stake actor migration checks the state it migrates.
+// The migrated state is the same whatever the verification.
+type StakeVerification int
+
+const (
+	// Migrates the stake state without checking it.
+	StakeVerificationOff StakeVerification = iota
+	// Logs any drift found in the stake state.
+	StakeVerificationLog
+	// Fails the migration if any drift is found in the stake state.
+	StakeVerificationStrict
 )
 
 type stakeMigrator struct{}
@@ -33,7 +57,7 @@ func (m stakeMigrator) migrateState(ctx context.Context, store cbor.IpldStore, i
 		return nil, err
 	}
 
-	vestingRewardMap, err := migrateHAMTRaw(ctx, store, inState.VestingRewardMap, builtin3.DefaultHamtBitwidth)
+	vestingRewardMap, err := m.migrateVestingRewards(ctx, store, inState.VestingRewardMap)
 	if err != nil {
 		return nil, err
 	}
@@ -42,6 +66,20 @@ func (m stakeMigrator) migrateState(ctx context.Context, store cbor.IpldStore, i
 	if err != nil {
 		return nil, err
 	}
+	stakerEventQueue, err := m.queueAllStakers(ctx, store, lockedPrincipalMap, in.priorEpoch+1)
+	if err != nil {
+		return nil, err
+	}
+
+	emptyMap, err := adt3.StoreEmptyMap(adt3.WrapStore(ctx, store), builtin3.DefaultHamtBitwidth)
+	if err != nil {
+		return nil, err
+	}
+	rewardVestingSpecs, err := stake3.ConstructRewardVestingSpecs(adt3.WrapStore(ctx, store))
+	if err != nil {
+		return nil, err
+	}
+
 	outState := stake3.State{
 		RootKey:         inState.RootKey,
 		TotalStakePower: inState.TotalStakePower,
@@ -52,6 +90,7 @@ func (m stakeMigrator) migrateState(ctx context.Context, store cbor.IpldStore, i
 		MinDepositAmount:      inState.MinDepositAmount,
 		MaxRewardPerRound:     inState.MaxRewardPerRound,
 		InflationFactor:       inState.InflationFactor,
+		RewardCurve:           stake3.LinearRewardCurve(),
 		LastRoundReward:       inState.LastRoundReward,
 		StakePeriodStart:      inState.StakePeriodStart,
 		NextRoundEpoch:        inState.NextRoundEpoch,
@@ -61,6 +100,17 @@ func (m stakeMigrator) migrateState(ctx context.Context, store cbor.IpldStore, i
 		StakePowerMap:         stakePowerMap,
 		VestingRewardMap:      vestingRewardMap,
 		AvailableRewardMap:    availableRewardMap,
+		StakerEventQueue:      stakerEventQueue,
+		FirstEventEpoch:       in.priorEpoch + 1,
+		Delegations:           emptyMap,
+		AutoCompound:          emptyMap,
+		Slashers:              emptyMap,
+		UnbondingPeriod:       stake3.DefaultUnbondingPeriod,
+		UnbondingMap:          emptyMap,
+		ProposalDelay:         stake3.DefaultProposalDelay,
+		Proposals:             emptyMap,
+		NextProposalID:        0,
+		RewardVestingSpecs:    rewardVestingSpecs,
 	}
 	newHead, err := store.Put(ctx, &outState)
 	return &actorMigrationResult{
@@ -68,6 +118,146 @@ func (m stakeMigrator) migrateState(ctx context.Context, store cbor.IpldStore, i
 		newHead:    newHead,
 	}, err
 }
+
 func (m stakeMigrator) migratedCodeCID() cid.Cid {
 	return builtin3.StakeActorCodeID
 }
+
+// Migrator that checks the stake state before migrating it with a wrapped migration, even one found in the cache.
+type verifiedStakeMigrator struct {
+	verification StakeVerification
+	log          Logger
+	actorMigration
+}
+
+func (m verifiedStakeMigrator) migrateState(ctx context.Context, store cbor.IpldStore, in actorMigrationInput) (*actorMigrationResult, error) {
+	if m.verification != StakeVerificationOff {
+		var inState stake2.State
+		if err := store.Get(ctx, in.head, &inState); err != nil {
+			return nil, err
+		}
+		if err := m.verifyState(ctx, store, &inState); err != nil {
+			return nil, err
+		}
+	}
+	return m.actorMigration.migrateState(ctx, store, in)
+}
+
+// Checks the stake state for drift, logging it or, under strict verification, returning it as an error.
+// The total stake power must match the sum of the stake power map.
+// Each staker's locked principals must be ordered by epoch, since they are unlocked and matured from the front.
+func (m verifiedStakeMigrator) verifyState(ctx context.Context, store cbor.IpldStore, inState *stake2.State) error {
+	astore := adt3.WrapStore(ctx, store)
+	var drift []string
+
+	stakePowers, err := adt2.AsMap(astore, inState.StakePowerMap)
+	if err != nil {
+		return err
+	}
+	totalStakePower := big.Zero()
+	var power abi.StakePower
+	if err = stakePowers.ForEach(&power, func(key string) error {
+		totalStakePower = big.Add(totalStakePower, power)
+		return nil
+	}); err != nil {
+		return err
+	}
+	if !totalStakePower.Equals(inState.TotalStakePower) {
+		drift = append(drift, fmt.Sprintf("total stake power %v does not match sum of stake powers %v",
+			inState.TotalStakePower, totalStakePower))
+	}
+
+	lockedPrincipals, err := adt2.AsMap(astore, inState.LockedPrincipalMap)
+	if err != nil {
+		return err
+	}
+	var lockedPrincipalsCid cbg.CborCid
+	if err = lockedPrincipals.ForEach(&lockedPrincipalsCid, func(key string) error {
+		staker, err := address.NewFromBytes([]byte(key))
+		if err != nil {
+			return err
+		}
+		var principals stake2.LockedPrincipals
+		if err := store.Get(ctx, cid.Cid(lockedPrincipalsCid), &principals); err != nil {
+			return err
+		}
+		for i := 1; i < len(principals.Data); i++ {
+			if principals.Data[i].Epoch < principals.Data[i-1].Epoch {
+				drift = append(drift, fmt.Sprintf("locked principals of %v out of order at index %d: epoch %d after %d",
+					staker, i, principals.Data[i].Epoch, principals.Data[i-1].Epoch))
+				break
+			}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if len(drift) > 0 && m.verification == StakeVerificationStrict {
+		return xerrors.Errorf("stake state drift: %s", strings.Join(drift, "; "))
+	}
+	for _, msg := range drift {
+		m.log.Log(rt.WARN, "stake state drift: %s", msg)
+	}
+	return nil
+}
+
+// Re-encodes every staker's vesting funds, recording them as vesting under the default reward vesting spec.
+func (m stakeMigrator) migrateVestingRewards(ctx context.Context, store cbor.IpldStore, root cid.Cid) (cid.Cid, error) {
+	astore := adt3.WrapStore(ctx, store)
+	inVestingRewards, err := adt2.AsMap(astore, root)
+	if err != nil {
+		return cid.Undef, err
+	}
+	outVestingRewards, err := adt3.MakeEmptyMap(astore, builtin3.DefaultHamtBitwidth)
+	if err != nil {
+		return cid.Undef, err
+	}
+
+	var inFundsCid cbg.CborCid
+	if err = inVestingRewards.ForEach(&inFundsCid, func(key string) error {
+		var inFunds stake2.VestingFunds
+		if err := store.Get(ctx, cid.Cid(inFundsCid), &inFunds); err != nil {
+			return err
+		}
+		outFunds := stake3.VestingFunds{Funds: make([]stake3.VestingFund, 0, len(inFunds.Funds))}
+		for _, vf := range inFunds.Funds {
+			outFunds.Funds = append(outFunds.Funds, stake3.VestingFund{Epoch: vf.Epoch, Amount: vf.Amount, Spec: 0})
+		}
+		outFundsCid, err := store.Put(ctx, &outFunds)
+		if err != nil {
+			return err
+		}
+		outFundsCborCid := cbg.CborCid(outFundsCid)
+		return outVestingRewards.Put(StringKey(key), &outFundsCborCid)
+	}); err != nil {
+		return cid.Undef, err
+	}
+	return outVestingRewards.Root()
+}
+
+// Builds a staker event queue with every staker due at epoch, so the first tick after the
+// migration evaluates all stakers and queues each for its next event.
+func (m stakeMigrator) queueAllStakers(ctx context.Context, store cbor.IpldStore, lockedPrincipalMapRoot cid.Cid, epoch abi.ChainEpoch) (cid.Cid, error) {
+	astore := adt3.WrapStore(ctx, store)
+	lockedPrincipalMap, err := adt3.AsMap(astore, lockedPrincipalMapRoot, builtin3.DefaultHamtBitwidth)
+	if err != nil {
+		return cid.Undef, err
+	}
+	queue, err := adt3.MakeEmptyMultimap(astore, stake3.StakerQueueHamtBitwidth, stake3.StakerQueueAmtBitwidth)
+	if err != nil {
+		return cid.Undef, err
+	}
+
+	var lockedPrincipalsCid cbg.CborCid
+	if err = lockedPrincipalMap.ForEach(&lockedPrincipalsCid, func(key string) error {
+		staker, err := address.NewFromBytes([]byte(key))
+		if err != nil {
+			return err
+		}
+		return queue.Add(abi.IntKey(int64(epoch)), &staker)
+	}); err != nil {
+		return cid.Undef, err
+	}
+	return queue.Root()
+}
@@ -0,0 +1,250 @@
+package test_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	addr "github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/rt"
+	"github.com/ipfs/go-cid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	cbg "github.com/whyrusleeping/cbor-gen"
+
+	builtin2 "github.com/filecoin-project/specs-actors/v2/actors/builtin"
+	stake2 "github.com/filecoin-project/specs-actors/v2/actors/builtin/stake"
+	states2 "github.com/filecoin-project/specs-actors/v2/actors/states"
+	adt2 "github.com/filecoin-project/specs-actors/v2/actors/util/adt"
+	vm2 "github.com/filecoin-project/specs-actors/v2/support/vm"
+
+	builtin3 "github.com/filecoin-project/specs-actors/v3/actors/builtin"
+	stake3 "github.com/filecoin-project/specs-actors/v3/actors/builtin/stake"
+	"github.com/filecoin-project/specs-actors/v3/actors/migration/nv9"
+	states3 "github.com/filecoin-project/specs-actors/v3/actors/states"
+	tutil "github.com/filecoin-project/specs-actors/v3/support/testing"
+)
+
+func TestStakeMigrationVerification(t *testing.T) {
+	ctx := context.Background()
+	staker1 := tutil.NewIDAddr(t, 1000)
+	staker2 := tutil.NewIDAddr(t, 1001)
+	amount := big.Mul(big.NewInt(1_000), vm2.FIL)
+
+	stakers := []v2Staker{{
+		addr:      staker1,
+		locked:    []stake2.LockedPrincipal{{Amount: amount, Epoch: 10}, {Amount: amount, Epoch: 20}},
+		available: amount,
+		power:     big.Mul(amount, big.NewInt(2)),
+	}, {
+		addr:      staker2,
+		locked:    []stake2.LockedPrincipal{{Amount: amount, Epoch: 15}},
+		available: big.Zero(),
+		power:     amount,
+	}}
+	totalStakePower := big.Mul(amount, big.NewInt(3))
+
+	t.Run("consistent state migrates under strict verification", func(t *testing.T) {
+		log := &recordingLogger{TestLogger: TestLogger{t}}
+		store, root, inState := buildV2StakeTree(ctx, t, totalStakePower, stakers)
+
+		outState, err := migrateStake(ctx, t, store, root, nv9.StakeVerificationStrict, log, nv9.NewMemMigrationCache())
+		require.NoError(t, err)
+		assert.Empty(t, log.warnings())
+
+		assert.Equal(t, inState.RootKey, outState.RootKey)
+		assert.Equal(t, totalStakePower, outState.TotalStakePower)
+		assert.Equal(t, inState.MaturePeriod, outState.MaturePeriod)
+		assert.Equal(t, inState.PrincipalLockDuration, outState.PrincipalLockDuration)
+		assert.Equal(t, inState.MinDepositAmount, outState.MinDepositAmount)
+		assert.Equal(t, inState.InflationFactor, outState.InflationFactor)
+
+		for _, s := range stakers {
+			snapshot, found, err := outState.GetStaker(store, s.addr)
+			require.NoError(t, err)
+			require.True(t, found)
+			var locked []stake2.LockedPrincipal
+			for _, lp := range snapshot.LockedPrincipals {
+				locked = append(locked, stake2.LockedPrincipal{Amount: lp.Amount, Epoch: lp.Epoch})
+			}
+			assert.Equal(t, s.locked, locked)
+			assert.Equal(t, s.available, snapshot.AvailablePrincipal)
+			assert.Equal(t, s.power, snapshot.StakePower)
+		}
+	})
+
+	t.Run("total stake power drift is logged and the recorded total copied", func(t *testing.T) {
+		log := &recordingLogger{TestLogger: TestLogger{t}}
+		store, root, _ := buildV2StakeTree(ctx, t, amount, stakers)
+
+		outState, err := migrateStake(ctx, t, store, root, nv9.StakeVerificationLog, log, nv9.NewMemMigrationCache())
+		require.NoError(t, err)
+		assert.Equal(t, amount, outState.TotalStakePower)
+		require.Len(t, log.warnings(), 1)
+		assert.Contains(t, log.warnings()[0], "does not match sum of stake powers")
+	})
+
+	t.Run("drift is verified when the migration is cached", func(t *testing.T) {
+		log := &recordingLogger{TestLogger: TestLogger{t}}
+		store, root, _ := buildV2StakeTree(ctx, t, amount, stakers)
+		cache := nv9.NewMemMigrationCache()
+
+		cachedState, err := migrateStake(ctx, t, store, root, nv9.StakeVerificationOff, log, cache)
+		require.NoError(t, err)
+		assert.Empty(t, log.warnings())
+
+		outState, err := migrateStake(ctx, t, store, root, nv9.StakeVerificationLog, log, cache)
+		require.NoError(t, err)
+		assert.Equal(t, cachedState, outState)
+		require.Len(t, log.warnings(), 1)
+		assert.Contains(t, log.warnings()[0], "does not match sum of stake powers")
+
+		_, err = migrateStake(ctx, t, store, root, nv9.StakeVerificationStrict, log, cache)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "does not match sum of stake powers")
+	})
+
+	t.Run("total stake power is copied without verification", func(t *testing.T) {
+		log := &recordingLogger{TestLogger: TestLogger{t}}
+		store, root, _ := buildV2StakeTree(ctx, t, amount, stakers)
+
+		outState, err := migrateStake(ctx, t, store, root, nv9.StakeVerificationOff, log, nv9.NewMemMigrationCache())
+		require.NoError(t, err)
+		assert.Equal(t, amount, outState.TotalStakePower)
+		assert.Empty(t, log.warnings())
+	})
+
+	t.Run("strict verification fails on total stake power drift", func(t *testing.T) {
+		log := &recordingLogger{TestLogger: TestLogger{t}}
+		store, root, _ := buildV2StakeTree(ctx, t, amount, stakers)
+
+		_, err := migrateStake(ctx, t, store, root, nv9.StakeVerificationStrict, log, nv9.NewMemMigrationCache())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "does not match sum of stake powers")
+	})
+
+	t.Run("out of order locked principals", func(t *testing.T) {
+		unordered := []v2Staker{stakers[0], {
+			addr:      staker2,
+			locked:    []stake2.LockedPrincipal{{Amount: amount, Epoch: 15}, {Amount: amount, Epoch: 5}},
+			available: big.Zero(),
+			power:     amount,
+		}}
+
+		log := &recordingLogger{TestLogger: TestLogger{t}}
+		store, root, _ := buildV2StakeTree(ctx, t, totalStakePower, unordered)
+		_, err := migrateStake(ctx, t, store, root, nv9.StakeVerificationLog, log, nv9.NewMemMigrationCache())
+		require.NoError(t, err)
+		require.Len(t, log.warnings(), 1)
+		assert.Contains(t, log.warnings()[0], fmt.Sprintf("locked principals of %v out of order", staker2))
+
+		store, root, _ = buildV2StakeTree(ctx, t, totalStakePower, unordered)
+		_, err = migrateStake(ctx, t, store, root, nv9.StakeVerificationStrict, log, nv9.NewMemMigrationCache())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "out of order")
+	})
+}
+
+type v2Staker struct {
+	addr      addr.Address
+	locked    []stake2.LockedPrincipal
+	available abi.TokenAmount
+	power     abi.StakePower
+}
+
+// Builds a v2 state tree holding a stake actor with the given stakers and recorded total stake power.
+func buildV2StakeTree(ctx context.Context, t *testing.T, totalStakePower abi.StakePower, stakers []v2Staker) (adt2.Store, cid.Cid, *stake2.State) {
+	v := vm2.NewVMWithSingletons(ctx, t)
+	store := v.Store()
+
+	emptyMap, err := adt2.MakeEmptyMap(store).Root()
+	require.NoError(t, err)
+	st := stake2.ConstructState(&stake2.ConstructorParams{
+		RootKey:               tutil.NewIDAddr(t, 100),
+		MaturePeriod:          abi.ChainEpoch(10),
+		RoundPeriod:           abi.ChainEpoch(20),
+		PrincipalLockDuration: abi.ChainEpoch(30),
+		FirstRoundEpoch:       abi.ChainEpoch(1),
+		MinDepositAmount:      big.Mul(big.NewInt(1), vm2.FIL),
+		MaxRewardPerRound:     big.Mul(big.NewInt(10), vm2.FIL),
+		InflationFactor:       big.NewInt(100),
+	}, emptyMap)
+	st.TotalStakePower = totalStakePower
+
+	lockedPrincipals := adt2.MakeEmptyMap(store)
+	availablePrincipals := adt2.MakeEmptyMap(store)
+	stakePowers := adt2.MakeEmptyMap(store)
+	for _, s := range stakers {
+		lockedCid, err := store.Put(ctx, &stake2.LockedPrincipals{Data: s.locked})
+		require.NoError(t, err)
+		lockedCborCid := cbg.CborCid(lockedCid)
+		require.NoError(t, lockedPrincipals.Put(abi.AddrKey(s.addr), &lockedCborCid))
+		available := s.available
+		require.NoError(t, availablePrincipals.Put(abi.AddrKey(s.addr), &available))
+		power := s.power
+		require.NoError(t, stakePowers.Put(abi.AddrKey(s.addr), &power))
+	}
+	st.LockedPrincipalMap, err = lockedPrincipals.Root()
+	require.NoError(t, err)
+	st.AvailablePrincipalMap, err = availablePrincipals.Root()
+	require.NoError(t, err)
+	st.StakePowerMap, err = stakePowers.Root()
+	require.NoError(t, err)
+
+	head, err := store.Put(ctx, st)
+	require.NoError(t, err)
+	tree, err := v.GetStateTree()
+	require.NoError(t, err)
+	require.NoError(t, tree.SetActor(builtin2.StakeActorAddr, &states2.Actor{
+		Code:    builtin2.StakeActorCodeID,
+		Head:    head,
+		Balance: big.Zero(),
+	}))
+	root, err := tree.Flush()
+	require.NoError(t, err)
+	return store, root, st
+}
+
+func migrateStake(ctx context.Context, t *testing.T, store adt2.Store, root cid.Cid, verification nv9.StakeVerification, log nv9.Logger, cache *nv9.MemMigrationCache) (*stake3.State, error) {
+	cfg := nv9.Config{MaxWorkers: 1, StakeVerification: verification}
+	nextRoot, err := nv9.MigrateStateTree(ctx, store, root, abi.ChainEpoch(0), cfg, log, cache)
+	if err != nil {
+		return nil, err
+	}
+
+	tree, err := states3.LoadTree(store, nextRoot)
+	require.NoError(t, err)
+	actor, found, err := tree.GetActor(builtin3.StakeActorAddr)
+	require.NoError(t, err)
+	require.True(t, found)
+	assert.Equal(t, builtin3.StakeActorCodeID, actor.Code)
+
+	var st stake3.State
+	require.NoError(t, store.Get(ctx, actor.Head, &st))
+	return &st, nil
+}
+
+// Records the warnings logged by a migration.
+type recordingLogger struct {
+	TestLogger
+	mu   sync.Mutex
+	msgs []string
+}
+
+func (l *recordingLogger) Log(level rt.LogLevel, msg string, args ...interface{}) {
+	if level == rt.WARN {
+		l.mu.Lock()
+		l.msgs = append(l.msgs, fmt.Sprintf(msg, args...))
+		l.mu.Unlock()
+	}
+	l.TestLogger.Log(level, msg, args...)
+}
+
+func (l *recordingLogger) warnings() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.msgs
+}
@@ -0,0 +1,194 @@
+package test_test
+
+import (
+	"context"
+	"testing"
+
+	addr "github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/ipfs/go-cid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	cbg "github.com/whyrusleeping/cbor-gen"
+
+	states2 "github.com/filecoin-project/specs-actors/v2/actors/states"
+	adt2 "github.com/filecoin-project/specs-actors/v2/actors/util/adt"
+	vm2 "github.com/filecoin-project/specs-actors/v2/support/vm"
+
+	builtin3 "github.com/filecoin-project/specs-actors/v3/actors/builtin"
+	token3 "github.com/filecoin-project/specs-actors/v3/actors/builtin/token"
+	"github.com/filecoin-project/specs-actors/v3/actors/migration/nv9"
+	states3 "github.com/filecoin-project/specs-actors/v3/actors/states"
+	adt3 "github.com/filecoin-project/specs-actors/v3/actors/util/adt"
+	tutil "github.com/filecoin-project/specs-actors/v3/support/testing"
+)
+
+func TestTokenMigration(t *testing.T) {
+	ctx := context.Background()
+	v := vm2.NewVMWithSingletons(ctx, t)
+	store := v.Store()
+
+	creator := tutil.NewIDAddr(t, 1000)
+	holder := tutil.NewIDAddr(t, 1001)
+	operator := tutil.NewIDAddr(t, 1002)
+
+	// Builds the prior token state with v2 collections. Token 1 has two holders and an emptied balance,
+	// token 2 is held only by its creator.
+	putCid := func(c cid.Cid) *cbg.CborCid {
+		cc := cbg.CborCid(c)
+		return &cc
+	}
+	setArray := func(values map[uint64]cbg.CBORMarshaler) cid.Cid {
+		array := adt2.MakeEmptyArray(store)
+		for i, value := range values {
+			require.NoError(t, array.Set(i, value))
+		}
+		root, err := array.Root()
+		require.NoError(t, err)
+		return root
+	}
+	putMap := func(values map[abi.Keyer]cbg.CBORMarshaler) cid.Cid {
+		m := adt2.MakeEmptyMap(store)
+		for k, value := range values {
+			require.NoError(t, m.Put(k, value))
+		}
+		root, err := m.Root()
+		require.NoError(t, err)
+		return root
+	}
+	amount := func(n int64) *abi.TokenAmount {
+		a := big.NewInt(n)
+		return &a
+	}
+	putBalances := func(balances map[abi.Keyer]cbg.CBORMarshaler) *cbg.CborCid {
+		c, err := store.Put(ctx, &token3.AddrTokenAmountMap{AddrTokenAmountMap: putMap(balances)})
+		require.NoError(t, err)
+		return putCid(c)
+	}
+
+	approves, err := store.Put(ctx, &token3.AddrApproveMap{AddrApproveMap: putMap(map[abi.Keyer]cbg.CBORMarshaler{
+		abi.AddrKey(operator): amount(1),
+	})})
+	require.NoError(t, err)
+
+	inState := nv9.PriorTokenState{
+		Nonce: big.NewInt(2),
+		URIs: setArray(map[uint64]cbg.CBORMarshaler{
+			1: &token3.TokenURI{TokenURI: "uri1"},
+			2: &token3.TokenURI{TokenURI: "uri2"},
+		}),
+		Creators: setArray(map[uint64]cbg.CBORMarshaler{1: &creator, 2: &creator}),
+		Balances: setArray(map[uint64]cbg.CBORMarshaler{
+			1: putBalances(map[abi.Keyer]cbg.CBORMarshaler{
+				abi.AddrKey(creator):  amount(60),
+				abi.AddrKey(holder):   amount(40),
+				abi.AddrKey(operator): amount(0),
+			}),
+			2: putBalances(map[abi.Keyer]cbg.CBORMarshaler{
+				abi.AddrKey(creator): amount(5),
+			}),
+		}),
+		Approves: putMap(map[abi.Keyer]cbg.CBORMarshaler{abi.AddrKey(holder): putCid(approves)}),
+	}
+	head, err := store.Put(ctx, &inState)
+	require.NoError(t, err)
+
+	tree, err := v.GetStateTree()
+	require.NoError(t, err)
+	require.NoError(t, tree.SetActor(builtin3.TokenActorAddr, &states2.Actor{
+		Code:    builtin3.TokenActorCodeID,
+		Head:    head,
+		Balance: big.Zero(),
+	}))
+	root, err := tree.Flush()
+	require.NoError(t, err)
+
+	log := TestLogger{t}
+	nextRoot, err := nv9.MigrateStateTree(ctx, store, root, abi.ChainEpoch(0), nv9.Config{MaxWorkers: 1}, log, nv9.NewMemMigrationCache())
+	require.NoError(t, err)
+
+	treeOut, err := states3.LoadTree(store, nextRoot)
+	require.NoError(t, err)
+	actor, found, err := treeOut.GetActor(builtin3.TokenActorAddr)
+	require.NoError(t, err)
+	require.True(t, found)
+	assert.Equal(t, builtin3.TokenActorCodeID, actor.Code)
+
+	var st token3.State
+	require.NoError(t, store.Get(ctx, actor.Head, &st))
+	adtStore := adt3.WrapStore(ctx, store)
+
+	summary, msgs := token3.CheckStateInvariants(&st, adtStore)
+	assert.Empty(t, msgs.Messages())
+	assert.Equal(t, big.NewInt(2), st.Nonce)
+	assert.Equal(t, big.NewInt(100), summary.Supplies[1])
+	assert.Equal(t, big.NewInt(5), summary.Supplies[2])
+	assert.Equal(t, big.NewInt(60), summary.Balances[1][creator])
+	assert.Equal(t, big.NewInt(40), summary.Balances[1][holder])
+	assert.Equal(t, big.NewInt(5), summary.Balances[2][creator])
+
+	suppliesArray, err := adt3.AsArray(adtStore, st.Supplies, token3.LaneStatesAmtBitwidth)
+	require.NoError(t, err)
+	supply, found, err := st.LoadTokenSupply(suppliesArray, big.NewInt(1))
+	require.NoError(t, err)
+	require.True(t, found)
+	assert.Equal(t, big.NewInt(100), supply)
+	mintedArray, err := adt3.AsArray(adtStore, st.Minted, token3.LaneStatesAmtBitwidth)
+	require.NoError(t, err)
+	minted, found, err := st.LoadTokenMinted(mintedArray, big.NewInt(1))
+	require.NoError(t, err)
+	require.True(t, found)
+	assert.Equal(t, big.NewInt(100), minted)
+
+	uris, err := adt3.AsArray(adtStore, st.URIs, token3.LaneStatesAmtBitwidth)
+	require.NoError(t, err)
+	uri, found, err := st.LoadTokenURI(uris, big.NewInt(2))
+	require.NoError(t, err)
+	require.True(t, found)
+	assert.Equal(t, "uri2", uri.TokenURI)
+
+	// Every token is given the metadata of a token created without any.
+	metadataArray, err := adt3.AsArray(adtStore, st.Metadata, token3.LaneStatesAmtBitwidth)
+	require.NoError(t, err)
+	for tokenID := int64(1); tokenID <= 2; tokenID++ {
+		metadata, found, err := st.LoadTokenMetadata(metadataArray, big.NewInt(tokenID))
+		require.NoError(t, err)
+		require.True(t, found)
+		assert.Equal(t, token3.TokenMetadata{MaxSupply: big.Zero()}, *metadata)
+	}
+
+	// The holder index lists only positive balances.
+	tokensOf := func(a addr.Address) map[uint64]abi.TokenAmount {
+		held := make(map[uint64]abi.TokenAmount)
+		require.NoError(t, st.ForEachTokenOf(adtStore, a, func(tokenID big.Int, balance abi.TokenAmount) error {
+			held[tokenID.Uint64()] = balance
+			return nil
+		}))
+		return held
+	}
+	assert.Equal(t, map[uint64]abi.TokenAmount{1: big.NewInt(60), 2: big.NewInt(5)}, tokensOf(creator))
+	assert.Equal(t, map[uint64]abi.TokenAmount{1: big.NewInt(40)}, tokensOf(holder))
+	assert.Empty(t, tokensOf(operator))
+
+	approvesMap, err := adt3.AsMap(adtStore, st.Approves, builtin3.DefaultHamtBitwidth)
+	require.NoError(t, err)
+	approveMap, found, err := st.LoadAddrApproveMap(adtStore, approvesMap, holder)
+	require.NoError(t, err)
+	require.True(t, found)
+	operatorApprovals, err := adt3.AsMap(adtStore, approveMap.AddrApproveMap, builtin3.DefaultHamtBitwidth)
+	require.NoError(t, err)
+	approved, _, err := st.LoadAddrApprove(operatorApprovals, operator)
+	require.NoError(t, err)
+	assert.True(t, approved)
+
+	// Collections the prior state did not have start out empty.
+	emptyArray, err := adt3.StoreEmptyArray(adtStore, token3.LaneStatesAmtBitwidth)
+	require.NoError(t, err)
+	emptyMap, err := adt3.StoreEmptyMap(adtStore, builtin3.DefaultHamtBitwidth)
+	require.NoError(t, err)
+	assert.Equal(t, emptyMap, st.Allowances)
+	assert.Equal(t, emptyArray, st.Events)
+	assert.Equal(t, emptyArray, st.PendingCreators)
+	assert.Equal(t, emptyArray, st.Minters)
+}
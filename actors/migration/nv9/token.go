@@ -0,0 +1,209 @@
+package nv9
+
+import (
+	"context"
+
+	addr "github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	cid "github.com/ipfs/go-cid"
+	cbor "github.com/ipfs/go-ipld-cbor"
+	cbg "github.com/whyrusleeping/cbor-gen"
+
+	builtin3 "github.com/filecoin-project/specs-actors/v3/actors/builtin"
+	token3 "github.com/filecoin-project/specs-actors/v3/actors/builtin/token"
+	adt3 "github.com/filecoin-project/specs-actors/v3/actors/util/adt"
+)
+
+// PriorTokenActorCodeID is the code CID of the token actor deployed before this upgrade.
+// builtin2 registers no token actor: the deployed one already runs under the code CID builtin3 registers,
+// with the PriorTokenState schema, and keeps that code CID through the migration.
+var PriorTokenActorCodeID = builtin3.TokenActorCodeID
+
+// PriorTokenState is the state of the token actor deployed before this upgrade.
+// It predates supplies, metadata, allowances, events, delegated creation and minting, and the holder index.
+type PriorTokenState struct {
+	Nonce    big.Int
+	URIs     cid.Cid // array, AMT[TokenID]TokenURI
+	Creators cid.Cid // array, AMT[TokenID]addr.Address
+	Balances cid.Cid // array, AMT[TokenID]AddrTokenAmountMap
+	Approves cid.Cid // Map, HAMT[address]AddrApproveMap
+}
+
+type tokenMigrator struct{}
+
+func (m tokenMigrator) migrateState(ctx context.Context, store cbor.IpldStore, in actorMigrationInput) (*actorMigrationResult, error) {
+	var inState PriorTokenState
+	if err := store.Get(ctx, in.head, &inState); err != nil {
+		return nil, err
+	}
+
+	urisOut, err := migrateAMTRaw(ctx, store, inState.URIs, token3.LaneStatesAmtBitwidth)
+	if err != nil {
+		return nil, err
+	}
+	creatorsOut, err := migrateAMTRaw(ctx, store, inState.Creators, token3.LaneStatesAmtBitwidth)
+	if err != nil {
+		return nil, err
+	}
+	balancesOut, err := migrateAMTCids(ctx, store, inState.Balances, token3.LaneStatesAmtBitwidth, func(c cid.Cid) (cid.Cid, error) {
+		return m.migrateBalances(ctx, store, c)
+	})
+	if err != nil {
+		return nil, err
+	}
+	approvesOut, err := migrateHAMTCids(ctx, store, inState.Approves, builtin3.DefaultHamtBitwidth, func(c cid.Cid) (cid.Cid, error) {
+		return m.migrateApproves(ctx, store, c)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Collections the prior state did not have start out empty, except for those derived from the balances
+	// and the metadata that the new state requires for every token.
+	adtStore := adt3.WrapStore(ctx, store)
+	outState, err := token3.ConstructState(adtStore)
+	if err != nil {
+		return nil, err
+	}
+	outState.Nonce = inState.Nonce
+	outState.URIs = urisOut
+	outState.Creators = creatorsOut
+	outState.Balances = balancesOut
+	outState.Approves = approvesOut
+	if outState.Supplies, outState.Holdings, err = m.indexBalances(adtStore, balancesOut); err != nil {
+		return nil, err
+	}
+	// Burns were not recorded before the upgrade, so the total minted of each token starts at its supply.
+	outState.Minted = outState.Supplies
+	if outState.Metadata, err = m.defaultMetadata(adtStore, inState.Nonce); err != nil {
+		return nil, err
+	}
+
+	newHead, err := store.Put(ctx, outState)
+	return &actorMigrationResult{
+		newCodeCID: m.migratedCodeCID(),
+		newHead:    newHead,
+	}, err
+}
+
+func (m tokenMigrator) migratedCodeCID() cid.Cid {
+	return builtin3.TokenActorCodeID
+}
+
+// Migrates the balances of a token, held in a HAMT referenced from an AddrTokenAmountMap block.
+func (m tokenMigrator) migrateBalances(ctx context.Context, store cbor.IpldStore, root cid.Cid) (cid.Cid, error) {
+	var balances token3.AddrTokenAmountMap
+	if err := store.Get(ctx, root, &balances); err != nil {
+		return cid.Undef, err
+	}
+	balanceMapOut, err := migrateHAMTRaw(ctx, store, balances.AddrTokenAmountMap, builtin3.DefaultHamtBitwidth)
+	if err != nil {
+		return cid.Undef, err
+	}
+	return store.Put(ctx, &token3.AddrTokenAmountMap{AddrTokenAmountMap: balanceMapOut})
+}
+
+// Migrates the operator approvals of an owner, held in a HAMT referenced from an AddrApproveMap block.
+func (m tokenMigrator) migrateApproves(ctx context.Context, store cbor.IpldStore, root cid.Cid) (cid.Cid, error) {
+	var approves token3.AddrApproveMap
+	if err := store.Get(ctx, root, &approves); err != nil {
+		return cid.Undef, err
+	}
+	approveMapOut, err := migrateHAMTRaw(ctx, store, approves.AddrApproveMap, builtin3.DefaultHamtBitwidth)
+	if err != nil {
+		return cid.Undef, err
+	}
+	return store.Put(ctx, &token3.AddrApproveMap{AddrApproveMap: approveMapOut})
+}
+
+// Computes the supply of each token and the holder index from migrated balances.
+func (m tokenMigrator) indexBalances(store adt3.Store, balancesOut cid.Cid) (cid.Cid, cid.Cid, error) {
+	balances, err := adt3.AsArray(store, balancesOut, token3.LaneStatesAmtBitwidth)
+	if err != nil {
+		return cid.Undef, cid.Undef, err
+	}
+	supplies, err := adt3.MakeEmptyArray(store, token3.LaneStatesAmtBitwidth)
+	if err != nil {
+		return cid.Undef, cid.Undef, err
+	}
+	holdings := make(map[addr.Address]*adt3.Map)
+
+	var balancesRoot cbg.CborCid
+	if err = balances.ForEach(&balancesRoot, func(tokenID int64) error {
+		var addrTokenAmountMap token3.AddrTokenAmountMap
+		if err := store.Get(store.Context(), cid.Cid(balancesRoot), &addrTokenAmountMap); err != nil {
+			return err
+		}
+		balanceMap, err := adt3.AsMap(store, addrTokenAmountMap.AddrTokenAmountMap, builtin3.DefaultHamtBitwidth)
+		if err != nil {
+			return err
+		}
+		supply := big.Zero()
+		var balance abi.TokenAmount
+		if err := balanceMap.ForEach(&balance, func(k string) error {
+			holder, err := addr.NewFromBytes([]byte(k))
+			if err != nil {
+				return err
+			}
+			if !balance.GreaterThan(big.Zero()) {
+				return nil
+			}
+			supply = big.Add(supply, balance)
+
+			tokens, ok := holdings[holder]
+			if !ok {
+				if tokens, err = adt3.MakeEmptyMap(store, builtin3.DefaultHamtBitwidth); err != nil {
+					return err
+				}
+				holdings[holder] = tokens
+			}
+			return tokens.Put(abi.UIntKey(uint64(tokenID)), nil)
+		}); err != nil {
+			return err
+		}
+		return supplies.Set(uint64(tokenID), &supply)
+	}); err != nil {
+		return cid.Undef, cid.Undef, err
+	}
+
+	holdingsMap, err := adt3.MakeEmptyMap(store, builtin3.DefaultHamtBitwidth)
+	if err != nil {
+		return cid.Undef, cid.Undef, err
+	}
+	for holder, tokens := range holdings {
+		root, err := tokens.Root()
+		if err != nil {
+			return cid.Undef, cid.Undef, err
+		}
+		rootCborCid := cbg.CborCid(root)
+		if err := holdingsMap.Put(abi.AddrKey(holder), &rootCborCid); err != nil {
+			return cid.Undef, cid.Undef, err
+		}
+	}
+
+	suppliesRoot, err := supplies.Root()
+	if err != nil {
+		return cid.Undef, cid.Undef, err
+	}
+	holdingsRoot, err := holdingsMap.Root()
+	if err != nil {
+		return cid.Undef, cid.Undef, err
+	}
+	return suppliesRoot, holdingsRoot, nil
+}
+
+// Gives every token created before the upgrade the metadata of a token created without any:
+// fungible, with no name, symbol or decimals, and an uncapped supply.
+func (m tokenMigrator) defaultMetadata(store adt3.Store, nonce big.Int) (cid.Cid, error) {
+	metadata, err := adt3.MakeEmptyArray(store, token3.LaneStatesAmtBitwidth)
+	if err != nil {
+		return cid.Undef, err
+	}
+	for tokenID := uint64(1); tokenID <= nonce.Uint64(); tokenID++ {
+		if err := metadata.Set(tokenID, &token3.TokenMetadata{MaxSupply: big.Zero()}); err != nil {
+			return cid.Undef, err
+		}
+	}
+	return metadata.Root()
+}
@@ -16,6 +16,7 @@ import (
 	"golang.org/x/xerrors"
 
 	builtin2 "github.com/filecoin-project/specs-actors/v2/actors/builtin"
+	exported2 "github.com/filecoin-project/specs-actors/v2/actors/builtin/exported"
 	states2 "github.com/filecoin-project/specs-actors/v2/actors/states"
 
 	builtin3 "github.com/filecoin-project/specs-actors/v3/actors/builtin"
@@ -37,6 +38,9 @@ type Config struct {
 	// Time between progress logs to emit.
 	// Zero (the default) results in no progress logs.
 	ProgressLogPeriod time.Duration
+	// How the stake actor's state is checked before it is migrated, including when its migration is found in the cache.
+	// Verification only logs or fails on drift, and never changes the migrated state.
+	StakeVerification StakeVerification
 }
 
 type Logger interface {
@@ -83,14 +87,27 @@ func MigrateStateTree(ctx context.Context, store cbor.IpldStore, actorsRootIn ci
 		builtin2.StoragePowerActorCodeID:     cachedMigration(cache, powerMigrator{}),
 		builtin2.SystemActorCodeID:           nilMigrator{builtin3.SystemActorCodeID},
 		builtin2.VerifiedRegistryActorCodeID: cachedMigration(cache, verifregMigrator{}),
-		builtin2.StakeActorCodeID:            cachedMigration(cache, stakeMigrator{}),
+		builtin2.StakeActorCodeID:            verifiedStakeMigrator{cfg.StakeVerification, log, cachedMigration(cache, stakeMigrator{})},
+		PriorTokenActorCodeID:                cachedMigration(cache, tokenMigrator{}),
 	}
 	// Set of prior version code CIDs for actors to defer during iteration, for explicit migration afterwards.
 	var deferredCodeIDs = map[cid.Cid]struct{}{
 		// None
 	}
-	if len(migrations)+len(deferredCodeIDs) != 12 {
-		panic(fmt.Sprintf("incomplete migration specification with %d code CIDs", len(migrations)))
+	// Every actor registered by builtin2, and the token actor it does not register, must be migrated or deferred.
+	priorCodeIDs := []cid.Cid{PriorTokenActorCodeID}
+	for _, actor := range exported2.BuiltinActors() {
+		priorCodeIDs = append(priorCodeIDs, actor.Code())
+	}
+	for _, code := range priorCodeIDs {
+		_, migrated := migrations[code]
+		_, deferred := deferredCodeIDs[code]
+		if !migrated && !deferred {
+			panic(fmt.Sprintf("incomplete migration specification, no migration for code CID %s", code))
+		}
+	}
+	if len(migrations)+len(deferredCodeIDs) != len(priorCodeIDs) {
+		panic(fmt.Sprintf("migration specification has %d code CIDs, expected %d", len(migrations)+len(deferredCodeIDs), len(priorCodeIDs)))
 	}
 	startTime := time.Now()
 
@@ -134,6 +134,73 @@ func migrateHAMTAMTRaw(ctx context.Context, store cbor.IpldStore, root cid.Cid,
 	return store.Put(ctx, outRootNodeOuter)
 }
 
+// Migrates a HAMT whose values are CIDs from v2 to v3, replacing each value with the result of migrating the
+// structure it references.
+func migrateHAMTCids(ctx context.Context, store cbor.IpldStore, root cid.Cid, newBitwidth int, migrateValue func(cid.Cid) (cid.Cid, error)) (cid.Cid, error) {
+	inRootNode, err := hamt2.LoadNode(ctx, store, root, adt2.HamtOptions...)
+	if err != nil {
+		return cid.Undef, err
+	}
+
+	newOpts := append(adt3.DefaultHamtOptions, hamt3.UseTreeBitWidth(newBitwidth))
+	outRootNode, err := hamt3.NewNode(store, newOpts...)
+	if err != nil {
+		return cid.Undef, err
+	}
+
+	if err = inRootNode.ForEach(ctx, func(k string, val interface{}) error {
+		var inValue cbg.CborCid
+		if err := inValue.UnmarshalCBOR(bytes.NewReader(val.(*cbg.Deferred).Raw)); err != nil {
+			return err
+		}
+		outValue, err := migrateValue(cid.Cid(inValue))
+		if err != nil {
+			return err
+		}
+		c := cbg.CborCid(outValue)
+		return outRootNode.Set(ctx, k, &c)
+	}); err != nil {
+		return cid.Undef, err
+	}
+
+	if err := outRootNode.Flush(ctx); err != nil {
+		return cid.Undef, err
+	}
+	return store.Put(ctx, outRootNode)
+}
+
+// Migrates an AMT whose values are CIDs from v2 to v3, replacing each value with the result of migrating the
+// structure it references.
+func migrateAMTCids(ctx context.Context, store cbor.IpldStore, root cid.Cid, newBitwidth int, migrateValue func(cid.Cid) (cid.Cid, error)) (cid.Cid, error) {
+	inRootNode, err := amt2.LoadAMT(ctx, store, root)
+	if err != nil {
+		return cid.Undef, err
+	}
+
+	newOpts := append(adt3.DefaultAmtOptions, amt3.UseTreeBitWidth(uint(newBitwidth)))
+	outRootNode, err := amt3.NewAMT(store, newOpts...)
+	if err != nil {
+		return cid.Undef, err
+	}
+
+	if err = inRootNode.ForEach(ctx, func(k uint64, d *cbg.Deferred) error {
+		var inValue cbg.CborCid
+		if err := inValue.UnmarshalCBOR(bytes.NewReader(d.Raw)); err != nil {
+			return err
+		}
+		outValue, err := migrateValue(cid.Cid(inValue))
+		if err != nil {
+			return err
+		}
+		c := cbg.CborCid(outValue)
+		return outRootNode.Set(ctx, k, &c)
+	}); err != nil {
+		return cid.Undef, err
+	}
+
+	return outRootNode.Flush(ctx)
+}
+
 type MemMigrationCache struct {
 	MigrationMap sync.Map
 }
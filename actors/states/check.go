@@ -7,6 +7,8 @@ import (
 	"github.com/filecoin-project/go-state-types/big"
 	"github.com/filecoin-project/specs-actors/v3/actors/builtin/power"
 	"github.com/filecoin-project/specs-actors/v3/actors/builtin/reward"
+	"github.com/filecoin-project/specs-actors/v3/actors/builtin/stake"
+	"github.com/filecoin-project/specs-actors/v3/actors/builtin/token"
 	"golang.org/x/xerrors"
 
 	"github.com/filecoin-project/specs-actors/v3/actors/builtin"
@@ -35,6 +37,8 @@ func CheckStateInvariants(tree *Tree, expectedBalanceTotal abi.TokenAmount, prio
 	var powerSummary *power.StateSummary
 	var paychSummaries []*paych.StateSummary
 	var multisigSummaries []*multisig.StateSummary
+	var stakeSummary *stake.StateSummary
+	var tokenSummary *token.StateSummary
 	minerSummaries := make(map[addr.Address]*miner.StateSummary)
 
 	if err := tree.ForEach(func(key addr.Address, actor *Actor) error {
@@ -134,6 +138,24 @@ func CheckStateInvariants(tree *Tree, expectedBalanceTotal abi.TokenAmount, prio
 			acc.WithPrefix("verifreg: ").AddAll(msgs)
 			verifregSummary = summary
 
+		case builtin.StakeActorCodeID:
+			var st stake.State
+			if err := tree.Store.Get(tree.Store.Context(), actor.Head, &st); err != nil {
+				return err
+			}
+			summary, msgs := stake.CheckStateInvariants(&st, tree.Store, actor.Balance)
+			acc.WithPrefix("stake: ").AddAll(msgs)
+			stakeSummary = summary
+
+		case builtin.TokenActorCodeID:
+			var st token.State
+			if err := tree.Store.Get(tree.Store.Context(), actor.Head, &st); err != nil {
+				return err
+			}
+			summary, msgs := token.CheckStateInvariants(&st, tree.Store)
+			acc.WithPrefix("token: ").AddAll(msgs)
+			tokenSummary = summary
+
 		default:
 			return xerrors.Errorf("unexpected actor code CID %v for address %v", actor.Code, key)
 
@@ -155,6 +177,8 @@ func CheckStateInvariants(tree *Tree, expectedBalanceTotal abi.TokenAmount, prio
 	_ = cronSummary
 	_ = marketSummary
 	_ = rewardSummary
+	_ = stakeSummary
+	_ = tokenSummary
 
 	if !totalFIl.Equals(expectedBalanceTotal) {
 		acc.Addf("total token balance is %v, expected %v", totalFIl, expectedBalanceTotal)
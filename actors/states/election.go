@@ -7,9 +7,20 @@ import (
 
 	"github.com/filecoin-project/specs-actors/v3/actors/builtin/miner"
 	"github.com/filecoin-project/specs-actors/v3/actors/builtin/power"
+	"github.com/filecoin-project/specs-actors/v3/actors/builtin/stake"
 	"github.com/filecoin-project/specs-actors/v3/actors/util/adt"
 )
 
+// Minimum stake power bonded to a miner for it to be eligible for election.
+// The stake bonded to a miner is the stake power of its owner, as for the pledge discount, so every miner
+// of an owner is measured against the owner's whole stake. Zero disables the requirement.
+var ConsensusMinerMinStake = big.Zero() // PARAM_SPEC
+
+// Share of a miner's election weight drawn from its share of total stake power, over StakeElectionWeightDenominator.
+// The remainder is drawn from its quality-adjusted power claim. Zero weighs storage alone.
+var StakeElectionWeight = big.Zero() // PARAM_SPEC
+var StakeElectionWeightDenominator = big.NewInt(100)
+
 // Checks for miner election eligibility.
 // A miner must satisfy conditions on both the immediate parent state, as well as state at the
 // Winning PoSt election lookback.
@@ -47,3 +58,52 @@ func MinerPoStLookbackEligibleForElection(store adt.Store, pstate *power.State,
 	// Minimum power requirements.
 	return pstate.MinerNominalPowerMeetsConsensusMinimum(store, mAddr)
 }
+
+// Tests whether the stake bonded to a miner meets the consensus minimum.
+// The stake state may be taken either from the immediately prior state or at Winning PoSt lookback,
+// matching the power state it is used with.
+func MinerStakeEligibleForElection(store adt.Store, sstate *stake.State, mstate *miner.State) (bool, error) {
+	stakePower, err := ownerStakePower(store, sstate, mstate)
+	if err != nil {
+		return false, err
+	}
+	return stakePower.GreaterThanEqual(ConsensusMinerMinStake), nil
+}
+
+// Computes a miner's election weight, combining its quality-adjusted power with its share of total stake power.
+// The stake part is expressed in quality-adjusted power as the owner's share of total stake power applied to the
+// total quality-adjusted power. The owner's stake is not split between its miners: each of them is weighted with
+// all of it. The weights of all miners therefore sum to the total quality-adjusted power only when no owner
+// with stake controls more than one miner, and exceed it otherwise.
+func StakeWeightedPower(store adt.Store, sstate *stake.State, pstate *power.State, mstate *miner.State, mAddr addr.Address) (abi.StoragePower, error) {
+	claim, found, err := pstate.GetClaim(store, mAddr)
+	if err != nil {
+		return big.Zero(), err
+	}
+	qaPower := big.Zero()
+	if found {
+		qaPower = claim.QualityAdjPower
+	}
+	storageWeight := big.Sub(StakeElectionWeightDenominator, StakeElectionWeight)
+	weighted := big.Mul(qaPower, storageWeight)
+
+	if StakeElectionWeight.GreaterThan(big.Zero()) && sstate.TotalStakePower.GreaterThan(big.Zero()) {
+		stakePower, err := ownerStakePower(store, sstate, mstate)
+		if err != nil {
+			return big.Zero(), err
+		}
+		stakeShare := big.Mul(pstate.TotalQualityAdjPower, stakePower)
+		stakeShare = big.Div(stakeShare, sstate.TotalStakePower)
+		weighted = big.Add(weighted, big.Mul(stakeShare, StakeElectionWeight))
+	}
+	return big.Div(weighted, StakeElectionWeightDenominator), nil
+}
+
+// Returns the stake power of a miner's owner, which is the stake bonded to the miner.
+func ownerStakePower(store adt.Store, sstate *stake.State, mstate *miner.State) (abi.StakePower, error) {
+	info, err := mstate.GetInfo(store)
+	if err != nil {
+		return big.Zero(), err
+	}
+	return sstate.GetStakePower(store, info.Owner)
+}
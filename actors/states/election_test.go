@@ -13,6 +13,7 @@ import (
 	"github.com/filecoin-project/specs-actors/v3/actors/builtin"
 	"github.com/filecoin-project/specs-actors/v3/actors/builtin/miner"
 	"github.com/filecoin-project/specs-actors/v3/actors/builtin/power"
+	"github.com/filecoin-project/specs-actors/v3/actors/builtin/stake"
 	"github.com/filecoin-project/specs-actors/v3/actors/states"
 	"github.com/filecoin-project/specs-actors/v3/actors/util/adt"
 	"github.com/filecoin-project/specs-actors/v3/support/ipld"
@@ -139,6 +140,135 @@ func TestMinerEligibleAtLookback(t *testing.T) {
 	})
 }
 
+func TestMinerStakeEligibleForElection(t *testing.T) {
+	ctx := context.Background()
+	store := ipld.NewADTStore(ctx)
+	owner := tutil.NewIDAddr(t, 100)
+	maddr := tutil.NewIDAddr(t, 101)
+	otherOwner := tutil.NewIDAddr(t, 102)
+
+	defer func(prev abi.StakePower) { states.ConsensusMinerMinStake = prev }(states.ConsensusMinerMinStake)
+	// stake held by the miner's own address is not bonded to it
+	sstate := constructStakeStateWithStakers(t, store, map[address.Address]abi.StakePower{
+		owner: big.NewInt(1000),
+		maddr: big.NewInt(5000),
+	})
+	mstate := constructMinerState(ctx, t, store, owner)
+	otherMstate := constructMinerState(ctx, t, store, otherOwner)
+
+	t.Run("no minimum", func(t *testing.T) {
+		states.ConsensusMinerMinStake = big.Zero()
+		eligible, err := states.MinerStakeEligibleForElection(store, sstate, otherMstate)
+		require.NoError(t, err)
+		assert.True(t, eligible)
+	})
+
+	t.Run("owner stake meets minimum", func(t *testing.T) {
+		states.ConsensusMinerMinStake = big.NewInt(1000)
+		eligible, err := states.MinerStakeEligibleForElection(store, sstate, mstate)
+		require.NoError(t, err)
+		assert.True(t, eligible)
+	})
+
+	t.Run("owner stake below minimum", func(t *testing.T) {
+		states.ConsensusMinerMinStake = big.NewInt(1001)
+		eligible, err := states.MinerStakeEligibleForElection(store, sstate, mstate)
+		require.NoError(t, err)
+		assert.False(t, eligible)
+
+		eligible, err = states.MinerStakeEligibleForElection(store, sstate, otherMstate)
+		require.NoError(t, err)
+		assert.False(t, eligible)
+	})
+
+	t.Run("every miner of an owner is measured against its whole stake", func(t *testing.T) {
+		states.ConsensusMinerMinStake = big.NewInt(1000)
+		secondMstate := constructMinerState(ctx, t, store, owner)
+		for _, ms := range []*miner.State{mstate, secondMstate} {
+			eligible, err := states.MinerStakeEligibleForElection(store, sstate, ms)
+			require.NoError(t, err)
+			assert.True(t, eligible)
+		}
+	})
+}
+
+func TestStakeWeightedPower(t *testing.T) {
+	ctx := context.Background()
+	store := ipld.NewADTStore(ctx)
+	proofType := abi.RegisteredPoStProof_StackedDrgWindow32GiBV1
+	owner := tutil.NewIDAddr(t, 100)
+	maddr := tutil.NewIDAddr(t, 101)
+	otherOwner := tutil.NewIDAddr(t, 102)
+	other := tutil.NewIDAddr(t, 103)
+
+	defer func(prev big.Int) { states.StakeElectionWeight = prev }(states.StakeElectionWeight)
+
+	// the miner holds 1/4 of quality-adjusted power and its owner 3/4 of stake power
+	pstate := constructPowerStateWithMiner(t, store, maddr, abi.NewStoragePower(1000), proofType)
+	pstate.TotalQualityAdjPower = abi.NewStoragePower(4000)
+	sstate := constructStakeStateWithStakers(t, store, map[address.Address]abi.StakePower{
+		owner:      big.NewInt(300),
+		otherOwner: big.NewInt(100),
+	})
+	mstates := map[address.Address]*miner.State{
+		maddr: constructMinerState(ctx, t, store, owner),
+		other: constructMinerState(ctx, t, store, otherOwner),
+	}
+
+	for _, tc := range []struct {
+		stakeWeight int64
+		miner       address.Address
+		expected    abi.StoragePower
+	}{{
+		// storage alone
+		stakeWeight: 0,
+		miner:       maddr,
+		expected:    abi.NewStoragePower(1000),
+	}, {
+		stakeWeight: 50,
+		miner:       maddr,
+		expected:    abi.NewStoragePower(2000), // (1000 + 3000) / 2
+	}, {
+		// stake alone
+		stakeWeight: 100,
+		miner:       maddr,
+		expected:    abi.NewStoragePower(3000),
+	}, {
+		// stake without a power claim
+		stakeWeight: 50,
+		miner:       other,
+		expected:    abi.NewStoragePower(500),
+	}} {
+		states.StakeElectionWeight = big.NewInt(tc.stakeWeight)
+		weighted, err := states.StakeWeightedPower(store, sstate, pstate, mstates[tc.miner], tc.miner)
+		require.NoError(t, err)
+		assert.Equal(t, tc.expected, weighted)
+	}
+
+	t.Run("no stake", func(t *testing.T) {
+		states.StakeElectionWeight = big.NewInt(50)
+		empty := constructStakeStateWithStakers(t, store, nil)
+		weighted, err := states.StakeWeightedPower(store, empty, pstate, mstates[maddr], maddr)
+		require.NoError(t, err)
+		assert.Equal(t, abi.NewStoragePower(500), weighted)
+	})
+
+	t.Run("miners of one owner are each weighted with its whole stake", func(t *testing.T) {
+		states.StakeElectionWeight = big.NewInt(100)
+		second := tutil.NewIDAddr(t, 104)
+		secondMstate := constructMinerState(ctx, t, store, owner)
+
+		first, err := states.StakeWeightedPower(store, sstate, pstate, mstates[maddr], maddr)
+		require.NoError(t, err)
+		weighted, err := states.StakeWeightedPower(store, sstate, pstate, secondMstate, second)
+		require.NoError(t, err)
+		assert.Equal(t, abi.NewStoragePower(3000), first)
+		assert.Equal(t, abi.NewStoragePower(3000), weighted)
+		// the owner's stake is counted once per miner, so the weights exceed the total power
+		assert.True(t, big.Add(first, weighted).GreaterThan(pstate.TotalQualityAdjPower))
+	})
+}
+
 func constructMinerState(ctx context.Context, t *testing.T, store adt.Store, owner address.Address) *miner.State {
 	proofType := abi.RegisteredPoStProof_StackedDrgWindow32GiBV1
 	ssize, err := proofType.SectorSize()
@@ -187,3 +317,27 @@ func constructPowerStateWithMiner(t *testing.T, store adt.Store, maddr address.A
 	require.NoError(t, err)
 	return pSt
 }
+
+func constructStakeStateWithStakers(t *testing.T, store adt.Store, powers map[address.Address]abi.StakePower) *stake.State {
+	sSt, err := stake.ConstructState(store, &stake.ConstructorParams{
+		RootKey:               tutil.NewIDAddr(t, 100),
+		MaturePeriod:          abi.ChainEpoch(10),
+		RoundPeriod:           abi.ChainEpoch(20),
+		PrincipalLockDuration: abi.ChainEpoch(30),
+		MinDepositAmount:      abi.NewTokenAmount(1),
+		MaxRewardPerRound:     abi.NewTokenAmount(0),
+		InflationFactor:       big.Zero(),
+	}, nil)
+	require.NoError(t, err)
+
+	stakePowers, err := adt.AsMap(store, sSt.StakePowerMap, builtin.DefaultHamtBitwidth)
+	require.NoError(t, err)
+	for staker, pwr := range powers {
+		pwr := pwr
+		require.NoError(t, stakePowers.Put(abi.AddrKey(staker), &pwr))
+		sSt.TotalStakePower = big.Add(sSt.TotalStakePower, pwr)
+	}
+	sSt.StakePowerMap, err = stakePowers.Root()
+	require.NoError(t, err)
+	return sSt
+}
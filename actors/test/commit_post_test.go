@@ -119,6 +119,7 @@ func TestCommitPoStFlow(t *testing.T) {
 					{To: builtin.RewardActorAddr, Method: builtin.MethodsReward.UpdateNetworkKPI},
 				}},
 				{To: builtin.StorageMarketActorAddr, Method: builtin.MethodsMarket.CronTick},
+				{To: builtin.StakeActorAddr, Method: builtin.MethodsStake.OnEpochTickEnd},
 			},
 		}.Matches(t, tv.Invocations()[0])
 
@@ -174,6 +175,7 @@ func TestCommitPoStFlow(t *testing.T) {
 				{To: builtin.RewardActorAddr, Method: builtin.MethodsReward.UpdateNetworkKPI},
 			}},
 			{To: builtin.StorageMarketActorAddr, Method: builtin.MethodsMarket.CronTick},
+			{To: builtin.StakeActorAddr, Method: builtin.MethodsStake.OnEpochTickEnd},
 		},
 	}.Matches(t, v.Invocations()[1])
 
@@ -319,6 +321,7 @@ func TestCommitPoStFlow(t *testing.T) {
 					{To: builtin.RewardActorAddr, Method: builtin.MethodsReward.UpdateNetworkKPI},
 				}},
 				{To: builtin.StorageMarketActorAddr, Method: builtin.MethodsMarket.CronTick},
+				{To: builtin.StakeActorAddr, Method: builtin.MethodsStake.OnEpochTickEnd},
 			},
 		}.Matches(t, tv.Invocations()[0])
 
@@ -199,6 +199,7 @@ func TestReplaceCommittedCapacitySectorWithDealLadenSector(t *testing.T) {
 					// slash funds
 					{To: builtin.BurntFundsActorAddr, Method: builtin.MethodSend},
 				}},
+				{To: builtin.StakeActorAddr, Method: builtin.MethodsStake.OnEpochTickEnd},
 			},
 		}.Matches(t, tv.LastInvocation())
 	})
@@ -242,6 +243,7 @@ func TestReplaceCommittedCapacitySectorWithDealLadenSector(t *testing.T) {
 				{To: builtin.RewardActorAddr, Method: builtin.MethodsReward.UpdateNetworkKPI},
 			}},
 			{To: builtin.StorageMarketActorAddr, Method: builtin.MethodsMarket.CronTick},
+			{To: builtin.StakeActorAddr, Method: builtin.MethodsStake.OnEpochTickEnd},
 		},
 	}.Matches(t, v.LastInvocation())
 
@@ -285,6 +287,7 @@ func TestReplaceCommittedCapacitySectorWithDealLadenSector(t *testing.T) {
 					{To: builtin.RewardActorAddr, Method: builtin.MethodsReward.UpdateNetworkKPI},
 				}},
 				{To: builtin.StorageMarketActorAddr, Method: builtin.MethodsMarket.CronTick, SubInvocations: []vm.ExpectInvocation{}},
+				{To: builtin.StakeActorAddr, Method: builtin.MethodsStake.OnEpochTickEnd},
 			},
 		}.Matches(t, tv.LastInvocation())
 
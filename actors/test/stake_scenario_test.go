@@ -0,0 +1,201 @@
+package test_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	addr "github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/exitcode"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/specs-actors/v3/actors/builtin"
+	"github.com/filecoin-project/specs-actors/v3/actors/builtin/stake"
+	"github.com/filecoin-project/specs-actors/v3/actors/builtin/token"
+	"github.com/filecoin-project/specs-actors/v3/actors/states"
+	"github.com/filecoin-project/specs-actors/v3/support/ipld"
+	vm "github.com/filecoin-project/specs-actors/v3/support/vm"
+)
+
+func TestStakeOverManyEpochs(t *testing.T) {
+	ctx := context.Background()
+	params := &stake.ConstructorParams{
+		RootKey:               vm.StakeRoot,
+		MaturePeriod:          abi.ChainEpoch(10),
+		RoundPeriod:           abi.ChainEpoch(20),
+		PrincipalLockDuration: abi.ChainEpoch(30),
+		FirstRoundEpoch:       abi.ChainEpoch(0),
+		MinDepositAmount:      vm.FIL,
+		MaxRewardPerRound:     big.Mul(big.NewInt(100), vm.FIL),
+		InflationFactor:       big.NewInt(100),
+	}
+	v := vm.NewVMWithStakeParams(ctx, t, ipld.NewBlockStoreInMemory(), params, nil)
+	addrs := vm.CreateAccounts(ctx, t, v, 1, big.Mul(big.NewInt(10_000), vm.FIL), 93837778)
+	staker := addrs[0]
+	stakerID, found := v.NormalizeAddress(staker)
+	require.True(t, found)
+
+	deposit := big.Mul(big.NewInt(1_000), vm.FIL)
+	vm.ApplyOk(t, v, staker, builtin.StakeActorAddr, deposit, builtin.MethodsStake.Deposit, nil)
+
+	// principal contributes no power until it matures
+	vm.ApplyOk(t, v, builtin.SystemActorAddr, builtin.CronActorAddr, big.Zero(), builtin.MethodsCron.EpochTick, nil)
+	summary := stakeSummary(t, v)
+	assert.Equal(t, big.Zero(), summary.TotalStakePower)
+	assert.Equal(t, deposit, summary.TotalLockedPrincipal)
+
+	v = advanceStakeRounds(t, v, 1)
+	summary = stakeSummary(t, v)
+	assert.Equal(t, deposit, summary.StakePowers[stakerID])
+	assert.Equal(t, deposit, summary.TotalStakePower)
+
+	// principal cannot be withdrawn while locked
+	_, code := v.ApplyMessage(staker, builtin.StakeActorAddr, big.Zero(), builtin.MethodsStake.WithdrawPrincipal, &stake.WithdrawParams{AmountRequested: deposit})
+	assert.Equal(t, exitcode.ErrIllegalState, code)
+
+	// after the lock duration principal becomes available and keeps earning rewards
+	v = advanceStakeRounds(t, v, 1)
+	summary = stakeSummary(t, v)
+	assert.Equal(t, big.Zero(), summary.TotalLockedPrincipal)
+	assert.Equal(t, deposit, summary.TotalAvailablePrincipal)
+	assert.Equal(t, deposit, summary.TotalStakePower)
+	assert.True(t, summary.TotalVestingReward.GreaterThan(big.Zero()))
+	assert.Equal(t, big.Zero(), summary.TotalAvailableReward)
+
+	// rewards begin vesting a day after they are earned
+	v = advanceStakeRounds(t, v, 2*builtin.EpochsInDay/params.RoundPeriod+1)
+	summary = stakeSummary(t, v)
+	reward := summary.TotalAvailableReward
+	assert.True(t, reward.GreaterThan(big.Zero()))
+
+	balanceBefore := actorBalance(t, v, staker)
+	vm.ApplyOk(t, v, staker, builtin.StakeActorAddr, big.Zero(), builtin.MethodsStake.WithdrawReward, &stake.WithdrawParams{AmountRequested: reward})
+	vm.ApplyOk(t, v, staker, builtin.StakeActorAddr, big.Zero(), builtin.MethodsStake.WithdrawPrincipal, &stake.WithdrawParams{AmountRequested: deposit})
+	assert.Equal(t, big.Add(balanceBefore, reward), actorBalance(t, v, staker))
+
+	summary = stakeSummary(t, v)
+	assert.Equal(t, big.Zero(), summary.TotalStakePower)
+	assert.Equal(t, big.Zero(), summary.TotalAvailableReward)
+	assert.Equal(t, deposit, summary.TotalUnbondingPrincipal)
+	assert.True(t, summary.TotalVestingReward.GreaterThan(big.Zero()))
+
+	// withdrawn principal is paid out once it has unbonded
+	_, code = v.ApplyMessage(staker, builtin.StakeActorAddr, big.Zero(), builtin.MethodsStake.ClaimUnbonded, &stakerID)
+	assert.Equal(t, exitcode.ErrIllegalArgument, code)
+
+	v, err := v.WithEpoch(v.GetEpoch() + stake.DefaultUnbondingPeriod)
+	require.NoError(t, err)
+	vm.ApplyOk(t, v, builtin.SystemActorAddr, builtin.CronActorAddr, big.Zero(), builtin.MethodsCron.EpochTick, nil)
+	vm.ApplyOk(t, v, staker, builtin.StakeActorAddr, big.Zero(), builtin.MethodsStake.ClaimUnbonded, &stakerID)
+	assert.Equal(t, big.Sum(balanceBefore, reward, deposit), actorBalance(t, v, staker))
+	assert.Equal(t, big.Zero(), stakeSummary(t, v).TotalUnbondingPrincipal)
+
+	checkVMInvariants(t, v)
+}
+
+func TestStakeGenesisRewardCurve(t *testing.T) {
+	ctx := context.Background()
+	budget := big.Mul(big.NewInt(10), vm.FIL)
+	curve := stake.RewardCurveParams{
+		Curve:                 stake.RewardCurveFixedBudget,
+		InitialRewardPerRound: big.Zero(),
+		TargetStakeRatio:      big.Zero(),
+		BudgetPerRound:        budget,
+	}
+	v := vm.NewVMWithStakeParams(ctx, t, ipld.NewBlockStoreInMemory(), vm.DefaultStakeParams(), &curve)
+
+	var st stake.State
+	require.NoError(t, v.GetState(builtin.StakeActorAddr, &st))
+	assert.Equal(t, curve, st.RewardCurve)
+
+	addrs := vm.CreateAccounts(ctx, t, v, 1, big.Mul(big.NewInt(10_000), vm.FIL), 93837778)
+	vm.ApplyOk(t, v, addrs[0], builtin.StakeActorAddr, big.Mul(big.NewInt(1_000), vm.FIL), builtin.MethodsStake.Deposit, nil)
+
+	// rounds pay the genesis budget once the deposit has matured
+	v = advanceStakeRounds(t, v, vm.DefaultStakeParams().MaturePeriod/vm.DefaultStakeParams().RoundPeriod+1)
+	require.NoError(t, v.GetState(builtin.StakeActorAddr, &st))
+	assert.Equal(t, budget, st.LastRoundReward)
+	checkVMInvariants(t, v)
+}
+
+func TestTokenSingleton(t *testing.T) {
+	ctx := context.Background()
+	v := vm.NewVMWithSingletons(ctx, t, ipld.NewBlockStoreInMemory())
+	addrs := vm.CreateAccounts(ctx, t, v, 2, big.Mul(big.NewInt(10_000), vm.FIL), 93837778)
+	creator, receiver := addrs[0], addrs[1]
+	creatorID, found := v.NormalizeAddress(creator)
+	require.True(t, found)
+	receiverID, found := v.NormalizeAddress(receiver)
+	require.True(t, found)
+
+	vm.ApplyOk(t, v, creator, builtin.TokenActorAddr, big.Zero(), builtin.MethodsToken.CreateWithMetadata, &token.CreateWithMetadataParams{
+		ValueInit: big.NewInt(100),
+		TokenURI:  "token 1",
+		Metadata:  token.TokenMetadata{Name: "Token", Symbol: "TKN", MaxSupply: big.Zero()},
+	})
+	vm.ApplyOk(t, v, creator, builtin.TokenActorAddr, big.Zero(), builtin.MethodsToken.SafeTransferFrom, &token.SafeTransferFromParams{
+		AddrFrom: creatorID,
+		AddrTo:   receiverID,
+		TokenID:  big.NewInt(1),
+		Value:    big.NewInt(40),
+	})
+
+	ret := vm.ApplyOk(t, v, receiver, builtin.TokenActorAddr, big.Zero(), builtin.MethodsToken.BalanceOf, &token.BalanceOfParams{
+		AddrOwner: receiverID,
+		TokenID:   big.NewInt(1),
+	})
+	assert.Equal(t, big.NewInt(40), ret.(*token.BalanceOfResults).Balance)
+
+	// Trigger cron to keep reward accounting correct
+	vm.ApplyOk(t, v, builtin.SystemActorAddr, builtin.CronActorAddr, big.Zero(), builtin.MethodsCron.EpochTick, nil)
+	checkVMInvariants(t, v)
+}
+
+// Advances the VM by the given number of stake rounds, running cron at the start of each round.
+func advanceStakeRounds(t *testing.T, v *vm.VM, rounds abi.ChainEpoch) *vm.VM {
+	var st stake.State
+	require.NoError(t, v.GetState(builtin.StakeActorAddr, &st))
+
+	epoch := v.GetEpoch()
+	for i := abi.ChainEpoch(1); i <= rounds; i++ {
+		var err error
+		v, err = v.WithEpoch(epoch + i*st.RoundPeriod)
+		require.NoError(t, err)
+		vm.ApplyOk(t, v, builtin.SystemActorAddr, builtin.CronActorAddr, big.Zero(), builtin.MethodsCron.EpochTick, nil)
+	}
+	return v
+}
+
+func actorBalance(t *testing.T, v *vm.VM, a addr.Address) abi.TokenAmount {
+	actor, found, err := v.GetActor(a)
+	require.NoError(t, err)
+	require.True(t, found)
+	return actor.Balance
+}
+
+func stakeSummary(t *testing.T, v *vm.VM) *stake.StateSummary {
+	var st stake.State
+	require.NoError(t, v.GetState(builtin.StakeActorAddr, &st))
+	stateTree, err := v.GetStateTree()
+	require.NoError(t, err)
+	actor, found, err := v.GetActor(builtin.StakeActorAddr)
+	require.NoError(t, err)
+	require.True(t, found)
+
+	summary, msgs := stake.CheckStateInvariants(&st, stateTree.Store, actor.Balance)
+	assert.True(t, msgs.IsEmpty(), strings.Join(msgs.Messages(), "\n"))
+	return summary
+}
+
+func checkVMInvariants(t *testing.T, v *vm.VM) {
+	stateTree, err := v.GetStateTree()
+	require.NoError(t, err)
+	totalBalance, err := v.GetTotalActorBalance()
+	require.NoError(t, err)
+	msgs, err := states.CheckStateInvariants(stateTree, totalBalance, v.GetEpoch())
+	require.NoError(t, err)
+	assert.True(t, msgs.IsEmpty(), strings.Join(msgs.Messages(), "\n"))
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/filecoin-project/specs-actors/v3/actors/builtin/system"
 	"github.com/filecoin-project/specs-actors/v3/actors/builtin/token"
 	"github.com/filecoin-project/specs-actors/v3/actors/builtin/verifreg"
+	"github.com/filecoin-project/specs-actors/v3/actors/migration/nv9"
 	"github.com/filecoin-project/specs-actors/v3/actors/util/smoothing"
 )
 
@@ -150,7 +151,13 @@ func main() {
 		stake.LockedPrincipals{},
 		stake.LockedPrincipal{},
 		stake.VestingFunds{},
-		// stake.VestingFund{},
+		stake.VestingFund{},
+		stake.Delegation{},
+		stake.Unbondings{},
+		stake.Unbonding{},
+		stake.Proposal{},
+		stake.PendingProposal{},
+		stake.RewardCurveParams{},
 
 		// method params
 		// stake.ConstructorParams{},
@@ -161,6 +168,21 @@ func main() {
 		// stake.ChangeMinDepositAmountParams{},
 		// stake.ChangeMaxRewardsPerRoundParams{},
 		// stake.ChangeInflationFactorParams{},
+		stake.DepositForParams{},
+		stake.WithdrawDelegatedPrincipalParams{},
+		stake.RestakeRewardParams{},
+		stake.SetAutoCompoundParams{},
+		stake.SlashParams{},
+		stake.GetStakerInfoReturn{},
+		stake.GetStakeParamsReturn{},
+		stake.GetTotalStakePowerReturn{},
+		stake.ChangeUnbondingPeriodParams{},
+		stake.ChangeProposalDelayParams{},
+		stake.CancelProposalParams{},
+		stake.GetProposalsReturn{},
+		stake.ProposeReturn{},
+		stake.ChangeRewardVestingSpecParams{},
+		stake.ChangeRewardCurveParams{},
 	); err != nil {
 		panic(err)
 	}
@@ -171,9 +193,15 @@ func main() {
 		token.AddrTokenAmountMap{},
 		token.AddrApproveMap{},
 		token.TokenURI{},
+		token.TransferEvent{},
+		token.URIEvent{},
+		token.TokenEvent{},
+		token.EpochEvents{},
+		token.TokenMetadata{},
 
 		// method params
 		token.CreateTokenParams{},
+		token.CreateWithMetadataParams{},
 		token.MintBatchTokenParams{},
 		token.BalanceOfParams{},
 		token.BalanceOfResults{},
@@ -186,6 +214,27 @@ func main() {
 		token.SetApproveForAllParams{},
 		token.IsApproveForAllParams{},
 		token.IsApprovedForAllResults{},
+		token.TotalSupplyParams{},
+		token.TotalSupplyResults{},
+		token.TotalSupplyBatchParams{},
+		token.TotalSupplyBatchResults{},
+		token.BurnParams{},
+		token.BurnBatchParams{},
+		token.ApproveParams{},
+		token.AllowanceParams{},
+		token.AllowanceResults{},
+		token.OnTokenReceivedParams{},
+		token.OnBatchTokenReceivedParams{},
+		token.GetEventsParams{},
+		token.GetMetadataParams{},
+		token.TransferCreatorParams{},
+		token.MinterParams{},
+		token.GetCreatorParams{},
+		token.GetCreatorResults{},
+		token.GetMintersParams{},
+		token.GetMintersResults{},
+		token.TokensOfParams{},
+		token.TokensOfResults{},
 	); err != nil {
 		panic(err)
 	}
@@ -225,6 +274,8 @@ func main() {
 		miner.WorkerKeyChange{},
 		miner.VestingFunds{},
 		miner.VestingFund{},
+		miner.VestSpec{},
+		miner.StakePowerReturn{},
 		// method params and returns
 		// miner.ConstructorParams{}, // in power actor
 		//miner.SubmitWindowedPoStParams{}, // Aliased from v0
@@ -273,4 +324,11 @@ func main() {
 		panic(err)
 	}
 
+	// Migrations
+	if err := gen.WriteTupleEncodersToFile("./actors/migration/nv9/cbor_gen.go", "nv9",
+		nv9.PriorTokenState{},
+	); err != nil {
+		panic(err)
+	}
+
 }
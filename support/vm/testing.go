@@ -26,7 +26,9 @@ import (
 	"github.com/filecoin-project/specs-actors/v3/actors/builtin/miner"
 	"github.com/filecoin-project/specs-actors/v3/actors/builtin/power"
 	"github.com/filecoin-project/specs-actors/v3/actors/builtin/reward"
+	"github.com/filecoin-project/specs-actors/v3/actors/builtin/stake"
 	"github.com/filecoin-project/specs-actors/v3/actors/builtin/system"
+	"github.com/filecoin-project/specs-actors/v3/actors/builtin/token"
 	"github.com/filecoin-project/specs-actors/v3/actors/builtin/verifreg"
 	"github.com/filecoin-project/specs-actors/v3/actors/runtime"
 	"github.com/filecoin-project/specs-actors/v3/actors/states"
@@ -37,6 +39,10 @@ import (
 
 var FIL = big.NewInt(1e18)
 var VerifregRoot address.Address
+var StakeRoot address.Address
+
+// Balance with which the stake actor is funded at genesis, and from which staking rewards are paid.
+var StakeRewardPool = big.Mul(big.NewInt(1_000_000), FIL)
 
 func init() {
 	var err error
@@ -44,14 +50,38 @@ func init() {
 	if err != nil {
 		panic("could not create id address 80")
 	}
+	StakeRoot, err = address.NewIDAddress(81)
+	if err != nil {
+		panic("could not create id address 81")
+	}
 }
 
 //
 // Genesis like setup
 //
 
-// Creates a new VM and initializes all singleton actors plus a root verifier account.
+// Stake actor parameters used by NewVMWithSingletons.
+func DefaultStakeParams() *stake.ConstructorParams {
+	return &stake.ConstructorParams{
+		RootKey:               StakeRoot,
+		MaturePeriod:          builtin.EpochsInDay,
+		RoundPeriod:           builtin.EpochsInHour,
+		PrincipalLockDuration: 7 * builtin.EpochsInDay,
+		FirstRoundEpoch:       0,
+		MinDepositAmount:      FIL,
+		MaxRewardPerRound:     big.Mul(big.NewInt(100), FIL),
+		InflationFactor:       big.NewInt(10),
+	}
+}
+
+// Creates a new VM and initializes all singleton actors plus root verifier and stake admin accounts.
 func NewVMWithSingletons(ctx context.Context, t testing.TB, bs ipldcbor.IpldBlockstore) *VM {
+	return NewVMWithStakeParams(ctx, t, bs, DefaultStakeParams(), nil)
+}
+
+// Like NewVMWithSingletons, but constructs the stake actor with the given parameters.
+// A nil rewardCurve starts the stake actor on the linear reward curve.
+func NewVMWithStakeParams(ctx context.Context, t testing.TB, bs ipldcbor.IpldBlockstore, stakeParams *stake.ConstructorParams, rewardCurve *stake.RewardCurveParams) *VM {
 	lookup := map[cid.Cid]runtime.VMActor{}
 	for _, ba := range exported.BuiltinActors() {
 		lookup[ba.Code()] = ba
@@ -89,6 +119,15 @@ func NewVMWithSingletons(ctx context.Context, t testing.TB, bs ipldcbor.IpldBloc
 	// burnt funds
 	initializeActor(ctx, t, vm, &account.State{Address: builtin.BurntFundsActorAddr}, builtin.AccountActorCodeID, builtin.BurntFundsActorAddr, big.Zero())
 
+	initializeActor(ctx, t, vm, &account.State{Address: StakeRoot}, builtin.AccountActorCodeID, StakeRoot, big.Zero())
+	stakeState, err := stake.ConstructState(store, stakeParams, rewardCurve)
+	require.NoError(t, err)
+	initializeActor(ctx, t, vm, stakeState, builtin.StakeActorCodeID, builtin.StakeActorAddr, StakeRewardPool)
+
+	tokenState, err := token.ConstructState(store)
+	require.NoError(t, err)
+	initializeActor(ctx, t, vm, tokenState, builtin.TokenActorCodeID, builtin.TokenActorAddr, big.Zero())
+
 	_, err = vm.checkpoint()
 	require.NoError(t, err)
 